@@ -9,6 +9,7 @@ import (
 	Cli "github.com/docker/docker/cli"
 	flag "github.com/docker/docker/pkg/mflag"
 	"github.com/docker/docker/pkg/signal"
+	"github.com/docker/docker/pkg/term"
 )
 
 // CmdAttach attaches to a running container.
@@ -18,11 +19,21 @@ func (cli *DockerCli) CmdAttach(args ...string) error {
 	cmd := Cli.Subcmd("attach", []string{"CONTAINER"}, Cli.DockerCommands["attach"].Description, true)
 	noStdin := cmd.Bool([]string{"-no-stdin"}, false, "Do not attach STDIN")
 	proxy := cmd.Bool([]string{"-sig-proxy"}, true, "Proxy all received signals to the process")
+	detachKeys := cmd.String([]string{"-detach-keys"}, "", "Override the key sequence for detaching a container")
 
 	cmd.Require(flag.Exact, 1)
 
 	cmd.ParseFlags(args, true)
 
+	if *detachKeys != "" {
+		if _, err := term.ToBytes(*detachKeys); err != nil {
+			return err
+		}
+		if err := cli.checkDaemonAPIVersion("--detach-keys", minDetachKeysAPIVersion); err != nil {
+			return err
+		}
+	}
+
 	c, err := cli.client.ContainerInspect(cmd.Arg(0))
 	if err != nil {
 		return err
@@ -52,6 +63,7 @@ func (cli *DockerCli) CmdAttach(args ...string) error {
 		Stdin:       !*noStdin && c.Config.OpenStdin,
 		Stdout:      true,
 		Stderr:      true,
+		DetachKeys:  *detachKeys,
 	}
 
 	var in io.ReadCloser
@@ -0,0 +1,15 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCmdAttachInvalidDetachKeys(t *testing.T) {
+	// The keys are validated before the daemon is contacted.
+	cli := &DockerCli{}
+	err := cli.CmdAttach("--detach-keys", "ctrl-1", "container")
+	if err == nil || !strings.Contains(err.Error(), `invalid key "ctrl-1" in key sequence "ctrl-1"`) {
+		t.Fatalf("Expected an error about the key sequence, got %v", err)
+	}
+}
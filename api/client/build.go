@@ -3,9 +3,11 @@ package client
 import (
 	"archive/tar"
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -23,6 +25,7 @@ import (
 	"github.com/docker/docker/pkg/fileutils"
 	"github.com/docker/docker/pkg/gitutils"
 	"github.com/docker/docker/pkg/httputils"
+	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/jsonmessage"
 	flag "github.com/docker/docker/pkg/mflag"
 	"github.com/docker/docker/pkg/progress"
@@ -35,6 +38,29 @@ import (
 	"github.com/docker/docker/utils"
 )
 
+const (
+	progressModeAuto  = "auto"
+	progressModePlain = "plain"
+	progressModeJSON  = "json"
+)
+
+// The kinds of build contexts, told apart by the context argument of
+// docker build.
+const (
+	buildContextStdin = "stdin"
+	buildContextGit   = "git"
+	buildContextURL   = "url"
+	buildContextLocal = "local"
+)
+
+// builtImageRegexp matches the message of the daemon which reports the ID of
+// the built image.
+var builtImageRegexp = regexp.MustCompile(`^Successfully built ([0-9a-f]+)\s*$`)
+
+// archiveExtensions are the extensions of the remote contexts which must be
+// tar archives.
+var archiveExtensions = []string{".tar", ".tar.gz", ".tgz", ".tar.bz2", ".tbz2", ".tar.xz", ".txz"}
+
 // CmdBuild builds a new image from the source code at a given path.
 //
 // If '-' is provided instead of a path or URL, Docker will build an image from either a Dockerfile or tar archive read from STDIN.
@@ -61,7 +87,13 @@ func (cli *DockerCli) CmdBuild(args ...string) error {
 	flCgroupParent := cmd.String([]string{"-cgroup-parent"}, "", "Optional parent cgroup for the container")
 	flBuildArg := opts.NewListOpts(opts.ValidateEnv)
 	cmd.Var(&flBuildArg, []string{"-build-arg"}, "Set build-time variables")
+	flBuildArgFile := opts.NewListOpts(nil)
+	cmd.Var(&flBuildArgFile, []string{"-build-arg-file"}, "Read in a file of build-time variables")
+	flContextExcludes := opts.NewListOpts(nil)
+	cmd.Var(&flContextExcludes, []string{"-build-context-exclude"}, "Exclude files matching the pattern from the build context")
 	isolation := cmd.String([]string{"-isolation"}, "", "Container isolation level")
+	progressMode := cmd.String([]string{"-progress"}, progressModeAuto, "Set type of progress output (auto, plain, json)")
+	imageIDFile := cmd.String([]string{"-iidfile"}, "", "Write the image ID to the file")
 
 	ulimits := make(map[string]*ulimit.Ulimit)
 	flUlimits := opts.NewUlimitOpt(&ulimits)
@@ -74,14 +106,35 @@ func (cli *DockerCli) CmdBuild(args ...string) error {
 
 	cmd.ParseFlags(args, true)
 
+	switch *progressMode {
+	case progressModeAuto, progressModePlain, progressModeJSON:
+	default:
+		return fmt.Errorf("%q is not a valid value for --progress", *progressMode)
+	}
+
+	// Remove the ID of a previous build, so that the file only exists if
+	// this build succeeds.
+	if *imageIDFile != "" {
+		if err := os.Remove(*imageIDFile); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("Failed to remove the image ID file: %v", err)
+		}
+	}
+
 	var (
 		context  io.ReadCloser
 		isRemote bool
 		err      error
 	)
 
-	_, err = exec.LookPath("git")
-	hasGit := err == nil
+	buildArgs, err := readBuildArgs(flBuildArgFile.GetAll(), flBuildArg.GetAll())
+	if err != nil {
+		return err
+	}
+
+	contextExcludes, err := validateContextExcludes(flContextExcludes.GetAll())
+	if err != nil {
+		return err
+	}
 
 	specifiedContext := cmd.Arg(0)
 
@@ -91,12 +144,18 @@ func (cli *DockerCli) CmdBuild(args ...string) error {
 		relDockerfile string
 	)
 
-	switch {
-	case specifiedContext == "-":
+	switch buildContextKind(specifiedContext) {
+	case buildContextStdin:
+		if cli.isTerminalIn {
+			return fmt.Errorf("unable to prepare context: STDIN is a terminal, pipe a tar archive or a Dockerfile to build from STDIN")
+		}
 		tempDir, relDockerfile, err = getContextFromReader(cli.in, *dockerfileName)
-	case urlutil.IsGitURL(specifiedContext) && hasGit:
+	case buildContextGit:
+		if _, err := exec.LookPath("git"); err != nil {
+			return fmt.Errorf("unable to prepare context: git is required to build from %s", specifiedContext)
+		}
 		tempDir, relDockerfile, err = getContextFromGitURL(specifiedContext, *dockerfileName)
-	case urlutil.IsURL(specifiedContext):
+	case buildContextURL:
 		tempDir, relDockerfile, err = getContextFromURL(cli.out, specifiedContext, *dockerfileName)
 	default:
 		contextDir, relDockerfile, err = getContextFromLocalDir(specifiedContext, *dockerfileName)
@@ -138,6 +197,7 @@ func (cli *DockerCli) CmdBuild(args ...string) error {
 			return err
 		}
 	}
+	excludes = append(excludes, contextExcludes...)
 
 	if err := utils.ValidateContextDirectory(contextDir, excludes); err != nil {
 		return fmt.Errorf("Error checking context: '%s'.", err)
@@ -171,7 +231,15 @@ func (cli *DockerCli) CmdBuild(args ...string) error {
 	context = replaceDockerfileTarWrapper(context, newDockerfile, relDockerfile)
 
 	// Setup an upload progress bar
-	progressOutput := streamformatter.NewStreamFormatter().NewProgressOutput(cli.out, true)
+	var progressOutput progress.Output
+	switch *progressMode {
+	case progressModePlain:
+		progressOutput = plainProgressOutput{cli.out}
+	case progressModeJSON:
+		progressOutput = streamformatter.NewJSONStreamFormatter().NewProgressOutput(cli.out, false)
+	default:
+		progressOutput = streamformatter.NewStreamFormatter().NewProgressOutput(cli.out, true)
+	}
 
 	var body io.Reader = progress.NewProgressReader(context, progressOutput, 0, "", "Sending build context to Docker daemon")
 
@@ -223,7 +291,7 @@ func (cli *DockerCli) CmdBuild(args ...string) error {
 		ShmSize:        *flShmSize,
 		Dockerfile:     relDockerfile,
 		Ulimits:        flUlimits.GetList(),
-		BuildArgs:      flBuildArg.GetAll(),
+		BuildArgs:      buildArgs,
 		AuthConfigs:    cli.configFile.AuthConfigs,
 	}
 
@@ -232,7 +300,23 @@ func (cli *DockerCli) CmdBuild(args ...string) error {
 		return err
 	}
 
-	err = jsonmessage.DisplayJSONMessagesStream(response.Body, cli.out, cli.outFd, cli.isTerminalOut)
+	var (
+		responseBody io.ReadCloser = response.Body
+		imageIDChan  <-chan string
+	)
+	if *imageIDFile != "" {
+		responseBody, imageIDChan = builtImageIDStream(response.Body)
+		defer responseBody.Close()
+	}
+
+	switch *progressMode {
+	case progressModePlain:
+		err = jsonmessage.DisplayJSONMessagesStream(responseBody, cli.out, cli.outFd, false)
+	case progressModeJSON:
+		err = copyJSONMessagesStream(responseBody, cli.out)
+	default:
+		err = jsonmessage.DisplayJSONMessagesStream(responseBody, cli.out, cli.outFd, cli.isTerminalOut)
+	}
 	if err != nil {
 		if jerr, ok := err.(*jsonmessage.JSONError); ok {
 			// If no error code is set, default to 1
@@ -241,6 +325,7 @@ func (cli *DockerCli) CmdBuild(args ...string) error {
 			}
 			return Cli.StatusError{Status: jerr.Message, StatusCode: jerr.Code}
 		}
+		return err
 	}
 
 	// Windows: show error message about modified file permissions.
@@ -256,9 +341,103 @@ func (cli *DockerCli) CmdBuild(args ...string) error {
 		}
 	}
 
+	if *imageIDFile != "" {
+		responseBody.Close()
+		return cli.writeImageIDFile(*imageIDFile, <-imageIDChan)
+	}
+	return nil
+}
+
+// builtImageIDStream returns a reader which passes the JSON messages of a
+// build through from in, and a channel which receives the ID reported by the
+// daemon once the reader is closed. The ID is empty if the daemon reported
+// none.
+func builtImageIDStream(in io.Reader) (io.ReadCloser, <-chan string) {
+	r, w := io.Pipe()
+	imageIDChan := make(chan string, 1)
+
+	go func() {
+		var imageID string
+		dec := json.NewDecoder(r)
+		for {
+			var jm jsonmessage.JSONMessage
+			if err := dec.Decode(&jm); err != nil {
+				break
+			}
+			if matches := builtImageRegexp.FindStringSubmatch(jm.Stream); len(matches) == 2 {
+				imageID = matches[1]
+			}
+		}
+		// Keep reading after a decoding error, so that the messages still
+		// pass through.
+		io.Copy(ioutil.Discard, r)
+		imageIDChan <- imageID
+	}()
+
+	return ioutils.NewReadCloserWrapper(io.TeeReader(in, w), w.Close), imageIDChan
+}
+
+// writeImageIDFile resolves the short ID reported by the daemon to the full ID
+// of the built image and writes it to the file.
+func (cli *DockerCli) writeImageIDFile(path, shortID string) error {
+	if shortID == "" {
+		return fmt.Errorf("Failed to write the image ID file: the daemon didn't report the ID of the built image")
+	}
+	image, _, err := cli.client.ImageInspectWithRaw(shortID, false)
+	if err != nil {
+		return fmt.Errorf("Failed to write the image ID file: %v", err)
+	}
+	if err := ioutil.WriteFile(path, []byte(image.ID), 0644); err != nil {
+		return fmt.Errorf("Failed to write the image ID file: %v", err)
+	}
 	return nil
 }
 
+// plainProgressOutput is a progress.Output which only writes a line once a
+// transfer is complete, so it never has to overwrite the current line.
+type plainProgressOutput struct {
+	out io.Writer
+}
+
+func (o plainProgressOutput) WriteProgress(p progress.Progress) error {
+	if p.Message != "" {
+		_, err := fmt.Fprintln(o.out, p.Message)
+		return err
+	}
+	if !p.LastUpdate {
+		return nil
+	}
+	_, err := fmt.Fprintf(o.out, "%s %s\n", p.Action, units.HumanSize(float64(p.Current)))
+	return err
+}
+
+// copyJSONMessagesStream copies the JSON messages read from in to out, one
+// message per line. It stops at the first message with an error and returns
+// that error.
+func copyJSONMessagesStream(in io.Reader, out io.Writer) error {
+	dec := json.NewDecoder(in)
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if _, err := fmt.Fprintf(out, "%s\n", raw); err != nil {
+			return err
+		}
+
+		var jm jsonmessage.JSONMessage
+		if err := json.Unmarshal(raw, &jm); err != nil {
+			return err
+		}
+		if jm.Error != nil {
+			return jm.Error
+		}
+	}
+}
+
 // validateTag checks if the given image name can be resolved.
 func validateTag(rawRepo string) (string, error) {
 	ref, err := reference.ParseNamed(rawRepo)
@@ -273,6 +452,96 @@ func validateTag(rawRepo string) (string, error) {
 	return rawRepo, nil
 }
 
+// readBuildArgs reads the build-time variables from the given files and
+// merges them with the ones set on the command line. Variables set on the
+// command line take precedence over the ones read from a file.
+func readBuildArgs(files []string, flagArgs []string) ([]string, error) {
+	var fileArgs []string
+	for _, filename := range files {
+		args, err := parseBuildArgFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		fileArgs = append(fileArgs, args...)
+	}
+	return mergeBuildArgs(fileArgs, flagArgs), nil
+}
+
+// parseBuildArgFile reads a file of KEY=VALUE lines. Blank lines and lines
+// starting with '#' are ignored. A KEY without a value takes its value from
+// the current environment, the same way --build-arg does.
+func parseBuildArgFile(filename string) ([]string, error) {
+	fh, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+
+	var args []string
+	scanner := bufio.NewScanner(fh)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key := strings.SplitN(line, "=", 2)[0]
+		if key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("invalid build-arg in %s at line %d: %q", filename, lineNum, line)
+		}
+		arg, err := opts.ValidateEnv(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid build-arg in %s at line %d: %v", filename, lineNum, err)
+		}
+		args = append(args, arg)
+	}
+	return args, scanner.Err()
+}
+
+// mergeBuildArgs merges two lists of build-time variables, dropping the
+// entries of base that are overridden by a variable of the same name in
+// overrides.
+func mergeBuildArgs(base, overrides []string) []string {
+	overridden := make(map[string]bool, len(overrides))
+	for _, arg := range overrides {
+		overridden[strings.SplitN(arg, "=", 2)[0]] = true
+	}
+
+	var merged []string
+	for _, arg := range base {
+		if !overridden[strings.SplitN(arg, "=", 2)[0]] {
+			merged = append(merged, arg)
+		}
+	}
+	return append(merged, overrides...)
+}
+
+// validateContextExcludes checks the patterns given with
+// --build-context-exclude and cleans them up the same way the patterns read
+// from .dockerignore are. Patterns are relative to the context directory, so
+// absolute paths are rejected.
+func validateContextExcludes(patterns []string) ([]string, error) {
+	var excludes []string
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if filepath.IsAbs(strings.TrimPrefix(pattern, "!")) {
+			return nil, fmt.Errorf("invalid build context exclude %q: pattern must be relative to the context directory", pattern)
+		}
+		if _, err := filepath.Match(strings.TrimPrefix(pattern, "!"), ""); err != nil {
+			return nil, fmt.Errorf("invalid build context exclude %q: %v", pattern, err)
+		}
+		excludes = append(excludes, pattern)
+	}
+
+	excludes, _, _, err := fileutils.CleanPatterns(excludes)
+	if err != nil {
+		return nil, err
+	}
+	return excludes, nil
+}
+
 // isUNC returns true if the path is UNC (one starting \\). It always returns
 // false on Linux.
 func isUNC(path string) bool {
@@ -379,6 +648,38 @@ func writeToFile(r io.Reader, filename string) error {
 	return nil
 }
 
+// buildContextKind returns the kind of build context given by the context
+// argument of docker build: "-" is a tar archive or a Dockerfile read from
+// STDIN, git URLs are cloned, other HTTP(S) URLs are downloaded, and anything
+// else is a local directory.
+func buildContextKind(specifiedContext string) string {
+	switch {
+	case specifiedContext == "-":
+		return buildContextStdin
+	case urlutil.IsGitURL(specifiedContext):
+		return buildContextGit
+	case urlutil.IsURL(specifiedContext):
+		return buildContextURL
+	default:
+		return buildContextLocal
+	}
+}
+
+// isArchiveURL returns whether the path of the URL ends with the extension of
+// a tar archive.
+func isArchiveURL(remoteURL string) bool {
+	u, err := url.Parse(remoteURL)
+	if err != nil {
+		return false
+	}
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(strings.ToLower(u.Path), ext) {
+			return true
+		}
+	}
+	return false
+}
+
 // getContextFromReader will read the contents of the given reader as either a
 // Dockerfile or tar archive to be extracted to a temporary directory used as
 // the context directory. Returns the absolute path to the temporary context
@@ -435,7 +736,8 @@ func getContextFromGitURL(gitURL, dockerfileName string) (absContextDir, relDock
 // archive and stored in a temporary directory used as the context directory.
 // Returns the absolute path to the temporary context directory, the relative
 // path of the dockerfile in that context directory, and a non-nil error on
-// success.
+// success. A remote resource whose path has the extension of a tar archive
+// must be one.
 func getContextFromURL(out io.Writer, remoteURL, dockerfileName string) (absContextDir, relDockerfile string, err error) {
 	response, err := httputils.Download(remoteURL)
 	if err != nil {
@@ -447,7 +749,18 @@ func getContextFromURL(out io.Writer, remoteURL, dockerfileName string) (absCont
 	// Pass the response body through a progress reader.
 	progReader := progress.NewProgressReader(response.Body, progressOutput, response.ContentLength, "", fmt.Sprintf("Downloading build context from remote url: %s", remoteURL))
 
-	return getContextFromReader(progReader, dockerfileName)
+	buf := bufio.NewReader(progReader)
+	if isArchiveURL(remoteURL) {
+		magic, err := buf.Peek(archive.HeaderSize)
+		if err != nil && err != io.EOF {
+			return "", "", fmt.Errorf("unable to download remote context %s: %v", remoteURL, err)
+		}
+		if !archive.IsArchive(magic) {
+			return "", "", fmt.Errorf("remote context %s is not a tar archive", remoteURL)
+		}
+	}
+
+	return getContextFromReader(buf, dockerfileName)
 }
 
 // getContextFromLocalDir uses the given local directory as context for a
@@ -0,0 +1,249 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/pkg/progress"
+)
+
+func tmpBuildArgFile(t *testing.T, content string) string {
+	tmpFile, err := ioutil.TempFile("", "build-arg-file-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmpFile.Close()
+
+	if _, err := tmpFile.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+	return tmpFile.Name()
+}
+
+func TestParseBuildArgFile(t *testing.T) {
+	os.Setenv("DOCKER_TEST_BUILD_ARG", "from-env")
+	defer os.Unsetenv("DOCKER_TEST_BUILD_ARG")
+
+	content := `# a comment
+HTTP_PROXY=http://10.20.30.2:1234
+
+  VERSION=1.0
+EMPTY=
+DOCKER_TEST_BUILD_ARG
+`
+	filename := tmpBuildArgFile(t, content)
+	defer os.Remove(filename)
+
+	args, err := parseBuildArgFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"HTTP_PROXY=http://10.20.30.2:1234", "VERSION=1.0", "EMPTY=", "DOCKER_TEST_BUILD_ARG=from-env"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Fatalf("Expected %v, got %v", expected, args)
+	}
+}
+
+func TestParseBuildArgFileInvalid(t *testing.T) {
+	filename := tmpBuildArgFile(t, "VERSION=1.0\n\n=value\n")
+	defer os.Remove(filename)
+
+	_, err := parseBuildArgFile(filename)
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Fatalf("Expected an error at line 3, got %v", err)
+	}
+
+	filename = tmpBuildArgFile(t, "BAD KEY=value\n")
+	defer os.Remove(filename)
+
+	_, err = parseBuildArgFile(filename)
+	if err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Fatalf("Expected an error at line 1, got %v", err)
+	}
+}
+
+func TestMergeBuildArgs(t *testing.T) {
+	merged := mergeBuildArgs([]string{"A=1", "B=2", "C"}, []string{"B=3", "C=4"})
+	expected := []string{"A=1", "B=3", "C=4"}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Fatalf("Expected %v, got %v", expected, merged)
+	}
+}
+
+func TestValidateContextExcludes(t *testing.T) {
+	excludes, err := validateContextExcludes([]string{" *.log ", "", "tmp/../cache", "!cache/keep"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"*.log", "cache", "!cache/keep"}
+	if !reflect.DeepEqual(excludes, expected) {
+		t.Fatalf("Expected %v, got %v", expected, excludes)
+	}
+
+	invalid := []string{"/etc/passwd", "!/etc", "[", "!"}
+	for _, pattern := range invalid {
+		if _, err := validateContextExcludes([]string{pattern}); err == nil {
+			t.Fatalf("Expected %q to be an invalid exclude pattern", pattern)
+		}
+	}
+}
+
+func TestCopyJSONMessagesStream(t *testing.T) {
+	in := `{"stream":"Step 1 : FROM busybox\n"}
+{"stream":" ---\u003e 769b9341d937\n"}{"errorDetail":{"message":"failed"},"error":"failed"}
+{"stream":"never copied\n"}`
+	out := bytes.NewBuffer(nil)
+
+	err := copyJSONMessagesStream(strings.NewReader(in), out)
+	if err == nil || err.Error() != "failed" {
+		t.Fatalf("Expected the error of the stream, got %v", err)
+	}
+	expected := `{"stream":"Step 1 : FROM busybox\n"}
+{"stream":" ---\u003e 769b9341d937\n"}
+{"errorDetail":{"message":"failed"},"error":"failed"}
+`
+	if out.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestPlainProgressOutput(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	output := plainProgressOutput{out}
+
+	output.WriteProgress(progress.Progress{Action: "Sending build context to Docker daemon", Current: 512})
+	output.WriteProgress(progress.Progress{Action: "Sending build context to Docker daemon", Current: 2048, LastUpdate: true})
+
+	expected := "Sending build context to Docker daemon 2.048 kB\n"
+	if out.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestBuildContextKind(t *testing.T) {
+	cases := map[string]string{
+		"-":                                   buildContextStdin,
+		"github.com/docker/docker":            buildContextGit,
+		"git://github.com/docker/docker":      buildContextGit,
+		"git@github.com:docker/docker.git":    buildContextGit,
+		"https://example.com/repo.git#master": buildContextGit,
+		"https://example.com/context.tar.gz":  buildContextURL,
+		"http://example.com/Dockerfile":       buildContextURL,
+		".":                                   buildContextLocal,
+		"/tmp/context":                        buildContextLocal,
+		"example.com/context.tar.gz":          buildContextLocal,
+	}
+	for specifiedContext, expected := range cases {
+		if kind := buildContextKind(specifiedContext); kind != expected {
+			t.Errorf("Expected %s to be a %s context, got %s", specifiedContext, expected, kind)
+		}
+	}
+}
+
+func TestIsArchiveURL(t *testing.T) {
+	for _, u := range []string{"https://example.com/context.tar", "https://example.com/context.tar.gz?token=abc", "http://example.com/CONTEXT.TGZ", "http://example.com/a/context.tar.xz"} {
+		if !isArchiveURL(u) {
+			t.Errorf("Expected %s to be an archive URL", u)
+		}
+	}
+	for _, u := range []string{"https://example.com/Dockerfile", "https://example.com/context.gz", "https://example.com/?f=context.tar"} {
+		if isArchiveURL(u) {
+			t.Errorf("Expected %s not to be an archive URL", u)
+		}
+	}
+}
+
+func TestGetContextFromURL(t *testing.T) {
+	var context bytes.Buffer
+	gz := gzip.NewWriter(&context)
+	tw := tar.NewWriter(gz)
+	dockerfile := []byte("FROM busybox\n")
+	if err := tw.WriteHeader(&tar.Header{Name: "Dockerfile", Mode: 0644, Size: int64(len(dockerfile))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(dockerfile); err != nil {
+		t.Fatal(err)
+	}
+	tw.Close()
+	gz.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/context.tar.gz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(context.Bytes())
+	})
+	mux.HandleFunc("/broken.tar.gz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>Not an archive</html>"))
+	})
+	mux.HandleFunc("/Dockerfile", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(dockerfile)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	for _, path := range []string{"/context.tar.gz", "/Dockerfile"} {
+		contextDir, relDockerfile, err := getContextFromURL(ioutil.Discard, server.URL+path, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(contextDir)
+		content, err := ioutil.ReadFile(filepath.Join(contextDir, relDockerfile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(content, dockerfile) {
+			t.Fatalf("Expected the Dockerfile of %s to be %q, got %q", path, dockerfile, content)
+		}
+	}
+
+	if _, _, err := getContextFromURL(ioutil.Discard, server.URL+"/broken.tar.gz", ""); err == nil || !strings.Contains(err.Error(), "is not a tar archive") {
+		t.Fatalf("Expected an error about the archive, got %v", err)
+	}
+	if _, _, err := getContextFromURL(ioutil.Discard, server.URL+"/missing.tar.gz", ""); err == nil || !strings.Contains(err.Error(), "unable to download remote context") {
+		t.Fatalf("Expected a download error, got %v", err)
+	}
+
+	unreachable := server.URL
+	server.Close()
+	if _, _, err := getContextFromURL(ioutil.Discard, unreachable+"/context.tar.gz", ""); err == nil || !strings.Contains(err.Error(), "unable to download remote context") {
+		t.Fatalf("Expected a download error, got %v", err)
+	}
+}
+
+func TestBuiltImageIDStream(t *testing.T) {
+	messages := `{"stream":"Step 1 : FROM busybox\n"}
+{"stream":" ---> 47bcc53f74dc\n"}
+{"stream":"Successfully built 47bcc53f74dc\n"}
+`
+	body, imageIDChan := builtImageIDStream(strings.NewReader(messages))
+	out, err := ioutil.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body.Close()
+	if string(out) != messages {
+		t.Fatalf("Expected the messages to pass through, got %q", out)
+	}
+	if imageID := <-imageIDChan; imageID != "47bcc53f74dc" {
+		t.Fatalf("Expected the image ID 47bcc53f74dc, got %q", imageID)
+	}
+
+	body, imageIDChan = builtImageIDStream(strings.NewReader(`{"stream":"Step 1 : FROM busybox\n"}
+{"errorDetail":{"message":"failed"},"error":"failed"}
+`))
+	if _, err := ioutil.ReadAll(body); err != nil {
+		t.Fatal(err)
+	}
+	body.Close()
+	if imageID := <-imageIDChan; imageID != "" {
+		t.Fatalf("Expected no image ID, got %q", imageID)
+	}
+}
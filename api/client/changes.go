@@ -0,0 +1,104 @@
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/builder/dockerfile/command"
+)
+
+// changesOpts collects the Dockerfile instructions given with the --change
+// and --change-file flags of docker commit and docker import, in the order
+// the flags are given.
+type changesOpts struct {
+	values []string
+}
+
+// GetAll returns the instructions collected so far.
+func (o *changesOpts) GetAll() []string {
+	return o.values
+}
+
+// changeFlag is the flag.Value of --change, a single instruction.
+type changeFlag struct {
+	opts *changesOpts
+}
+
+func (f changeFlag) String() string {
+	return fmt.Sprintf("%v", f.opts.values)
+}
+
+func (f changeFlag) Set(value string) error {
+	if err := validateChange(value); err != nil {
+		return err
+	}
+	f.opts.values = append(f.opts.values, value)
+	return nil
+}
+
+// changeFileFlag is the flag.Value of --change-file, a file with an
+// instruction per line.
+type changeFileFlag struct {
+	opts *changesOpts
+}
+
+func (f changeFileFlag) String() string {
+	return ""
+}
+
+func (f changeFileFlag) Set(path string) error {
+	changes, err := readChangesFile(path)
+	if err != nil {
+		return err
+	}
+	f.opts.values = append(f.opts.values, changes...)
+	return nil
+}
+
+// validateChange checks that change starts with a Dockerfile instruction
+// which can be applied to an image without building it.
+func validateChange(change string) error {
+	fields := strings.Fields(change)
+	if len(fields) == 0 {
+		return fmt.Errorf("empty change")
+	}
+	if _, ok := command.CommitCommands[strings.ToLower(fields[0])]; !ok {
+		var valid []string
+		for c := range command.CommitCommands {
+			valid = append(valid, strings.ToUpper(c))
+		}
+		sort.Strings(valid)
+		return fmt.Errorf("%s is not a valid change command (valid commands are %s)", fields[0], strings.Join(valid, ", "))
+	}
+	return nil
+}
+
+// readChangesFile reads the Dockerfile instructions of a file, one per line.
+// Empty lines and lines starting with # are ignored.
+func readChangesFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var changes []string
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		change := strings.TrimSpace(scanner.Text())
+		if change == "" || strings.HasPrefix(change, "#") {
+			continue
+		}
+		if err := validateChange(change); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		changes = append(changes, change)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateChange(t *testing.T) {
+	for _, change := range []string{"CMD [\"sh\"]", "env FOO=bar", "  EXPOSE 80", "ONBUILD RUN make", "workdir /src"} {
+		if err := validateChange(change); err != nil {
+			t.Errorf("validateChange(%q) gave error: %v", change, err)
+		}
+	}
+
+	err := validateChange("RUN make")
+	expected := "RUN is not a valid change command (valid commands are CMD, ENTRYPOINT, ENV, EXPOSE, LABEL, ONBUILD, USER, VOLUME, WORKDIR)"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, got %v", expected, err)
+	}
+	if err := validateChange("   "); err == nil {
+		t.Error("expected an error for an empty change")
+	}
+}
+
+func TestReadChangesFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "docker-changes-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "changes")
+	content := "# the command\nCMD [\"top\"]\n\n  ENV FOO=bar  \nEXPOSE 80\n"
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	changes, err := readChangesFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{`CMD ["top"]`, "ENV FOO=bar", "EXPOSE 80"}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("expected %v, got %v", expected, changes)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("CMD top\n# comment\nFROM busybox\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readChangesFile(path); err == nil || !strings.HasPrefix(err.Error(), "line 3: FROM is not a valid change command") {
+		t.Fatalf("expected an error for line 3, got %v", err)
+	}
+}
+
+func TestChangesOptsOrder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "docker-changes-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "changes")
+	if err := ioutil.WriteFile(path, []byte("ENV B=2\nENV C=3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &changesOpts{}
+	if err := (changeFlag{opts}).Set("ENV A=1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := (changeFileFlag{opts}).Set(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := (changeFlag{opts}).Set("ENV D=4"); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"ENV A=1", "ENV B=2", "ENV C=3", "ENV D=4"}
+	if !reflect.DeepEqual(opts.GetAll(), expected) {
+		t.Fatalf("expected %v, got %v", expected, opts.GetAll())
+	}
+}
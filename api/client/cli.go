@@ -103,7 +103,11 @@ func NewDockerCli(in io.ReadCloser, out, err io.Writer, clientFlags *cli.ClientF
 		}
 		customHeaders["User-Agent"] = "Docker-Client/" + dockerversion.Version + " (" + runtime.GOOS + ")"
 
-		client, err := lib.NewClient(host, string(api.Version), clientFlags.Common.TLSOptions, customHeaders)
+		clientOptions := lib.ClientOptions{
+			RequestTimeout: clientFlags.RequestTimeout,
+			RequestRetries: clientFlags.RequestRetries,
+		}
+		client, err := lib.NewClientWithOptions(host, string(api.Version), clientFlags.Common.TLSOptions, customHeaders, clientOptions)
 		if err != nil {
 			return err
 		}
@@ -12,6 +12,7 @@ import (
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/runconfig"
+	"golang.org/x/net/context"
 )
 
 // apiClient is an interface that clients that talk with a docker server must implement.
@@ -30,6 +31,7 @@ type apiClient interface {
 	ContainerInspectWithRaw(containerID string, getSize bool) (types.ContainerJSON, []byte, error)
 	ContainerKill(containerID, signal string) error
 	ContainerList(options types.ContainerListOptions) ([]types.Container, error)
+	ContainerListWithContext(ctx context.Context, options types.ContainerListOptions) ([]types.Container, error)
 	ContainerLogs(options types.ContainerLogsOptions) (io.ReadCloser, error)
 	ContainerPause(containerID string) error
 	ContainerRemove(options types.ContainerRemoveOptions) error
@@ -43,6 +45,7 @@ type apiClient interface {
 	ContainerTop(containerID string, arguments []string) (types.ContainerProcessList, error)
 	ContainerUnpause(containerID string) error
 	ContainerWait(containerID string) (int, error)
+	ContainerWaitWithContext(ctx context.Context, containerID string) (int, error)
 	CopyFromContainer(containerID, srcPath string) (io.ReadCloser, types.ContainerPathStat, error)
 	CopyToContainer(options types.CopyToContainerOptions) error
 	Events(options types.EventsOptions) (io.ReadCloser, error)
@@ -51,8 +54,10 @@ type apiClient interface {
 	ImageHistory(imageID string) ([]types.ImageHistory, error)
 	ImageImport(options types.ImageImportOptions) (io.ReadCloser, error)
 	ImageInspectWithRaw(imageID string, getSize bool) (types.ImageInspect, []byte, error)
+	ImageInspectWithRawWithContext(ctx context.Context, imageID string, getSize bool) (types.ImageInspect, []byte, error)
 	ImageList(options types.ImageListOptions) ([]types.Image, error)
-	ImageLoad(input io.Reader) (io.ReadCloser, error)
+	ImageListWithContext(ctx context.Context, options types.ImageListOptions) ([]types.Image, error)
+	ImageLoad(input io.Reader, quiet bool) (types.ImageLoadResponse, error)
 	ImagePull(options types.ImagePullOptions, privilegeFunc lib.RequestPrivilegeFunc) (io.ReadCloser, error)
 	ImagePush(options types.ImagePushOptions, privilegeFunc lib.RequestPrivilegeFunc) (io.ReadCloser, error)
 	ImageRemove(options types.ImageRemoveOptions) ([]types.ImageDelete, error)
@@ -64,7 +69,7 @@ type apiClient interface {
 	NetworkCreate(options types.NetworkCreate) (types.NetworkCreateResponse, error)
 	NetworkDisconnect(networkID, containerID string) error
 	NetworkInspect(networkID string) (types.NetworkResource, error)
-	NetworkList() ([]types.NetworkResource, error)
+	NetworkList(filter filters.Args) ([]types.NetworkResource, error)
 	NetworkRemove(networkID string) error
 	RegistryLogin(auth types.AuthConfig) (types.AuthResponse, error)
 	ServerVersion() (types.Version, error)
@@ -6,12 +6,15 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	Cli "github.com/docker/docker/cli"
 	"github.com/docker/docker/pkg/archive"
 	flag "github.com/docker/docker/pkg/mflag"
 	"github.com/docker/docker/pkg/system"
+	"github.com/docker/docker/pkg/term"
+	"github.com/docker/docker/pkg/units"
 )
 
 type copyDirection int
@@ -24,6 +27,80 @@ const (
 
 type cpConfig struct {
 	followLink bool
+	// progress reports the number of bytes copied, it's nil unless
+	// --progress is set.
+	progress *cpProgress
+}
+
+const (
+	// cpProgressTerminalInterval is how often the progress is refreshed
+	// in place on a terminal.
+	cpProgressTerminalInterval = 100 * time.Millisecond
+	// cpProgressPlainInterval is how often a progress line is printed when
+	// the output isn't a terminal.
+	cpProgressPlainInterval = time.Second
+)
+
+// cpProgress prints the number of bytes copied so far. On a terminal the
+// current line is overwritten on each update, otherwise a new line is
+// printed for each update.
+type cpProgress struct {
+	out      io.Writer
+	terminal bool
+	interval time.Duration
+
+	current    int64
+	lastUpdate time.Time
+}
+
+func newCpProgress(out io.Writer, terminal bool) *cpProgress {
+	interval := cpProgressPlainInterval
+	if terminal {
+		interval = cpProgressTerminalInterval
+	}
+	return &cpProgress{out: out, terminal: terminal, interval: interval}
+}
+
+// wrap returns a reader which reports the bytes read from r.
+func (p *cpProgress) wrap(r io.Reader) io.Reader {
+	return &cpProgressReader{in: r, progress: p}
+}
+
+func (p *cpProgress) add(n int) {
+	p.current += int64(n)
+	if now := time.Now(); now.Sub(p.lastUpdate) >= p.interval {
+		p.lastUpdate = now
+		p.print(false)
+	}
+}
+
+// finish prints the total number of bytes copied.
+func (p *cpProgress) finish() {
+	p.print(true)
+}
+
+func (p *cpProgress) print(last bool) {
+	msg := fmt.Sprintf("Copied %s", units.HumanSize(float64(p.current)))
+	if !p.terminal {
+		fmt.Fprintln(p.out, msg)
+		return
+	}
+	// <ESC>[2K = erase entire current line
+	fmt.Fprintf(p.out, "\r%c[2K%s", 27, msg)
+	if last {
+		fmt.Fprintln(p.out)
+	}
+}
+
+type cpProgressReader struct {
+	in       io.Reader
+	progress *cpProgress
+}
+
+func (r *cpProgressReader) Read(p []byte) (int, error) {
+	n, err := r.in.Read(p)
+	r.progress.add(n)
+	return n, err
 }
 
 // CmdCp copies files/folders to or from a path in a container.
@@ -53,6 +130,7 @@ func (cli *DockerCli) CmdCp(args ...string) error {
 	)
 
 	followLink := cmd.Bool([]string{"L", "-follow-link"}, false, "Always follow symbol link in SRC_PATH")
+	showProgress := cmd.Bool([]string{"-progress"}, false, "Print the number of bytes copied to STDERR")
 
 	cmd.Require(flag.Exact, 2)
 	cmd.ParseFlags(args, true)
@@ -78,6 +156,10 @@ func (cli *DockerCli) CmdCp(args ...string) error {
 	cpParam := &cpConfig{
 		followLink: *followLink,
 	}
+	if *showProgress {
+		_, isTerminalErr := term.GetFdInfo(cli.err)
+		cpParam.progress = newCpProgress(cli.err, isTerminalErr)
+	}
 
 	switch direction {
 	case fromContainer:
@@ -128,6 +210,42 @@ func (cli *DockerCli) statContainerPath(containerName, path string) (types.Conta
 	return cli.client.ContainerStatPath(containerName, path)
 }
 
+// maxCpSymlinks is the number of symbolic links followed in a row in the
+// source path of a copy from a container.
+const maxCpSymlinks = 10
+
+// followContainerLinks dereferences path in the container for as long as
+// it's a symbolic link, and returns the path of the final target. Paths
+// which can't be stat-ed are returned as they are, the copy reports the
+// error.
+func (cli *DockerCli) followContainerLinks(containerName, path string) (string, error) {
+	for i := 0; i < maxCpSymlinks; i++ {
+		stat, err := cli.statContainerPath(containerName, path)
+		if err != nil || stat.Mode&os.ModeSymlink == 0 {
+			return path, nil
+		}
+
+		linkTarget := stat.LinkTarget
+		if !system.IsAbs(linkTarget) {
+			// Join with the parent directory.
+			parent, _ := archive.SplitPathDirEntry(path)
+			linkTarget = filepath.Join(parent, linkTarget)
+		}
+		path = linkTarget
+	}
+	return "", fmt.Errorf("too many levels of symbolic links in %q", path)
+}
+
+// cpDirToFileError is the error returned when the source of a copy is a
+// directory and the destination an existing file. linkPath is the symbolic
+// link which was followed to the directory, if any.
+func cpDirToFileError(srcPath, linkPath, dstPath string) error {
+	if linkPath != "" {
+		return fmt.Errorf("cannot copy directory %q to %q: the destination is a file (%q is a symbolic link to the directory)", srcPath, dstPath, linkPath)
+	}
+	return fmt.Errorf("cannot copy directory %q to %q: the destination is a file", srcPath, dstPath)
+}
+
 func resolveLocalPath(localPath string) (absPath string, err error) {
 	if absPath, err = filepath.Abs(localPath); err != nil {
 		return
@@ -146,30 +264,45 @@ func (cli *DockerCli) copyFromContainer(srcContainer, srcPath, dstPath string, c
 	}
 
 	// if client requests to follow symbol link, then must decide target file to be copied
-	var rebaseName string
+	var (
+		rebaseName string
+		linkPath   string
+	)
 	if cpParam.followLink {
-		srcStat, err := cli.statContainerPath(srcContainer, srcPath)
-
-		// If the destination is a symbolic link, we should follow it.
-		if err == nil && srcStat.Mode&os.ModeSymlink != 0 {
-			linkTarget := srcStat.LinkTarget
-			if !system.IsAbs(linkTarget) {
-				// Join with the parent directory.
-				srcParent, _ := archive.SplitPathDirEntry(srcPath)
-				linkTarget = filepath.Join(srcParent, linkTarget)
-			}
-
+		linkTarget, err := cli.followContainerLinks(srcContainer, srcPath)
+		if err != nil {
+			return err
+		}
+		if linkTarget != srcPath {
+			linkPath = srcPath
 			linkTarget, rebaseName = archive.GetRebaseName(srcPath, linkTarget)
 			srcPath = linkTarget
 		}
-
 	}
 
-	content, stat, err := cli.client.CopyFromContainer(srcContainer, srcPath)
+	response, stat, err := cli.client.CopyFromContainer(srcContainer, srcPath)
 	if err != nil {
 		return err
 	}
-	defer content.Close()
+	defer response.Close()
+
+	if dstPath != "-" && stat.Mode.IsDir() {
+		if dstStat, err := os.Stat(dstPath); err == nil && !dstStat.IsDir() {
+			return cpDirToFileError(srcContainer+":"+srcPath, linkPath, dstPath)
+		}
+	}
+
+	// The progress of copies from a container is the number of bytes read
+	// from the archive sent by the daemon.
+	var content io.Reader = response
+	if cpParam.progress != nil {
+		content = cpParam.progress.wrap(response)
+		defer func() {
+			if err == nil {
+				cpParam.progress.finish()
+			}
+		}()
+	}
 
 	if dstPath == "-" {
 		// Send the response to STDOUT.
@@ -257,6 +390,16 @@ func (cli *DockerCli) copyToContainer(srcPath, dstContainer, dstPath string, cpP
 			return err
 		}
 
+		if srcInfo.IsDir && dstInfo.Exists && !dstInfo.IsDir {
+			var linkPath string
+			if cpParam.followLink {
+				if srcStat, err := os.Lstat(srcPath); err == nil && srcStat.Mode()&os.ModeSymlink != 0 {
+					linkPath = srcPath
+				}
+			}
+			return cpDirToFileError(srcInfo.Path, linkPath, dstContainer+":"+dstInfo.Path)
+		}
+
 		srcArchive, err := archive.TarResource(srcInfo)
 		if err != nil {
 			return err
@@ -285,6 +428,12 @@ func (cli *DockerCli) copyToContainer(srcPath, dstContainer, dstPath string, cpP
 		content = preparedArchive
 	}
 
+	// The progress of copies to a container is the number of bytes of the
+	// archive written to the daemon.
+	if cpParam.progress != nil {
+		content = cpParam.progress.wrap(content)
+	}
+
 	options := types.CopyToContainerOptions{
 		ContainerID:               dstContainer,
 		Path:                      resolvedDstPath,
@@ -292,5 +441,11 @@ func (cli *DockerCli) copyToContainer(srcPath, dstContainer, dstPath string, cpP
 		AllowOverwriteDirWithFile: false,
 	}
 
-	return cli.client.CopyToContainer(options)
+	if err := cli.client.CopyToContainer(options); err != nil {
+		return err
+	}
+	if cpParam.progress != nil {
+		cpParam.progress.finish()
+	}
+	return nil
 }
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestCpProgressPlain(t *testing.T) {
+	var out bytes.Buffer
+	p := newCpProgress(&out, false)
+	p.interval = 0
+
+	r := p.wrap(strings.NewReader(strings.Repeat("a", 2048)))
+	buf := make([]byte, 1024)
+	for i := 0; i < 2; i++ {
+		if _, err := r.Read(buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p.finish()
+
+	expected := "Copied 1.024 kB\nCopied 2.048 kB\nCopied 2.048 kB\n"
+	if out.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestCpProgressTerminal(t *testing.T) {
+	var out bytes.Buffer
+	p := newCpProgress(&out, true)
+	p.interval = 0
+
+	if _, err := ioutil.ReadAll(p.wrap(strings.NewReader("hello"))); err != nil {
+		t.Fatal(err)
+	}
+	p.finish()
+
+	if !strings.HasPrefix(out.String(), "\r\x1b[2KCopied 5 B") {
+		t.Fatalf("expected the progress to overwrite the current line, got %q", out.String())
+	}
+	if !strings.HasSuffix(out.String(), "\r\x1b[2KCopied 5 B\n") {
+		t.Fatalf("expected the progress to end with the total and a new line, got %q", out.String())
+	}
+	if strings.Count(out.String(), "\n") != 1 {
+		t.Fatalf("expected a single new line, got %q", out.String())
+	}
+}
+
+func TestCpProgressInterval(t *testing.T) {
+	var out bytes.Buffer
+	p := newCpProgress(&out, false)
+
+	if _, err := ioutil.ReadAll(p.wrap(strings.NewReader("hello"))); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Count(out.String(), "\n")
+	p.finish()
+
+	// Only the first update is printed within the interval.
+	if lines != 1 {
+		t.Fatalf("expected a single progress line within the interval, got %q", out.String())
+	}
+	if !strings.HasSuffix(out.String(), "Copied 5 B\n") {
+		t.Fatalf("expected the progress to end with the total, got %q", out.String())
+	}
+}
+
+func TestCpDirToFileError(t *testing.T) {
+	err := cpDirToFileError("ctr:/etc", "", "/tmp/file")
+	expected := `cannot copy directory "ctr:/etc" to "/tmp/file": the destination is a file`
+	if err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err)
+	}
+
+	err = cpDirToFileError("ctr:/etc", "/etc-link", "/tmp/file")
+	expected = `cannot copy directory "ctr:/etc" to "/tmp/file": the destination is a file ("/etc-link" is a symbolic link to the directory)`
+	if err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err)
+	}
+}
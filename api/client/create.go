@@ -1,18 +1,25 @@
 package client
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"text/template"
+	"time"
 
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/client/lib"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
 	Cli "github.com/docker/docker/cli"
 	"github.com/docker/docker/pkg/jsonmessage"
+	flag "github.com/docker/docker/pkg/mflag"
 	"github.com/docker/docker/registry"
 	"github.com/docker/docker/runconfig"
 	tagpkg "github.com/docker/docker/tag"
+	"github.com/docker/docker/utils"
 )
 
 func (cli *DockerCli) pullImage(image string) error {
@@ -63,6 +70,125 @@ func (cli *DockerCli) pullImageCustomOut(image string, out io.Writer) error {
 	return jsonmessage.DisplayJSONMessagesStream(responseBody, out, cli.outFd, cli.isTerminalOut)
 }
 
+const (
+	// pullAlways pulls the image before creating the container.
+	pullAlways = "always"
+	// pullMissing pulls the image if it isn't found locally.
+	pullMissing = "missing"
+	// pullNever fails if the image isn't found locally.
+	pullNever = "never"
+)
+
+// addPullFlag adds the --pull flag of docker create and run to cmd.
+func addPullFlag(cmd *flag.FlagSet) *string {
+	return cmd.String([]string{"-pull"}, pullMissing, "Pull the image before creating the container (always, missing or never)")
+}
+
+// validatePullPolicy validates the value of the --pull flag.
+func validatePullPolicy(policy string) error {
+	switch policy {
+	case pullAlways, pullMissing, pullNever:
+		return nil
+	}
+	return fmt.Errorf("invalid --pull value %q, must be %s, %s or %s", policy, pullAlways, pullMissing, pullNever)
+}
+
+// maxNameTemplateTries is the number of names tried by --name-template
+// before giving up.
+const maxNameTemplateTries = 100
+
+// nameTemplateData is given to the --name-template template of docker create
+// and run.
+type nameTemplateData struct {
+	// Seq is one more than the number of containers whose name starts with
+	// the text of the template before its first action.
+	Seq int
+	// Date is the current date, as YYYYMMDD.
+	Date string
+}
+
+// addNameTemplateFlag adds the --name-template flag of docker create and run
+// to cmd.
+func addNameTemplateFlag(cmd *flag.FlagSet) *string {
+	return cmd.String([]string{"-name-template"}, "", "Assign a name to the container generated from a Go template")
+}
+
+// containerName returns the name given by --name, or generated from the
+// template given by --name-template.
+func (cli *DockerCli) containerName(name, nameTemplate string) (string, error) {
+	if nameTemplate == "" {
+		return name, nil
+	}
+	if name != "" {
+		return "", fmt.Errorf("--name and --name-template cannot be combined")
+	}
+	tmpl, err := template.New("").Funcs(funcMap).Parse(nameTemplate)
+	if err != nil {
+		return "", Cli.StatusError{StatusCode: 64,
+			Status: "Template parsing error: " + err.Error()}
+	}
+
+	prefix := nameTemplate
+	if i := strings.Index(nameTemplate, "{{"); i >= 0 {
+		prefix = nameTemplate[:i]
+	}
+	options := types.ContainerListOptions{All: true}
+	if prefix != "" {
+		// The name filter matches names partially, the prefix is checked
+		// by nameFromTemplate.
+		options.Filter = filters.NewArgs()
+		options.Filter.Add("name", prefix)
+	}
+	containers, err := cli.client.ContainerList(options)
+	if err != nil {
+		return "", err
+	}
+	var names []string
+	for _, c := range containers {
+		for _, n := range c.Names {
+			names = append(names, strings.TrimPrefix(n, "/"))
+		}
+	}
+	return nameFromTemplate(tmpl, prefix, names, time.Now())
+}
+
+// nameFromTemplate executes the template with the sequence number following
+// the names starting with prefix, and increments it until the generated name
+// isn't one of names.
+func nameFromTemplate(tmpl *template.Template, prefix string, names []string, now time.Time) (string, error) {
+	inUse := make(map[string]bool, len(names))
+	seq := 1
+	for _, n := range names {
+		inUse[n] = true
+		if strings.HasPrefix(n, prefix) {
+			seq++
+		}
+	}
+
+	var previous string
+	for i := 0; i < maxNameTemplateTries; i, seq = i+1, seq+1 {
+		var buffer bytes.Buffer
+		if err := tmpl.Execute(&buffer, nameTemplateData{Seq: seq, Date: now.Format("20060102")}); err != nil {
+			return "", Cli.StatusError{StatusCode: 64,
+				Status: "Template parsing error: " + err.Error()}
+		}
+		name := buffer.String()
+		if !utils.RestrictedNamePattern.MatchString(name) {
+			return "", fmt.Errorf("Invalid container name (%s) generated by --name-template, only %s are allowed", name, utils.RestrictedNameChars)
+		}
+		if !inUse[name] {
+			return name, nil
+		}
+		// The name doesn't depend on the sequence number, trying again
+		// won't help.
+		if name == previous {
+			break
+		}
+		previous = name
+	}
+	return "", fmt.Errorf("Error: no name generated by --name-template is available, the last one tried was %s", previous)
+}
+
 type cidFile struct {
 	path    string
 	file    *os.File
@@ -82,7 +208,9 @@ func newCIDFile(path string) (*cidFile, error) {
 	return &cidFile{path: path, file: f}, nil
 }
 
-func (cli *DockerCli) createContainer(config *runconfig.Config, hostConfig *runconfig.HostConfig, cidfile, name string) (*types.ContainerCreateResponse, error) {
+// createContainer creates a container, pulling its image first as given by
+// the pull policy.
+func (cli *DockerCli) createContainer(config *runconfig.Config, hostConfig *runconfig.HostConfig, cidfile, name, pull string) (*types.ContainerCreateResponse, error) {
 	mergedConfig := runconfig.MergeConfigs(config, hostConfig)
 
 	var containerIDFile *cidFile
@@ -113,7 +241,9 @@ func (cli *DockerCli) createContainer(config *runconfig.Config, hostConfig *runc
 
 	var trustedRef reference.Canonical
 
-	if isTrusted() && !isDigested {
+	// the trust server isn't contacted either if the image mustn't be
+	// pulled, the image found locally is used as is
+	if isTrusted() && !isDigested && pull != pullNever {
 		var err error
 		trustedRef, err = cli.trustedReference(ref.(reference.NamedTagged))
 		if err != nil {
@@ -122,28 +252,41 @@ func (cli *DockerCli) createContainer(config *runconfig.Config, hostConfig *runc
 		config.Image = trustedRef.String()
 	}
 
+	pullAndTag := func() error {
+		// we don't want to write to stdout anything apart from container.ID
+		if err := cli.pullImageCustomOut(config.Image, cli.err); err != nil {
+			return err
+		}
+		if trustedRef != nil && !isDigested {
+			return cli.tagTrusted(trustedRef, ref.(reference.NamedTagged))
+		}
+		return nil
+	}
+
+	if pull == pullAlways {
+		if err := pullAndTag(); err != nil {
+			return nil, err
+		}
+	}
+
 	//create the container
 	response, err := cli.client.ContainerCreate(mergedConfig, name)
 	//if image not found try to pull it
 	if err != nil {
-		if lib.IsErrImageNotFound(err) {
+		if lib.IsErrImageNotFound(err) && pull == pullMissing {
 			fmt.Fprintf(cli.err, "Unable to find image '%s' locally\n", ref.String())
 
-			// we don't want to write to stdout anything apart from container.ID
-			if err = cli.pullImageCustomOut(config.Image, cli.err); err != nil {
+			if err := pullAndTag(); err != nil {
 				return nil, err
 			}
-			if trustedRef != nil && !isDigested {
-				if err := cli.tagTrusted(trustedRef, ref.(reference.NamedTagged)); err != nil {
-					return nil, err
-				}
-			}
 			// Retry
 			var retryErr error
 			response, retryErr = cli.client.ContainerCreate(mergedConfig, name)
 			if retryErr != nil {
 				return nil, retryErr
 			}
+		} else if lib.IsErrImageNotFound(err) && pull == pullNever {
+			return nil, fmt.Errorf("Error: image %s not found locally", ref.String())
 		} else {
 			return nil, err
 		}
@@ -169,7 +312,9 @@ func (cli *DockerCli) CmdCreate(args ...string) error {
 
 	// These are flags not stored in Config/HostConfig
 	var (
-		flName = cmd.String([]string{"-name"}, "", "Assign a name to the container")
+		flName         = cmd.String([]string{"-name"}, "", "Assign a name to the container")
+		flPull         = addPullFlag(cmd)
+		flNameTemplate = addNameTemplateFlag(cmd)
 	)
 
 	config, hostConfig, cmd, err := runconfig.Parse(cmd, args)
@@ -181,7 +326,14 @@ func (cli *DockerCli) CmdCreate(args ...string) error {
 		cmd.Usage()
 		return nil
 	}
-	response, err := cli.createContainer(config, hostConfig, hostConfig.ContainerIDFile, *flName)
+	if err := validatePullPolicy(*flPull); err != nil {
+		return err
+	}
+	name, err := cli.containerName(*flName, *flNameTemplate)
+	if err != nil {
+		return err
+	}
+	response, err := cli.createContainer(config, hostConfig, hostConfig.ContainerIDFile, name, *flPull)
 	if err != nil {
 		return err
 	}
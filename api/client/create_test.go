@@ -0,0 +1,69 @@
+package client
+
+import (
+	"testing"
+	"text/template"
+	"time"
+)
+
+func TestValidatePullPolicy(t *testing.T) {
+	for _, policy := range []string{"always", "missing", "never"} {
+		if err := validatePullPolicy(policy); err != nil {
+			t.Fatalf("Expected %s to be valid, got %v", policy, err)
+		}
+	}
+	for _, policy := range []string{"", "Always", "if-not-present"} {
+		if err := validatePullPolicy(policy); err == nil {
+			t.Fatalf("Expected %q to be invalid", policy)
+		}
+	}
+}
+
+func TestNameFromTemplate(t *testing.T) {
+	now := time.Date(2016, time.January, 2, 15, 4, 5, 0, time.UTC)
+	cases := []struct {
+		format   string
+		prefix   string
+		names    []string
+		expected string
+	}{
+		{"job-{{.Seq}}-{{.Date}}", "job-", nil, "job-1-20160102"},
+		{"job-{{.Seq}}-{{.Date}}", "job-", []string{"job-1-20160101", "other"}, "job-2-20160102"},
+		// The names of the removed containers leave gaps, the colliding
+		// names are skipped.
+		{"job-{{.Seq}}", "job-", []string{"job-2", "job-3"}, "job-4"},
+		{"job-{{.Seq}}", "job-", []string{"job-3", "job-4"}, "job-5"},
+		{"{{.Date}}-{{.Seq}}", "", []string{"a", "b"}, "20160102-3"},
+	}
+	for _, c := range cases {
+		tmpl, err := template.New("").Parse(c.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		name, err := nameFromTemplate(tmpl, c.prefix, c.names, now)
+		if err != nil {
+			t.Fatalf("Expected no error for %s with %v, got %v", c.format, c.names, err)
+		}
+		if name != c.expected {
+			t.Fatalf("Expected %s for %s with %v, got %s", c.expected, c.format, c.names, name)
+		}
+	}
+}
+
+func TestNameFromTemplateInvalid(t *testing.T) {
+	now := time.Now()
+	cases := map[string][]string{
+		"job":           {"job"},
+		"job {{.Seq}}":  nil,
+		"job-{{.Date}}": {"job-" + now.Format("20060102")},
+	}
+	for format, names := range cases {
+		tmpl, err := template.New("").Parse(format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name, err := nameFromTemplate(tmpl, "job", names, now); err == nil {
+			t.Fatalf("Expected an error for %s with %v, got %s", format, names, name)
+		}
+	}
+}
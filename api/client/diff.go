@@ -2,21 +2,44 @@ package client
 
 import (
 	"fmt"
+	"strings"
+	"text/template"
 
+	"github.com/docker/docker/api/types"
 	Cli "github.com/docker/docker/cli"
+	"github.com/docker/docker/opts"
 	"github.com/docker/docker/pkg/archive"
 	flag "github.com/docker/docker/pkg/mflag"
 )
 
+// diffFilters maps the values accepted by the --filter flag of docker diff
+// to the kind of changes they select.
+var diffFilters = map[string]archive.ChangeType{
+	"added":   archive.ChangeAdd,
+	"changed": archive.ChangeModify,
+	"deleted": archive.ChangeDelete,
+}
+
+// diffEntry is a change of a container's filesystem, as given to the
+// --format template of docker diff.
+type diffEntry struct {
+	// Kind is A (added), C (changed) or D (deleted).
+	Kind string
+	Path string
+}
+
 // CmdDiff shows changes on a container's filesystem.
 //
 // Each changed file is printed on a separate line, prefixed with a single
 // character that indicates the status of the file: C (modified), A (added),
 // or D (deleted).
 //
-// Usage: docker diff CONTAINER
+// Usage: docker diff [OPTIONS] CONTAINER
 func (cli *DockerCli) CmdDiff(args ...string) error {
 	cmd := Cli.Subcmd("diff", []string{"CONTAINER"}, Cli.DockerCommands["diff"].Description, true)
+	flFilter := opts.NewListOpts(nil)
+	cmd.Var(&flFilter, []string{"f", "-filter"}, "Only show the changes of the given kind: added, changed or deleted")
+	format := cmd.String([]string{"-format"}, "", "Pretty-print changes using a Go template")
 	cmd.Require(flag.Exact, 1)
 
 	cmd.ParseFlags(args, true)
@@ -25,23 +48,66 @@ func (cli *DockerCli) CmdDiff(args ...string) error {
 		return fmt.Errorf("Container name cannot be empty")
 	}
 
+	kinds := map[archive.ChangeType]bool{}
+	for _, f := range flFilter.GetAll() {
+		kind, ok := diffFilters[f]
+		if !ok {
+			return fmt.Errorf("invalid filter %q: expected added, changed or deleted", f)
+		}
+		kinds[kind] = true
+	}
+
+	tmpl, err := template.New("").Funcs(funcMap).Parse(diffFormat(*format))
+	if err != nil {
+		return Cli.StatusError{StatusCode: 64,
+			Status: "Template parsing error: " + err.Error()}
+	}
+
 	changes, err := cli.client.ContainerDiff(cmd.Arg(0))
 	if err != nil {
 		return err
 	}
 
+	for _, entry := range filterChanges(changes, kinds) {
+		if err := tmpl.Execute(cli.out, entry); err != nil {
+			return Cli.StatusError{StatusCode: 64,
+				Status: "Template parsing error: " + err.Error()}
+		}
+		fmt.Fprintln(cli.out)
+	}
+
+	return nil
+}
+
+// diffFormat returns the template used to print the changes, format with
+// its escaped tabs and new lines replaced, or the default one.
+func diffFormat(format string) string {
+	if format == "" {
+		return "{{.Kind}} {{.Path}}"
+	}
+	return strings.NewReplacer(`\t`, "\t", `\n`, "\n").Replace(format)
+}
+
+// filterChanges returns the changes of the given kinds, or all of them if no
+// kind is given.
+func filterChanges(changes []types.ContainerChange, kinds map[archive.ChangeType]bool) []diffEntry {
+	var entries []diffEntry
 	for _, change := range changes {
-		var kind string
-		switch change.Kind {
+		kind := archive.ChangeType(change.Kind)
+		if len(kinds) > 0 && !kinds[kind] {
+			continue
+		}
+
+		entry := diffEntry{Path: change.Path}
+		switch kind {
 		case archive.ChangeModify:
-			kind = "C"
+			entry.Kind = "C"
 		case archive.ChangeAdd:
-			kind = "A"
+			entry.Kind = "A"
 		case archive.ChangeDelete:
-			kind = "D"
+			entry.Kind = "D"
 		}
-		fmt.Fprintf(cli.out, "%s %s\n", kind, change.Path)
+		entries = append(entries, entry)
 	}
-
-	return nil
+	return entries
 }
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/archive"
+)
+
+func TestFilterChanges(t *testing.T) {
+	changes := []types.ContainerChange{
+		{Kind: archive.ChangeModify, Path: "/etc"},
+		{Kind: archive.ChangeAdd, Path: "/etc/mtab"},
+		{Kind: archive.ChangeDelete, Path: "/etc/motd"},
+		{Kind: archive.ChangeAdd, Path: "/go"},
+	}
+
+	all := filterChanges(changes, nil)
+	expected := []diffEntry{{"C", "/etc"}, {"A", "/etc/mtab"}, {"D", "/etc/motd"}, {"A", "/go"}}
+	if !reflect.DeepEqual(all, expected) {
+		t.Fatalf("expected %v, got %v", expected, all)
+	}
+
+	added := filterChanges(changes, map[archive.ChangeType]bool{archive.ChangeAdd: true})
+	expected = []diffEntry{{"A", "/etc/mtab"}, {"A", "/go"}}
+	if !reflect.DeepEqual(added, expected) {
+		t.Fatalf("expected %v, got %v", expected, added)
+	}
+
+	changedOrDeleted := filterChanges(changes, map[archive.ChangeType]bool{archive.ChangeModify: true, archive.ChangeDelete: true})
+	expected = []diffEntry{{"C", "/etc"}, {"D", "/etc/motd"}}
+	if !reflect.DeepEqual(changedOrDeleted, expected) {
+		t.Fatalf("expected %v, got %v", expected, changedOrDeleted)
+	}
+}
+
+func TestDiffFormat(t *testing.T) {
+	if f := diffFormat(""); f != "{{.Kind}} {{.Path}}" {
+		t.Fatalf("expected the default format, got %q", f)
+	}
+	if f := diffFormat(`{{.Kind}}\t{{.Path}}`); f != "{{.Kind}}\t{{.Path}}" {
+		t.Fatalf("expected the tab to be unescaped, got %q", f)
+	}
+}
@@ -1,8 +1,17 @@
 package client
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
 	"github.com/docker/docker/api/types"
+	eventtypes "github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
+	timetypes "github.com/docker/docker/api/types/time"
 	Cli "github.com/docker/docker/cli"
 	"github.com/docker/docker/opts"
 	"github.com/docker/docker/pkg/jsonmessage"
@@ -16,12 +25,36 @@ func (cli *DockerCli) CmdEvents(args ...string) error {
 	cmd := Cli.Subcmd("events", nil, Cli.DockerCommands["events"].Description, true)
 	since := cmd.String([]string{"-since"}, "", "Show all events created since timestamp")
 	until := cmd.String([]string{"-until"}, "", "Stream events until this timestamp")
+	format := cmd.String([]string{"-format"}, "", "Format the output using the given Go template")
 	flFilter := opts.NewListOpts(nil)
 	cmd.Var(&flFilter, []string{"f", "-filter"}, "Filter output based on conditions provided")
 	cmd.Require(flag.Exact, 0)
 
 	cmd.ParseFlags(args, true)
 
+	// Resolve relative times against the same reference, so that --since
+	// and --until can be compared.
+	now := time.Now()
+	sinceTs, err := resolveEventsTime("--since", *since, now)
+	if err != nil {
+		return err
+	}
+	untilTs, err := resolveEventsTime("--until", *until, now)
+	if err != nil {
+		return err
+	}
+	if sinceTs != "" && untilTs != "" && !eventsTimeBefore(sinceTs, untilTs) {
+		return fmt.Errorf("--until %q must be after --since %q", *until, *since)
+	}
+
+	var tmpl *template.Template
+	if *format != "" {
+		if tmpl, err = template.New("").Funcs(funcMap).Parse(*format); err != nil {
+			return Cli.StatusError{StatusCode: 64,
+				Status: "Template parsing error: " + err.Error()}
+		}
+	}
+
 	eventFilterArgs := filters.NewArgs()
 
 	// Consolidate all filter flags, and sanity check them early.
@@ -34,9 +67,13 @@ func (cli *DockerCli) CmdEvents(args ...string) error {
 		}
 	}
 
+	if err := cli.validateEventsFilters(eventFilterArgs); err != nil {
+		return err
+	}
+
 	options := types.EventsOptions{
-		Since:   *since,
-		Until:   *until,
+		Since:   sinceTs,
+		Until:   untilTs,
 		Filters: eventFilterArgs,
 	}
 
@@ -46,5 +83,131 @@ func (cli *DockerCli) CmdEvents(args ...string) error {
 	}
 	defer responseBody.Close()
 
-	return jsonmessage.DisplayJSONMessagesStream(responseBody, cli.out, cli.outFd, cli.isTerminalOut)
+	if tmpl == nil {
+		return jsonmessage.DisplayJSONMessagesStream(responseBody, cli.out, cli.outFd, cli.isTerminalOut)
+	}
+	return printEventsWithTemplate(responseBody, cli.out, tmpl)
+}
+
+// acceptedEventsFilters lists the filters accepted by docker events.
+var acceptedEventsFilters = map[string]bool{
+	"container": true,
+	"event":     true,
+	"image":     true,
+	"label":     true,
+	"type":      true,
+}
+
+// eventsTypes lists the values accepted by the type filter. Only containers
+// and images generate events in this daemon, so the other types are refused
+// rather than silently matching nothing.
+var eventsTypes = []string{
+	eventtypes.ContainerEventType,
+	eventtypes.ImageEventType,
+}
+
+// eventsActions lists the values accepted by the event filter.
+var eventsActions = []string{
+	// container events
+	"attach", "commit", "copy", "create", "destroy", "die", "exec_create", "exec_start", "export",
+	"kill", "oom", "pause", "rename", "resize", "restart", "start", "stop", "top", "unpause",
+	"archive-path", "extract-to-dir",
+	// image events
+	"delete", "import", "pull", "push", "tag", "untag",
+}
+
+// validateEventsFilters checks the filters of docker events before they're
+// sent to the daemon, and resolves the container names of the container
+// filter to IDs, that the daemon matches the events against.
+func (cli *DockerCli) validateEventsFilters(filterArgs filters.Args) error {
+	if err := filterArgs.Validate(acceptedEventsFilters); err != nil {
+		return fmt.Errorf("%v (valid filters are container, event, image, label and type)", err)
+	}
+	if err := validateLabelFilter(filterArgs); err != nil {
+		return err
+	}
+	if err := validateFilterValues(filterArgs, "type", eventsTypes); err != nil {
+		return err
+	}
+	if err := validateFilterValues(filterArgs, "event", eventsActions); err != nil {
+		return err
+	}
+
+	for _, name := range filterArgs.Get("container") {
+		// Containers which don't exist anymore can only be matched by the
+		// ID they had, keep the value as it is.
+		c, err := cli.client.ContainerInspect(name)
+		if err != nil || c.ID == name {
+			continue
+		}
+		filterArgs.Del("container", name)
+		filterArgs.Add("container", c.ID)
+	}
+	return nil
+}
+
+func validateFilterValues(filterArgs filters.Args, field string, accepted []string) error {
+	return filterArgs.WalkValues(field, func(value string) error {
+		for _, a := range accepted {
+			if value == a {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid filter '%s': %q is not one of %s", field, value, strings.Join(accepted, ", "))
+	})
+}
+
+// resolveEventsTime validates the value of the --since or --until flag,
+// either an absolute timestamp or a duration relative to now, and returns
+// it as a Unix timestamp.
+func resolveEventsTime(flagName, value string, now time.Time) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	ts, err := timetypes.GetTimestamp(value, now)
+	if err == nil {
+		// Values that are neither durations nor dates are passed through
+		// unchanged, they must then already be Unix timestamps.
+		_, _, err = timetypes.ParseTimestamps(ts, 0)
+	}
+	if err != nil {
+		return "", fmt.Errorf("invalid value %q for %s: expected a timestamp or a duration such as 30m", value, flagName)
+	}
+	return ts, nil
+}
+
+func eventsTimeBefore(a, b string) bool {
+	aSec, aNano, _ := timetypes.ParseTimestamps(a, 0)
+	bSec, bNano, _ := timetypes.ParseTimestamps(b, 0)
+	return aSec < bSec || (aSec == bSec && aNano < bNano)
+}
+
+// printEventsWithTemplate renders each event of the stream with tmpl, one
+// per line, until the stream ends.
+func printEventsWithTemplate(in io.Reader, out io.Writer, tmpl *template.Template) error {
+	dec := json.NewDecoder(in)
+	for {
+		var ev eventtypes.Message
+		if err := dec.Decode(&ev); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		// Older daemons only send the deprecated fields.
+		if ev.Action == "" {
+			ev.Action = ev.Status
+		}
+		if ev.Actor.ID == "" {
+			ev.Actor.ID = ev.ID
+		}
+		if ev.Actor.Attributes == nil && ev.From != "" {
+			ev.Actor.Attributes = map[string]string{"image": ev.From}
+		}
+		if err := tmpl.Execute(out, ev); err != nil {
+			return Cli.StatusError{StatusCode: 64,
+				Status: "Template parsing error: " + err.Error()}
+		}
+		fmt.Fprintln(out)
+	}
 }
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/docker/docker/api/types/filters"
+)
+
+func TestResolveEventsTime(t *testing.T) {
+	now := time.Unix(1136214245, 0)
+	cases := []struct {
+		in, expected string
+		expectedErr  bool
+	}{
+		{"", "", false},
+		{"30m", "1136212445", false},
+		{"2h", "1136207045", false},
+		{"1136214245", "1136214245", false},
+		{"1136214245.000000001", "1136214245.000000001", false},
+		{"2006-01-02T15:04:05Z", "1136214245.000000000", false},
+		{"30x", "", true},
+		{"yesterday", "", true},
+		{"2006-01-02T25:04:05Z", "", true},
+	}
+	for _, c := range cases {
+		ts, err := resolveEventsTime("--since", c.in, now)
+		if c.expectedErr {
+			if err == nil {
+				t.Errorf("resolveEventsTime(%q) = %q, expected an error", c.in, ts)
+			} else if !strings.Contains(err.Error(), "--since") {
+				t.Errorf("resolveEventsTime(%q) gave error %q, expected it to name the flag", c.in, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("resolveEventsTime(%q) gave error: %v", c.in, err)
+			continue
+		}
+		if ts != c.expected {
+			t.Errorf("resolveEventsTime(%q) = %q, expected %q", c.in, ts, c.expected)
+		}
+	}
+}
+
+func TestEventsTimeBefore(t *testing.T) {
+	if !eventsTimeBefore("1136214245", "1136214245.000000001") {
+		t.Error("expected 1136214245 to be before 1136214245.000000001")
+	}
+	if eventsTimeBefore("1136214246", "1136214245.999999999") {
+		t.Error("expected 1136214246 not to be before 1136214245.999999999")
+	}
+}
+
+func TestPrintEventsWithTemplate(t *testing.T) {
+	stream := `{"status":"start","id":"abc","from":"busybox","Type":"container","Action":"start","Actor":{"ID":"abc","Attributes":{"image":"busybox","name":"web"}},"time":1136214245}
+{"status":"untag","id":"sha256:def","time":1136214246}
+`
+	tmpl := template.Must(template.New("").Parse("{{.Time}} {{.Type}} {{.Action}} {{.Actor.ID}} {{.Actor.Attributes.name}}"))
+
+	var out bytes.Buffer
+	if err := printEventsWithTemplate(strings.NewReader(stream), &out, tmpl); err != nil {
+		t.Fatal(err)
+	}
+	expected := "1136214245 container start abc web\n1136214246  untag sha256:def <no value>\n"
+	if out.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestValidateEventsFilters(t *testing.T) {
+	cases := []struct {
+		filters []string
+		err     string
+	}{
+		{[]string{"type=container", "type=image", "event=start", "label=com.example", "image=busybox"}, ""},
+		{[]string{"type=volume"}, `invalid filter 'type': "volume" is not one of container, image`},
+		{[]string{"foo=bar"}, "Invalid filter 'foo' (valid filters are container, event, image, label and type)"},
+		{[]string{"type=service"}, `invalid filter 'type': "service" is not one of container, image`},
+		{[]string{"event=started"}, `invalid filter 'event': "started" is not one of`},
+		{[]string{"label==value"}, "invalid filter 'label'"},
+	}
+	cli := &DockerCli{}
+	for _, c := range cases {
+		args := filters.NewArgs()
+		for _, f := range c.filters {
+			var err error
+			if args, err = filters.ParseFlag(f, args); err != nil {
+				t.Fatal(err)
+			}
+		}
+		err := cli.validateEventsFilters(args)
+		if c.err == "" {
+			if err != nil {
+				t.Errorf("%v: unexpected error: %v", c.filters, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%v: expected error containing %q, got %v", c.filters, c.err, err)
+		}
+	}
+}
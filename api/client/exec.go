@@ -8,9 +8,18 @@ import (
 	"github.com/docker/docker/api/types"
 	Cli "github.com/docker/docker/cli"
 	"github.com/docker/docker/pkg/promise"
+	"github.com/docker/docker/pkg/version"
 	"github.com/docker/docker/runconfig"
 )
 
+// minExecEnvAPIVersion is the first API version whose exec create requests
+// take environment variables.
+const minExecEnvAPIVersion = version.Version("1.22")
+
+// minDetachKeysAPIVersion is the first API version whose exec and attach
+// requests take a detach key sequence.
+const minDetachKeysAPIVersion = version.Version("1.22")
+
 // CmdExec runs a command in a running container.
 //
 // Usage: docker exec [OPTIONS] CONTAINER COMMAND [ARG...]
@@ -18,11 +27,26 @@ func (cli *DockerCli) CmdExec(args ...string) error {
 	cmd := Cli.Subcmd("exec", []string{"CONTAINER COMMAND [ARG...]"}, Cli.DockerCommands["exec"].Description, true)
 
 	execConfig, err := runconfig.ParseExec(cmd, args)
+	if err != nil {
+		cmd.ReportError(err.Error(), true)
+		return Cli.StatusError{StatusCode: 1}
+	}
 	// just in case the ParseExec does not exit
-	if execConfig.Container == "" || err != nil {
+	if execConfig.Container == "" {
 		return Cli.StatusError{StatusCode: 1}
 	}
 
+	if len(execConfig.Env) > 0 {
+		if err := cli.checkDaemonAPIVersion("-e and --env-file", minExecEnvAPIVersion); err != nil {
+			return err
+		}
+	}
+	if execConfig.DetachKeys != "" {
+		if err := cli.checkDaemonAPIVersion("--detach-keys", minDetachKeysAPIVersion); err != nil {
+			return err
+		}
+	}
+
 	response, err := cli.client.ContainerExecCreate(*execConfig)
 	if err != nil {
 		return err
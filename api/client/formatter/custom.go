@@ -0,0 +1,631 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api"
+	"github.com/docker/docker/api/types"
+	registrytypes "github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/pkg/stringutils"
+	"github.com/docker/docker/pkg/units"
+)
+
+const (
+	containerIDHeader  = "CONTAINER ID"
+	imageHeader        = "IMAGE"
+	namesHeader        = "NAMES"
+	commandHeader      = "COMMAND"
+	createdSinceHeader = "CREATED"
+	createdAtHeader    = "CREATED AT"
+	runningForHeader   = "CREATED"
+	statusHeader       = "STATUS"
+	portsHeader        = "PORTS"
+	sizeHeader         = "SIZE"
+	virtualSizeHeader  = "VIRTUAL SIZE"
+	labelsHeader       = "LABELS"
+	mountsHeader       = "MOUNTS"
+	imageIDHeader      = "IMAGE ID"
+	repositoryHeader   = "REPOSITORY"
+	tagHeader          = "TAG"
+	digestHeader       = "DIGEST"
+
+	statsContainerHeader = "CONTAINER"
+	statsNameHeader      = "NAME"
+	cpuPercHeader        = "CPU %"
+	memUsageHeader       = "MEM USAGE / LIMIT"
+	memPercHeader        = "MEM %"
+	netIOHeader          = "NET I/O"
+	blockIOHeader        = "BLOCK I/O"
+	pidsHeader           = "PIDS"
+
+	createdByHeader = "CREATED BY"
+	commentHeader   = "COMMENT"
+
+	nameHeader        = "NAME"
+	descriptionHeader = "DESCRIPTION"
+	starsHeader       = "STARS"
+	officialHeader    = "OFFICIAL"
+	automatedHeader   = "AUTOMATED"
+
+	networkIDHeader = "NETWORK ID"
+	driverHeader    = "DRIVER"
+	scopeHeader     = "SCOPE"
+
+	volumeNameHeader = "VOLUME NAME"
+	mountpointHeader = "MOUNTPOINT"
+)
+
+type containerContext struct {
+	baseSubContext
+	trunc      bool
+	splitSize  bool
+	timeLayout string
+	c          types.Container
+}
+
+func (c *containerContext) ID() string {
+	c.addHeader(containerIDHeader)
+	if c.trunc {
+		return stringid.TruncateID(c.c.ID)
+	}
+	return c.c.ID
+}
+
+func (c *containerContext) Names() string {
+	c.addHeader(namesHeader)
+	names := stripNamePrefix(c.c.Names)
+	if c.trunc {
+		for _, name := range names {
+			if len(strings.Split(name, "/")) == 1 {
+				names = []string{name}
+				break
+			}
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+func (c *containerContext) Image() string {
+	c.addHeader(imageHeader)
+	if c.c.Image == "" {
+		return "<no image>"
+	}
+	if c.trunc {
+		if trunc := stringid.TruncateID(c.c.ImageID); trunc == stringid.TruncateID(c.c.Image) {
+			return trunc
+		}
+	}
+	return c.c.Image
+}
+
+func (c *containerContext) Command() string {
+	c.addHeader(commandHeader)
+	command := c.c.Command
+	if c.trunc {
+		command = stringutils.Truncate(command, 20)
+	}
+	return strconv.Quote(command)
+}
+
+func (c *containerContext) CreatedAt() string {
+	c.addHeader(createdAtHeader)
+	return formatTime(c.c.Created, c.timeLayout)
+}
+
+func (c *containerContext) RunningFor() string {
+	c.addHeader(runningForHeader)
+	createdAt := time.Unix(int64(c.c.Created), 0)
+	return units.HumanDuration(time.Now().UTC().Sub(createdAt))
+}
+
+func (c *containerContext) Ports() string {
+	c.addHeader(portsHeader)
+	return api.DisplayablePorts(c.c.Ports)
+}
+
+func (c *containerContext) Status() string {
+	c.addHeader(statusHeader)
+	return c.c.Status
+}
+
+func (c *containerContext) Size() string {
+	c.addHeader(sizeHeader)
+	srw := units.HumanSize(float64(c.c.SizeRw))
+	sv := units.HumanSize(float64(c.c.SizeRootFs))
+
+	sf := srw
+	if c.c.SizeRootFs > 0 && !c.splitSize {
+		sf = fmt.Sprintf("%s (virtual %s)", srw, sv)
+	}
+	return sf
+}
+
+// VirtualSize returns the size of the writable layer and the read-only
+// image layers shared with other containers.
+func (c *containerContext) VirtualSize() string {
+	c.addHeader(virtualSizeHeader)
+	return units.HumanSize(float64(c.c.SizeRootFs))
+}
+
+func (c *containerContext) Mounts() string {
+	c.addHeader(mountsHeader)
+
+	var mounts []string
+	for _, m := range c.c.Mounts {
+		name := m.Name
+		if name == "" {
+			name = m.Source
+		}
+		if c.trunc {
+			name = stringutils.Truncate(name, 15)
+		}
+		mounts = append(mounts, name)
+	}
+	return strings.Join(mounts, ",")
+}
+
+func (c *containerContext) Labels() string {
+	c.addHeader(labelsHeader)
+	if c.c.Labels == nil {
+		return ""
+	}
+
+	var joinLabels []string
+	for k, v := range c.c.Labels {
+		joinLabels = append(joinLabels, fmt.Sprintf("%s=%s", k, v))
+	}
+	return strings.Join(joinLabels, ",")
+}
+
+func (c *containerContext) Label(name string) string {
+	n := strings.Split(name, ".")
+	r := strings.NewReplacer("-", " ", "_", " ")
+	h := r.Replace(n[len(n)-1])
+
+	c.addHeader(h)
+
+	if c.c.Labels == nil {
+		return ""
+	}
+	return c.c.Labels[name]
+}
+
+// containerPortJSON is a port of a container, as rendered by `{{json .}}`.
+// The IP and public port are omitted if the port isn't published.
+type containerPortJSON struct {
+	IP          string `json:"ip,omitempty"`
+	PrivatePort int    `json:"privatePort"`
+	PublicPort  int    `json:"publicPort,omitempty"`
+	Type        string `json:"type"`
+}
+
+// MarshalJSON renders the container row with stable field names and raw
+// values, so that `{{json .}}` is usable by machine consumers. IDs are never
+// truncated, sizes are expressed in bytes and ports are objects rather than
+// the string of the PORTS column.
+func (c *containerContext) MarshalJSON() ([]byte, error) {
+	names := make([]string, 0, len(c.c.Names))
+	for _, name := range c.c.Names {
+		names = append(names, strings.TrimPrefix(name, "/"))
+	}
+	ports := make([]containerPortJSON, 0, len(c.c.Ports))
+	for _, p := range c.c.Ports {
+		ports = append(ports, containerPortJSON{IP: p.IP, PrivatePort: p.PrivatePort, PublicPort: p.PublicPort, Type: p.Type})
+	}
+	return json.Marshal(struct {
+		ID          string
+		Names       []string
+		Image       string
+		ImageID     string
+		Command     string
+		CreatedAt   string
+		Status      string
+		Ports       []containerPortJSON
+		Labels      map[string]string
+		Size        int64
+		VirtualSize int64
+	}{
+		ID:          c.c.ID,
+		Names:       names,
+		Image:       c.c.Image,
+		ImageID:     c.c.ImageID,
+		Command:     c.c.Command,
+		CreatedAt:   time.Unix(int64(c.c.Created), 0).UTC().Format(time.RFC3339),
+		Status:      c.c.Status,
+		Ports:       ports,
+		Labels:      c.c.Labels,
+		Size:        c.c.SizeRw,
+		VirtualSize: c.c.SizeRootFs,
+	})
+}
+
+type imageContext struct {
+	baseSubContext
+	trunc      bool
+	timeLayout string
+	i          types.Image
+	repo       string
+	tag        string
+	digest     string
+}
+
+func (c *imageContext) ID() string {
+	c.addHeader(imageIDHeader)
+	if c.trunc {
+		return stringid.TruncateID(c.i.ID)
+	}
+	return c.i.ID
+}
+
+func (c *imageContext) Repository() string {
+	c.addHeader(repositoryHeader)
+	return c.repo
+}
+
+func (c *imageContext) Tag() string {
+	c.addHeader(tagHeader)
+	return c.tag
+}
+
+func (c *imageContext) Digest() string {
+	c.addHeader(digestHeader)
+	return c.digest
+}
+
+func (c *imageContext) CreatedSince() string {
+	c.addHeader(createdSinceHeader)
+	createdAt := time.Unix(int64(c.i.Created), 0)
+	return units.HumanDuration(time.Now().UTC().Sub(createdAt))
+}
+
+func (c *imageContext) CreatedAt() string {
+	c.addHeader(createdAtHeader)
+	return formatTime(c.i.Created, c.timeLayout)
+}
+
+func (c *imageContext) Size() string {
+	c.addHeader(sizeHeader)
+	return units.HumanSize(float64(c.i.Size))
+}
+
+func (c *imageContext) VirtualSize() string {
+	c.addHeader(virtualSizeHeader)
+	return units.HumanSize(float64(c.i.VirtualSize))
+}
+
+// MarshalJSON renders the image row with stable field names and raw values,
+// so that `{{json .}}` is usable by machine consumers. IDs are never
+// truncated and sizes are expressed in bytes.
+func (c *imageContext) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Repository  string
+		Tag         string
+		Digest      string
+		ID          string
+		CreatedAt   string
+		Size        int64
+		VirtualSize int64
+	}{
+		Repository:  c.repo,
+		Tag:         c.tag,
+		Digest:      c.digest,
+		ID:          c.i.ID,
+		CreatedAt:   time.Unix(int64(c.i.Created), 0).UTC().Format(time.RFC3339),
+		Size:        c.i.Size,
+		VirtualSize: c.i.VirtualSize,
+	})
+}
+
+type statsContext struct {
+	baseSubContext
+	s ContainerStats
+}
+
+func (c *statsContext) Container() string {
+	c.addHeader(statsContainerHeader)
+	return c.s.Container
+}
+
+func (c *statsContext) Name() string {
+	c.addHeader(statsNameHeader)
+	return c.s.Name
+}
+
+func (c *statsContext) CPUPerc() string {
+	c.addHeader(cpuPercHeader)
+	return fmt.Sprintf("%.2f%%", c.s.CPUPercentage)
+}
+
+func (c *statsContext) MemUsage() string {
+	c.addHeader(memUsageHeader)
+	return fmt.Sprintf("%s / %s", units.HumanSize(c.s.Memory), units.HumanSize(c.s.MemoryLimit))
+}
+
+func (c *statsContext) MemPerc() string {
+	c.addHeader(memPercHeader)
+	return fmt.Sprintf("%.2f%%", c.s.MemoryPercentage)
+}
+
+func (c *statsContext) NetIO() string {
+	c.addHeader(netIOHeader)
+	return fmt.Sprintf("%s / %s", units.HumanSize(c.s.NetworkRx), units.HumanSize(c.s.NetworkTx))
+}
+
+func (c *statsContext) BlockIO() string {
+	c.addHeader(blockIOHeader)
+	return fmt.Sprintf("%s / %s", units.HumanSize(c.s.BlockRead), units.HumanSize(c.s.BlockWrite))
+}
+
+func (c *statsContext) PIDs() string {
+	c.addHeader(pidsHeader)
+	return strconv.FormatUint(c.s.PidsCurrent, 10)
+}
+
+type historyContext struct {
+	baseSubContext
+	trunc bool
+	human bool
+	h     types.ImageHistory
+}
+
+func (c *historyContext) ID() string {
+	c.addHeader(imageHeader)
+	if c.trunc {
+		return stringid.TruncateID(c.h.ID)
+	}
+	return c.h.ID
+}
+
+// CreatedSince is the time elapsed since the layer was created, or the
+// creation time in RFC 3339 format if the times aren't human readable.
+func (c *historyContext) CreatedSince() string {
+	c.addHeader(createdSinceHeader)
+	created := time.Unix(c.h.Created, 0)
+	if c.human {
+		return units.HumanDuration(time.Now().UTC().Sub(created)) + " ago"
+	}
+	return created.Format(time.RFC3339)
+}
+
+func (c *historyContext) CreatedAt() string {
+	c.addHeader(createdAtHeader)
+	return time.Unix(c.h.Created, 0).Format(time.RFC3339)
+}
+
+func (c *historyContext) CreatedBy() string {
+	c.addHeader(createdByHeader)
+	createdBy := strings.Replace(c.h.CreatedBy, "\t", " ", -1)
+	if c.trunc {
+		return stringutils.Truncate(createdBy, 45)
+	}
+	return createdBy
+}
+
+func (c *historyContext) Size() string {
+	c.addHeader(sizeHeader)
+	if c.human {
+		return units.HumanSize(float64(c.h.Size))
+	}
+	return strconv.FormatInt(c.h.Size, 10)
+}
+
+func (c *historyContext) Comment() string {
+	c.addHeader(commentHeader)
+	return c.h.Comment
+}
+
+type searchContext struct {
+	baseSubContext
+	trunc bool
+	s     registrytypes.SearchResult
+}
+
+func (c *searchContext) Name() string {
+	c.addHeader(nameHeader)
+	return c.s.Name
+}
+
+// Description is the description of the image on a single line, truncated
+// to 45 characters unless trunc is unset.
+func (c *searchContext) Description() string {
+	c.addHeader(descriptionHeader)
+	desc := strings.Replace(c.s.Description, "\n", " ", -1)
+	desc = strings.Replace(desc, "\r", " ", -1)
+	if c.trunc && len(desc) > 45 {
+		desc = stringutils.Truncate(desc, 42) + "..."
+	}
+	return desc
+}
+
+func (c *searchContext) StarCount() string {
+	c.addHeader(starsHeader)
+	return strconv.Itoa(c.s.StarCount)
+}
+
+func (c *searchContext) IsOfficial() string {
+	c.addHeader(officialHeader)
+	if c.s.IsOfficial {
+		return "[OK]"
+	}
+	return ""
+}
+
+func (c *searchContext) IsAutomated() string {
+	c.addHeader(automatedHeader)
+	if c.s.IsAutomated || c.s.IsTrusted {
+		return "[OK]"
+	}
+	return ""
+}
+
+type networkContext struct {
+	baseSubContext
+	trunc bool
+	n     types.NetworkResource
+}
+
+func (c *networkContext) ID() string {
+	c.addHeader(networkIDHeader)
+	if c.trunc {
+		return stringid.TruncateID(c.n.ID)
+	}
+	return c.n.ID
+}
+
+func (c *networkContext) Name() string {
+	c.addHeader(nameHeader)
+	return c.n.Name
+}
+
+func (c *networkContext) Driver() string {
+	c.addHeader(driverHeader)
+	return c.n.Driver
+}
+
+func (c *networkContext) Scope() string {
+	c.addHeader(scopeHeader)
+	return c.n.Scope
+}
+
+type volumeContext struct {
+	baseSubContext
+	v *types.Volume
+}
+
+func (c *volumeContext) Name() string {
+	c.addHeader(volumeNameHeader)
+	return c.v.Name
+}
+
+func (c *volumeContext) Driver() string {
+	c.addHeader(driverHeader)
+	return c.v.Driver
+}
+
+func (c *volumeContext) Mountpoint() string {
+	c.addHeader(mountpointHeader)
+	return c.v.Mountpoint
+}
+
+type subContext interface {
+	fullHeader() string
+	addHeader(header string)
+	setHeader(header string)
+	startRow(row *bytes.Buffer)
+}
+
+// The ANSI escape codes of the colors of the rows.
+const (
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+	colorReset  = "\x1b[0m"
+)
+
+// colorer is implemented by the sub contexts whose rows are colored.
+type colorer interface {
+	// color returns the color of the row, or an empty string for the
+	// default color.
+	color() string
+}
+
+// color highlights the dangling images.
+func (c *imageContext) color() string {
+	if c.repo == "<none>" && c.tag == "<none>" {
+		return colorYellow
+	}
+	return ""
+}
+
+// color shows the running containers in green, the exited ones in red, and
+// the paused and restarting ones in yellow.
+func (c *containerContext) color() string {
+	switch {
+	case strings.HasSuffix(c.c.Status, "(Paused)"), strings.HasPrefix(c.c.Status, "Restarting"):
+		return colorYellow
+	case strings.HasPrefix(c.c.Status, "Up"):
+		return colorGreen
+	case strings.HasPrefix(c.c.Status, "Exited"):
+		return colorRed
+	}
+	return ""
+}
+
+// baseSubContext collects the header of the table while a row is rendered.
+// The header of a column is the one of the first field used in the column,
+// unless the column is named with the header template function.
+type baseSubContext struct {
+	header []string
+	// row is the buffer the row is rendered to, from its start offset. The
+	// tabs written since the start tell the column being rendered.
+	row   *bytes.Buffer
+	start int
+}
+
+// startRow sets the buffer the row is rendered to, to find the columns of
+// the header. Without it, each field adds a column.
+func (c *baseSubContext) startRow(row *bytes.Buffer) {
+	c.row = row
+	c.start = row.Len()
+	c.header = nil
+}
+
+// column returns the index of the column being rendered, growing the header
+// to hold it.
+func (c *baseSubContext) column() int {
+	column := len(c.header)
+	if c.row != nil {
+		column = bytes.Count(c.row.Bytes()[c.start:], []byte("\t"))
+	}
+	for len(c.header) <= column {
+		c.header = append(c.header, "")
+	}
+	return column
+}
+
+func (c *baseSubContext) fullHeader() string {
+	if c.header == nil && c.row == nil {
+		return ""
+	}
+	if c.row != nil {
+		// the columns after the last field have no header
+		c.column()
+	}
+	return strings.Join(c.header, "\t")
+}
+
+func (c *baseSubContext) addHeader(header string) {
+	if column := c.column(); c.header[column] == "" {
+		c.header[column] = strings.ToUpper(header)
+	}
+}
+
+// setHeader names the column being rendered, replacing the header of its
+// fields.
+func (c *baseSubContext) setHeader(header string) {
+	c.header[c.column()] = header
+}
+
+// formatTime formats a unix timestamp using the given layout, in UTC. An empty
+// layout keeps the default local time representation.
+func formatTime(created int64, layout string) string {
+	t := time.Unix(created, 0)
+	if layout == "" {
+		return t.String()
+	}
+	return t.UTC().Format(layout)
+}
+
+func stripNamePrefix(ss []string) []string {
+	for i, s := range ss {
+		ss[i] = s[1:]
+	}
+
+	return ss
+}
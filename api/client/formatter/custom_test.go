@@ -1,4 +1,4 @@
-package ps
+package formatter
 
 import (
 	"reflect"
@@ -22,8 +22,8 @@ func TestContainerPsContext(t *testing.T) {
 		expHeader string
 		call      func() string
 	}{
-		{types.Container{ID: containerID}, true, stringid.TruncateID(containerID), idHeader, ctx.ID},
-		{types.Container{ID: containerID}, false, containerID, idHeader, ctx.ID},
+		{types.Container{ID: containerID}, true, stringid.TruncateID(containerID), containerIDHeader, ctx.ID},
+		{types.Container{ID: containerID}, false, containerID, containerIDHeader, ctx.ID},
 		{types.Container{Names: []string{"/foobar_baz"}}, true, "foobar_baz", namesHeader, ctx.Names},
 		{types.Container{Image: "ubuntu"}, true, "ubuntu", imageHeader, ctx.Image},
 		{types.Container{Image: "verylongimagename"}, true, "verylongimagename", imageHeader, ctx.Image},
@@ -53,7 +53,10 @@ func TestContainerPsContext(t *testing.T) {
 		{types.Container{Status: "RUNNING"}, true, "RUNNING", statusHeader, ctx.Status},
 		{types.Container{SizeRw: 10}, true, "10 B", sizeHeader, ctx.Size},
 		{types.Container{SizeRw: 10, SizeRootFs: 20}, true, "10 B (virtual 20 B)", sizeHeader, ctx.Size},
+		{types.Container{SizeRootFs: 20}, true, "20 B", virtualSizeHeader, ctx.VirtualSize},
 		{types.Container{}, true, "", labelsHeader, ctx.Labels},
+		{types.Container{Mounts: []types.MountPoint{{Name: "733908409c91817de8e92b0096373245f329f19a88e2c849f02460e9b3d1c203", Source: "/var/lib/docker/volumes/733908409c91817de8e92b0096373245f329f19a88e2c849f02460e9b3d1c203/_data"}}}, true, "733908409c91817", mountsHeader, ctx.Mounts},
+		{types.Container{Mounts: []types.MountPoint{{Source: "/home/user/data"}}}, false, "/home/user/data", mountsHeader, ctx.Mounts},
 		{types.Container{Labels: map[string]string{"cpu": "6", "storage": "ssd"}}, true, "cpu=6,storage=ssd", labelsHeader, ctx.Labels},
 		{types.Container{Created: unix}, true, "Less than a second", runningForHeader, ctx.RunningFor},
 	}
@@ -90,6 +93,16 @@ func TestContainerPsContext(t *testing.T) {
 		}
 	}
 
+	ctx = containerContext{c: types.Container{Created: unix}, timeLayout: time.RFC3339}
+	if createdAt := ctx.CreatedAt(); createdAt != time.Unix(unix, 0).UTC().Format(time.RFC3339) {
+		t.Fatalf("Expected an RFC3339 creation time, was %s\n", createdAt)
+	}
+
+	ctx = containerContext{c: types.Container{SizeRw: 10, SizeRootFs: 20}, splitSize: true}
+	if size := ctx.Size(); size != "10 B" {
+		t.Fatalf("Expected 10 B, was %s\n", size)
+	}
+
 	c1 := types.Container{Labels: map[string]string{"com.docker.swarm.swarm-id": "33", "com.docker.swarm.node_name": "ubuntu"}}
 	ctx = containerContext{c: c1, trunc: true}
 
@@ -124,3 +137,66 @@ func TestContainerPsContext(t *testing.T) {
 	}
 
 }
+
+func TestImagesContext(t *testing.T) {
+	imageID := stringid.GenerateRandomID()
+	unix := time.Now().Unix()
+
+	var ctx imageContext
+	cases := []struct {
+		imageCtx  imageContext
+		expValue  string
+		expHeader string
+		call      func() string
+	}{
+		{imageContext{
+			i:     types.Image{ID: imageID},
+			trunc: true,
+		}, stringid.TruncateID(imageID), imageIDHeader, ctx.ID},
+		{imageContext{
+			i:     types.Image{ID: imageID},
+			trunc: false,
+		}, imageID, imageIDHeader, ctx.ID},
+		{imageContext{
+			i:     types.Image{Size: 10},
+			trunc: true,
+		}, "10 B", sizeHeader, ctx.Size},
+		{imageContext{
+			i:     types.Image{VirtualSize: 20},
+			trunc: true,
+		}, "20 B", virtualSizeHeader, ctx.VirtualSize},
+		{imageContext{
+			i:     types.Image{Created: unix},
+			trunc: true,
+		}, time.Unix(unix, 0).String(), createdAtHeader, ctx.CreatedAt},
+		{imageContext{
+			i:     types.Image{Created: unix},
+			trunc: true,
+		}, "Less than a second", createdSinceHeader, ctx.CreatedSince},
+		{imageContext{
+			i:    types.Image{},
+			repo: "busybox",
+		}, "busybox", repositoryHeader, ctx.Repository},
+		{imageContext{
+			i:   types.Image{},
+			tag: "latest",
+		}, "latest", tagHeader, ctx.Tag},
+		{imageContext{
+			i:      types.Image{},
+			digest: "sha256:d149ab53f8718e987c3a3024bb8aa0e2caadf6c0328f1d9d850b2a2a67f2819a",
+		}, "sha256:d149ab53f8718e987c3a3024bb8aa0e2caadf6c0328f1d9d850b2a2a67f2819a", digestHeader, ctx.Digest},
+	}
+
+	for _, c := range cases {
+		ctx = c.imageCtx
+		v := c.call()
+		if v != c.expValue {
+			t.Fatalf("Expected %s, was %s\n", c.expValue, v)
+		}
+
+		h := ctx.fullHeader()
+		if h != c.expHeader {
+			t.Fatalf("Expected %s, was %s\n", c.expHeader, h)
+		}
+	}
+}
@@ -0,0 +1,833 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	registrytypes "github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/pkg/units"
+)
+
+const (
+	tableFormatKey = "table"
+	rawFormatKey   = "raw"
+	jsonFormatKey  = "json"
+
+	// defaultJSONFormat renders a row as a JSON object, for the contexts
+	// whose rows implement json.Marshaler.
+	defaultJSONFormat = "{{json .}}"
+
+	defaultContainerTableFormat       = "table {{.ID}}\t{{.Image}}\t{{.Command}}\t{{.RunningFor}} ago\t{{.Status}}\t{{.Ports}}\t{{.Names}}"
+	defaultImageTableFormat           = "table {{.Repository}}\t{{.Tag}}\t{{.ID}}\t{{.CreatedSince}} ago\t{{.Size}}"
+	defaultImageTableFormatWithDigest = "table {{.Repository}}\t{{.Tag}}\t{{.Digest}}\t{{.ID}}\t{{.CreatedSince}} ago\t{{.Size}}"
+	defaultQuietFormat                = "{{.ID}}"
+	defaultStatsTableFormat           = "table {{.Container}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.MemPerc}}\t{{.NetIO}}\t{{.BlockIO}}"
+	defaultHistoryTableFormat         = "table {{.ID}}\t{{.CreatedSince}}\t{{.CreatedBy}}\t{{.Size}}\t{{.Comment}}"
+	defaultSearchTableFormat          = "table {{.Name}}\t{{.Description}}\t{{.StarCount}}\t{{.IsOfficial}}\t{{.IsAutomated}}"
+	defaultNetworkTableFormat         = "table {{.ID}}\t{{.Name}}\t{{.Driver}}"
+	defaultVolumeTableFormat          = "table {{.Driver}}\t{{.Name}}"
+	defaultVolumeQuietFormat          = "{{.Name}}"
+
+	// default table formats used when creation times are displayed as absolute timestamps
+	defaultContainerTableTimeFormat       = "table {{.ID}}\t{{.Image}}\t{{.Command}}\t{{.CreatedAt}}\t{{.Status}}\t{{.Ports}}\t{{.Names}}"
+	defaultImageTableTimeFormat           = "table {{.Repository}}\t{{.Tag}}\t{{.ID}}\t{{.CreatedAt}}\t{{.Size}}"
+	defaultImageTableTimeFormatWithDigest = "table {{.Repository}}\t{{.Tag}}\t{{.Digest}}\t{{.ID}}\t{{.CreatedAt}}\t{{.Size}}"
+
+	relativeTimeFormat = "relative"
+	rfc3339TimeFormat  = "rfc3339"
+)
+
+// The configuration of the tabwriter of the table format, shared by the
+// commands printing tables.
+const (
+	tabwriterMinWidth = 20
+	tabwriterTabWidth = 1
+	tabwriterPadding  = 3
+	tabwriterPadChar  = ' '
+
+	// searchTabwriterMinWidth is the minimal cell width of the search table.
+	searchTabwriterMinWidth = 10
+)
+
+// FuncMap is the template functions available to the --format flags:
+//
+//	json          renders a value as JSON
+//	humanSize     renders a size in bytes, e.g. 1.5 MB
+//	humanDuration renders the time elapsed since a Unix timestamp or an
+//	              RFC 3339 time, or a time.Duration, e.g. 3 weeks
+//	truncate      keeps the first n characters of a string
+//	pad           pads a string with spaces to n characters
+//	upper, lower  change the case of a string
+var FuncMap = template.FuncMap{
+	"json": func(v interface{}) string {
+		a, _ := json.Marshal(v)
+		return string(a)
+	},
+	"humanSize": func(size int64) string {
+		return units.HumanSize(float64(size))
+	},
+	"humanDuration": humanDuration,
+	"truncate":      truncate,
+	"pad":           pad,
+	"upper":         strings.ToUpper,
+	"lower":         strings.ToLower,
+}
+
+// truncate keeps the first n characters of s. The length comes first so
+// that strings can be piped to it, as in {{.ID | truncate 12}}.
+func truncate(n int, s string) string {
+	if n < 0 {
+		return s
+	}
+	if runes := []rune(s); len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
+
+// pad pads s with spaces on the right to n characters. Longer strings are
+// left as is.
+func pad(s string, n int) string {
+	if l := utf8.RuneCountInString(s); l < n {
+		return s + strings.Repeat(" ", n-l)
+	}
+	return s
+}
+
+func humanDuration(v interface{}) (string, error) {
+	switch t := v.(type) {
+	case time.Duration:
+		return units.HumanDuration(t), nil
+	case time.Time:
+		return units.HumanDuration(time.Now().UTC().Sub(t)), nil
+	case int64:
+		return units.HumanDuration(time.Now().UTC().Sub(time.Unix(t, 0))), nil
+	case int:
+		return units.HumanDuration(time.Now().UTC().Sub(time.Unix(int64(t), 0))), nil
+	case string:
+		parsed, err := time.Parse(time.RFC3339Nano, t)
+		if err != nil {
+			return "", err
+		}
+		return units.HumanDuration(time.Now().UTC().Sub(parsed)), nil
+	}
+	return "", fmt.Errorf("humanDuration: unsupported value %v of type %T", v, v)
+}
+
+// NewTabWriter returns a tabwriter writing to output aligned as the table
+// format.
+func NewTabWriter(output io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(output, tabwriterMinWidth, tabwriterTabWidth, tabwriterPadding, tabwriterPadChar, 0)
+}
+
+// Context contains information required by the formatter to print the output as desired.
+type Context struct {
+	// Output is the output stream to which the formatted string is written.
+	Output io.Writer
+	// Format is used to choose raw, table or custom format for the output.
+	Format string
+	// Quiet when set to true will simply print minimal information.
+	Quiet bool
+	// Trunc when set to true will truncate the output of certain fields such as Container ID.
+	Trunc bool
+	// NoHeader when set to true will omit the header row of the table format.
+	NoHeader bool
+	// TimeFormat is used to choose how creation times are displayed: relative
+	// (the default), rfc3339 or a Go time layout. Absolute times are in UTC.
+	TimeFormat string
+	// Color when set to true will color the rows, e.g. to highlight dangling
+	// images, with ANSI escape codes.
+	Color bool
+
+	// internal element
+	table       bool
+	finalFormat string
+	header      string
+	buffer      *bytes.Buffer
+	rowColors   []string
+	current     subContext
+	minWidth    int
+}
+
+// timeLayout returns the Go time layout used to display creation times, or
+// an empty string if they're displayed relative to now.
+func (c *Context) timeLayout() string {
+	switch c.TimeFormat {
+	case "", relativeTimeFormat:
+		return ""
+	case rfc3339TimeFormat:
+		return time.RFC3339
+	}
+	return c.TimeFormat
+}
+
+func (c *Context) preformat() {
+	c.finalFormat = c.Format
+
+	if strings.HasPrefix(c.Format, tableFormatKey) {
+		c.table = true
+		c.finalFormat = c.finalFormat[len(tableFormatKey):]
+	}
+
+	c.finalFormat = strings.Trim(c.finalFormat, " ")
+	r := strings.NewReplacer(`\t`, "\t", `\n`, "\n")
+	c.finalFormat = r.Replace(c.finalFormat)
+}
+
+// parseFormat parses the format with the functions of FuncMap, and header,
+// which names the column of a table it's used in, e.g.
+// {{.Size | header "DISK"}}. header returns the value given to it, if any.
+func (c *Context) parseFormat() (*template.Template, error) {
+	funcs := template.FuncMap{
+		"header": func(header string, v ...interface{}) interface{} {
+			if c.current != nil {
+				c.current.setHeader(header)
+			}
+			if len(v) > 0 {
+				return v[0]
+			}
+			return ""
+		},
+	}
+	tmpl, err := template.New("").Funcs(FuncMap).Funcs(funcs).Parse(c.finalFormat)
+	if err != nil {
+		c.buffer.WriteString(fmt.Sprintf("Template parsing error: %v\n", err))
+		c.buffer.WriteTo(c.Output)
+	}
+	return tmpl, err
+}
+
+func (c *Context) postformat(tmpl *template.Template, subContext subContext) {
+	if c.table {
+		if len(c.header) == 0 {
+			// if we still don't have a header, we didn't have any containers so we need to fake it to get the right headers from the template
+			row := bytes.NewBufferString("")
+			c.current = subContext
+			subContext.startRow(row)
+			tmpl.Execute(row, subContext)
+			c.header = subContext.fullHeader()
+		}
+
+		output := c.Output
+		if c.Color {
+			// the rows are colored once aligned, so that the escape codes
+			// don't count in the widths of the columns
+			output = &bytes.Buffer{}
+		}
+		t := NewTabWriter(output)
+		if c.minWidth > 0 {
+			t = tabwriter.NewWriter(output, c.minWidth, tabwriterTabWidth, tabwriterPadding, tabwriterPadChar, 0)
+		}
+		if !c.NoHeader {
+			t.Write([]byte(c.header))
+			t.Write([]byte("\n"))
+		}
+		c.buffer.WriteTo(t)
+		t.Flush()
+		if c.Color {
+			c.writeColoredRows(output.(*bytes.Buffer))
+		}
+	} else {
+		c.buffer.WriteTo(c.Output)
+	}
+}
+
+// writeColoredRows writes the aligned table to the output, coloring each
+// row with the color of its sub context.
+func (c *Context) writeColoredRows(table *bytes.Buffer) {
+	lines := strings.SplitAfter(table.String(), "\n")
+	if !c.NoHeader && len(lines) > 0 {
+		io.WriteString(c.Output, lines[0])
+		lines = lines[1:]
+	}
+	for i, line := range lines {
+		if i >= len(c.rowColors) || c.rowColors[i] == "" || line == "" {
+			io.WriteString(c.Output, line)
+			continue
+		}
+		io.WriteString(c.Output, c.rowColors[i]+strings.TrimSuffix(line, "\n")+colorReset+"\n")
+	}
+}
+
+func (c *Context) contextFormat(tmpl *template.Template, subContext subContext) error {
+	color := ""
+	if colorer, ok := subContext.(colorer); ok && c.Color {
+		color = colorer.color()
+	}
+	if color != "" && !c.table {
+		c.buffer.WriteString(color)
+	}
+	c.current = subContext
+	subContext.startRow(c.buffer)
+	if err := tmpl.Execute(c.buffer, subContext); err != nil {
+		c.buffer = bytes.NewBufferString(fmt.Sprintf("Template parsing error: %v\n", err))
+		c.buffer.WriteTo(c.Output)
+		return err
+	}
+	if c.table && len(c.header) == 0 {
+		c.header = subContext.fullHeader()
+	}
+	if color != "" && !c.table {
+		c.buffer.WriteString(colorReset)
+	}
+	c.rowColors = append(c.rowColors, color)
+	c.buffer.WriteString("\n")
+	return nil
+}
+
+// ContainerContext contains container specific information required by the formater, encapsulate a Context struct.
+type ContainerContext struct {
+	Context
+	// Size when set to true will display the size of the output.
+	Size bool
+	// SplitSize when set to true will display the writable layer size and
+	// the virtual size in separate columns instead of a single one.
+	SplitSize bool
+	// Containers
+	Containers []types.Container
+}
+
+// ImageContext contains image specific information required by the formater, encapsulate a Context struct.
+type ImageContext struct {
+	Context
+	// Digest when set to true will display the digest column.
+	Digest bool
+	// Group when set to true will render each image once, with its
+	// repositories, tags and digests joined by commas.
+	Group bool
+	// Limit when greater than zero is the maximum number of rows rendered.
+	// The table format ends with the number of rows left out.
+	Limit int
+	// InvalidReference, if set, is called for each repository tag or digest
+	// of an image which isn't a valid reference. Such references are
+	// rendered as the repository, with an <invalid> tag.
+	InvalidReference func(imageID, ref string, err error)
+	// Images
+	Images []types.Image
+
+	// internal element
+	rows int
+	more int
+}
+
+// ContainerStats contains the resource usage statistics of a container.
+type ContainerStats struct {
+	// Container is the name or ID used to request the statistics.
+	Container        string
+	Name             string
+	CPUPercentage    float64
+	Memory           float64
+	MemoryLimit      float64
+	MemoryPercentage float64
+	NetworkRx        float64
+	NetworkTx        float64
+	BlockRead        float64
+	BlockWrite       float64
+	PidsCurrent      uint64
+}
+
+// StatsContext contains container stats specific information required by
+// the formatter, encapsulate a Context struct.
+type StatsContext struct {
+	Context
+	// Stats
+	Stats []ContainerStats
+}
+
+// HistoryContext contains image history specific information required by
+// the formatter, encapsulate a Context struct.
+type HistoryContext struct {
+	Context
+	// Human when set to true will display the creation times and sizes in
+	// a human readable format.
+	Human bool
+	// History
+	History []types.ImageHistory
+}
+
+// SearchContext contains search results specific information required by
+// the formatter, encapsulate a Context struct.
+type SearchContext struct {
+	Context
+	// Results
+	Results []registrytypes.SearchResult
+}
+
+// NetworkContext contains network specific information required by the
+// formatter, encapsulate a Context struct.
+type NetworkContext struct {
+	Context
+	// Networks
+	Networks []types.NetworkResource
+}
+
+// VolumeContext contains volume specific information required by the
+// formatter, encapsulate a Context struct.
+type VolumeContext struct {
+	Context
+	// Volumes
+	Volumes []*types.Volume
+}
+
+// Write renders the containers using the Context format to the Context output.
+func (ctx ContainerContext) Write() {
+	switch ctx.Format {
+	case tableFormatKey:
+		ctx.Format = defaultContainerTableFormat
+		if ctx.timeLayout() != "" {
+			ctx.Format = defaultContainerTableTimeFormat
+		}
+		if ctx.Quiet {
+			ctx.Format = defaultQuietFormat
+		}
+	case jsonFormatKey:
+		ctx.Format = defaultJSONFormat
+	case rawFormatKey:
+		if ctx.Quiet {
+			ctx.Format = `container_id: {{.ID}}`
+		} else {
+			ctx.Format = `container_id: {{.ID}}
+image: {{.Image}}
+command: {{.Command}}
+created_at: {{.CreatedAt}}
+status: {{.Status}}
+names: {{.Names}}
+labels: {{.Labels}}
+ports: {{.Ports}}
+`
+			if ctx.Size {
+				ctx.Format += `size: {{.Size}}
+`
+				if ctx.SplitSize {
+					ctx.Format += `virtual_size: {{.VirtualSize}}
+`
+				}
+			}
+		}
+	}
+
+	ctx.buffer = bytes.NewBufferString("")
+	ctx.preformat()
+	if ctx.table && ctx.Size {
+		ctx.finalFormat += "\t{{.Size}}"
+		if ctx.SplitSize {
+			ctx.finalFormat += "\t{{.VirtualSize}}"
+		}
+	}
+
+	tmpl, err := ctx.parseFormat()
+	if err != nil {
+		return
+	}
+
+	for _, container := range ctx.Containers {
+		containerCtx := &containerContext{
+			trunc:      ctx.Trunc,
+			splitSize:  ctx.SplitSize,
+			timeLayout: ctx.timeLayout(),
+			c:          container,
+		}
+		err = ctx.contextFormat(tmpl, containerCtx)
+		if err != nil {
+			return
+		}
+	}
+
+	ctx.postformat(tmpl, &containerContext{splitSize: ctx.SplitSize})
+}
+
+// Write renders the images using the Context format to the Context output.
+// An image is rendered once per repository tag or digest it's known by.
+func (ctx ImageContext) Write() {
+	switch ctx.Format {
+	case tableFormatKey:
+		ctx.Format = defaultImageTableFormat
+		if ctx.Digest {
+			ctx.Format = defaultImageTableFormatWithDigest
+		}
+		if ctx.timeLayout() != "" {
+			ctx.Format = defaultImageTableTimeFormat
+			if ctx.Digest {
+				ctx.Format = defaultImageTableTimeFormatWithDigest
+			}
+		}
+		if ctx.Quiet {
+			ctx.Format = defaultQuietFormat
+		}
+	case jsonFormatKey:
+		ctx.Format = defaultJSONFormat
+	case rawFormatKey:
+		if ctx.Quiet {
+			ctx.Format = `image_id: {{.ID}}`
+		} else {
+			if ctx.Digest {
+				ctx.Format = `repository: {{ .Repository }}
+tag: {{.Tag}}
+digest: {{.Digest}}
+image_id: {{.ID}}
+created_at: {{.CreatedAt}}
+virtual_size: {{.Size}}
+`
+			} else {
+				ctx.Format = `repository: {{ .Repository }}
+tag: {{.Tag}}
+image_id: {{.ID}}
+created_at: {{.CreatedAt}}
+virtual_size: {{.Size}}
+`
+			}
+		}
+	}
+
+	ctx.buffer = bytes.NewBufferString("")
+	ctx.preformat()
+	if ctx.table && ctx.Digest && !strings.Contains(ctx.Format, "{{.Digest}}") {
+		ctx.finalFormat += "\t{{.Digest}}"
+	}
+
+	tmpl, err := ctx.parseFormat()
+	if err != nil {
+		return
+	}
+
+	for _, image := range ctx.Images {
+		repoTags := image.RepoTags
+		repoDigests := image.RepoDigests
+
+		if len(repoTags) == 1 && repoTags[0] == "<none>:<none>" && len(repoDigests) == 1 && repoDigests[0] == "<none>@<none>" {
+			// dangling image - clear out either repoTags or repoDigsts so we only show it once below
+			repoDigests = []string{}
+		}
+
+		var group imageGroup
+
+		// combine the tags and digests lists, in a new slice so that the
+		// spare capacity of the tags of the image isn't written to
+		tagsAndDigests := make([]string, 0, len(repoTags)+len(repoDigests))
+		tagsAndDigests = append(tagsAndDigests, repoTags...)
+		tagsAndDigests = append(tagsAndDigests, repoDigests...)
+		for _, repoAndRef := range tagsAndDigests {
+			// default repo, tag, and digest to none - if there's a value, it'll be set below
+			repo := "<none>"
+			tag := "<none>"
+			digest := "<none>"
+
+			if !strings.HasPrefix(repoAndRef, "<none>") {
+				ref, err := reference.ParseNamed(repoAndRef)
+				if err != nil {
+					if ctx.InvalidReference != nil {
+						ctx.InvalidReference(image.ID, repoAndRef, err)
+					}
+					repo = repoAndRef
+					tag = "<invalid>"
+				} else {
+					repo = ref.Name()
+
+					switch x := ref.(type) {
+					case reference.Digested:
+						digest = x.Digest().String()
+					case reference.Tagged:
+						tag = x.Tag()
+					}
+				}
+			}
+			if ctx.Group {
+				group.add(repo, tag, digest)
+				continue
+			}
+			imageCtx := &imageContext{
+				trunc:      ctx.Trunc,
+				timeLayout: ctx.timeLayout(),
+				i:          image,
+				repo:       repo,
+				tag:        tag,
+				digest:     digest,
+			}
+			err = ctx.render(tmpl, imageCtx)
+			if err != nil {
+				return
+			}
+		}
+
+		if ctx.Group && group.refs > 0 {
+			imageCtx := &imageContext{
+				trunc:      ctx.Trunc,
+				timeLayout: ctx.timeLayout(),
+				i:          image,
+				repo:       joinRefs(group.repos),
+				tag:        joinRefs(group.tags),
+				digest:     joinRefs(group.digests),
+			}
+			err = ctx.render(tmpl, imageCtx)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	ctx.postformat(tmpl, &imageContext{})
+	if ctx.more > 0 && ctx.table {
+		fmt.Fprintf(ctx.Output, "... (%d more)\n", ctx.more)
+	}
+}
+
+// render renders the row of an image, unless the limit of rows is reached.
+func (ctx *ImageContext) render(tmpl *template.Template, imageCtx *imageContext) error {
+	if ctx.Limit > 0 && ctx.rows >= ctx.Limit {
+		ctx.more++
+		return nil
+	}
+	ctx.rows++
+	return ctx.contextFormat(tmpl, imageCtx)
+}
+
+// imageGroup collects the distinct repositories, tags and digests of an
+// image, in the order they're listed, to render the image once.
+type imageGroup struct {
+	refs    int
+	repos   []string
+	tags    []string
+	digests []string
+}
+
+func (g *imageGroup) add(repo, tag, digest string) {
+	g.refs++
+	g.repos = appendRef(g.repos, repo)
+	g.tags = appendRef(g.tags, tag)
+	g.digests = appendRef(g.digests, digest)
+}
+
+// appendRef appends the value to the list, unless it's <none> or already
+// listed.
+func appendRef(list []string, value string) []string {
+	if value == "<none>" {
+		return list
+	}
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
+
+// joinRefs joins the values with commas, or returns <none> if there are no
+// values.
+func joinRefs(values []string) string {
+	if len(values) == 0 {
+		return "<none>"
+	}
+	return strings.Join(values, ",")
+}
+
+// Write renders the container stats using the Context format to the Context
+// output. Unlike the other contexts it returns the template errors, so that
+// callers refreshing the output can stop.
+func (ctx StatsContext) Write() error {
+	switch ctx.Format {
+	case tableFormatKey:
+		ctx.Format = defaultStatsTableFormat
+	case rawFormatKey:
+		ctx.Format = `container: {{.Container}}
+name: {{.Name}}
+cpu: {{.CPUPerc}}
+mem_usage: {{.MemUsage}}
+mem: {{.MemPerc}}
+net_io: {{.NetIO}}
+block_io: {{.BlockIO}}
+pids: {{.PIDs}}
+`
+	}
+
+	ctx.buffer = bytes.NewBufferString("")
+	ctx.preformat()
+
+	tmpl, err := ctx.parseFormat()
+	if err != nil {
+		return err
+	}
+
+	for _, stats := range ctx.Stats {
+		if err := ctx.contextFormat(tmpl, &statsContext{s: stats}); err != nil {
+			return err
+		}
+	}
+
+	ctx.postformat(tmpl, &statsContext{})
+	return nil
+}
+
+// Write renders the history of an image using the Context format to the
+// Context output.
+func (ctx HistoryContext) Write() {
+	switch ctx.Format {
+	case tableFormatKey:
+		ctx.Format = defaultHistoryTableFormat
+		if ctx.Quiet {
+			ctx.Format = defaultQuietFormat
+		}
+	case rawFormatKey:
+		if ctx.Quiet {
+			ctx.Format = `image_id: {{.ID}}`
+		} else {
+			ctx.Format = `image_id: {{.ID}}
+created_since: {{.CreatedSince}}
+created_at: {{.CreatedAt}}
+created_by: {{.CreatedBy}}
+size: {{.Size}}
+comment: {{.Comment}}
+`
+		}
+	}
+
+	ctx.buffer = bytes.NewBufferString("")
+	ctx.preformat()
+
+	tmpl, err := ctx.parseFormat()
+	if err != nil {
+		return
+	}
+
+	for _, entry := range ctx.History {
+		historyCtx := &historyContext{
+			trunc: ctx.Trunc,
+			human: ctx.Human,
+			h:     entry,
+		}
+		err = ctx.contextFormat(tmpl, historyCtx)
+		if err != nil {
+			return
+		}
+	}
+
+	ctx.postformat(tmpl, &historyContext{})
+}
+
+// Write renders the search results using the Context format to the Context
+// output.
+func (ctx SearchContext) Write() {
+	switch ctx.Format {
+	case tableFormatKey:
+		ctx.Format = defaultSearchTableFormat
+	case rawFormatKey:
+		ctx.Format = `name: {{.Name}}
+description: {{.Description}}
+star_count: {{.StarCount}}
+is_official: {{.IsOfficial}}
+is_automated: {{.IsAutomated}}
+`
+	}
+
+	ctx.buffer = bytes.NewBufferString("")
+	ctx.minWidth = searchTabwriterMinWidth
+	ctx.preformat()
+
+	tmpl, err := ctx.parseFormat()
+	if err != nil {
+		return
+	}
+
+	for _, result := range ctx.Results {
+		searchCtx := &searchContext{
+			trunc: ctx.Trunc,
+			s:     result,
+		}
+		err = ctx.contextFormat(tmpl, searchCtx)
+		if err != nil {
+			return
+		}
+	}
+
+	ctx.postformat(tmpl, &searchContext{})
+}
+
+// Write renders the networks using the Context format to the Context output.
+func (ctx NetworkContext) Write() {
+	switch ctx.Format {
+	case tableFormatKey:
+		ctx.Format = defaultNetworkTableFormat
+		if ctx.Quiet {
+			ctx.Format = defaultQuietFormat
+		}
+	case rawFormatKey:
+		if ctx.Quiet {
+			ctx.Format = `network_id: {{.ID}}`
+		} else {
+			ctx.Format = `network_id: {{.ID}}
+name: {{.Name}}
+driver: {{.Driver}}
+scope: {{.Scope}}
+`
+		}
+	}
+
+	ctx.buffer = bytes.NewBufferString("")
+	ctx.preformat()
+
+	tmpl, err := ctx.parseFormat()
+	if err != nil {
+		return
+	}
+
+	for _, network := range ctx.Networks {
+		networkCtx := &networkContext{
+			trunc: ctx.Trunc,
+			n:     network,
+		}
+		err = ctx.contextFormat(tmpl, networkCtx)
+		if err != nil {
+			return
+		}
+	}
+
+	ctx.postformat(tmpl, &networkContext{})
+}
+
+// Write renders the volumes using the Context format to the Context output.
+func (ctx VolumeContext) Write() {
+	switch ctx.Format {
+	case tableFormatKey:
+		ctx.Format = defaultVolumeTableFormat
+		if ctx.Quiet {
+			ctx.Format = defaultVolumeQuietFormat
+		}
+	case rawFormatKey:
+		if ctx.Quiet {
+			ctx.Format = `name: {{.Name}}`
+		} else {
+			ctx.Format = `name: {{.Name}}
+driver: {{.Driver}}
+mountpoint: {{.Mountpoint}}
+`
+		}
+	}
+
+	ctx.buffer = bytes.NewBufferString("")
+	ctx.preformat()
+
+	tmpl, err := ctx.parseFormat()
+	if err != nil {
+		return
+	}
+
+	for _, volume := range ctx.Volumes {
+		volumeCtx := &volumeContext{
+			v: volume,
+		}
+		err = ctx.contextFormat(tmpl, volumeCtx)
+		if err != nil {
+			return
+		}
+	}
+
+	ctx.postformat(tmpl, &volumeContext{v: &types.Volume{}})
+}
@@ -0,0 +1,1267 @@
+package formatter
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	registrytypes "github.com/docker/docker/api/types/registry"
+)
+
+func TestContainerContextWrite(t *testing.T) {
+	contexts := []struct {
+		context  ContainerContext
+		expected string
+	}{
+		// Errors
+		{
+			ContainerContext{
+				Context: Context{
+					Format: "{{InvalidFunction}}",
+				},
+			},
+			`Template parsing error: template: :1: function "InvalidFunction" not defined
+`,
+		},
+		{
+			ContainerContext{
+				Context: Context{
+					Format: "{{nil}}",
+				},
+			},
+			`Template parsing error: template: :1:2: executing "" at <nil>: nil is not a command
+`,
+		},
+		// Table Format
+		{
+			ContainerContext{
+				Context: Context{
+					Format: "table",
+				},
+			},
+			`CONTAINER ID        IMAGE               COMMAND             CREATED             STATUS              PORTS               NAMES
+containerID1        ubuntu              ""                  45 years ago                                                foobar_baz
+containerID2        ubuntu              ""                  45 years ago                                                foobar_bar
+`,
+		},
+		{
+			ContainerContext{
+				Context: Context{
+					Format: "table {{.Image}}",
+				},
+			},
+			"IMAGE\nubuntu\nubuntu\n",
+		},
+		{
+			ContainerContext{
+				Context: Context{
+					Format: "table {{.Image}}",
+				},
+				Size: true,
+			},
+			"IMAGE               SIZE\nubuntu              0 B\nubuntu              0 B\n",
+		},
+		{
+			ContainerContext{
+				Context: Context{
+					Format: "table {{.Image}}",
+				},
+				Size:      true,
+				SplitSize: true,
+			},
+			"IMAGE               SIZE                VIRTUAL SIZE\nubuntu              0 B                 0 B\nubuntu              0 B                 0 B\n",
+		},
+		{
+			ContainerContext{
+				Context: Context{
+					Format: "table {{.Image}}",
+					Quiet:  true,
+				},
+			},
+			"IMAGE\nubuntu\nubuntu\n",
+		},
+		{
+			ContainerContext{
+				Context: Context{
+					Format: "table",
+					Quiet:  true,
+				},
+			},
+			"containerID1\ncontainerID2\n",
+		},
+		// Raw Format
+		{
+			ContainerContext{
+				Context: Context{
+					Format: "raw",
+				},
+			},
+			`container_id: containerID1
+image: ubuntu
+command: ""
+created_at: 1970-01-01 00:00:00 +0000 UTC
+status: 
+names: foobar_baz
+labels: 
+ports: 
+
+container_id: containerID2
+image: ubuntu
+command: ""
+created_at: 1970-01-01 00:00:00 +0000 UTC
+status: 
+names: foobar_bar
+labels: 
+ports: 
+
+`,
+		},
+		{
+			ContainerContext{
+				Context: Context{
+					Format: "raw",
+				},
+				Size: true,
+			},
+			`container_id: containerID1
+image: ubuntu
+command: ""
+created_at: 1970-01-01 00:00:00 +0000 UTC
+status: 
+names: foobar_baz
+labels: 
+ports: 
+size: 0 B
+
+container_id: containerID2
+image: ubuntu
+command: ""
+created_at: 1970-01-01 00:00:00 +0000 UTC
+status: 
+names: foobar_bar
+labels: 
+ports: 
+size: 0 B
+
+`,
+		},
+		{
+			ContainerContext{
+				Context: Context{
+					Format: "raw",
+					Quiet:  true,
+				},
+			},
+			"container_id: containerID1\ncontainer_id: containerID2\n",
+		},
+		// Custom Format
+		{
+			ContainerContext{
+				Context: Context{
+					Format: "{{.Image}}",
+				},
+			},
+			"ubuntu\nubuntu\n",
+		},
+		{
+			ContainerContext{
+				Context: Context{
+					Format: "{{.Image}}",
+				},
+				Size: true,
+			},
+			"ubuntu\nubuntu\n",
+		},
+	}
+
+	for _, context := range contexts {
+		containers := []types.Container{
+			{ID: "containerID1", Names: []string{"/foobar_baz"}, Image: "ubuntu"},
+			{ID: "containerID2", Names: []string{"/foobar_bar"}, Image: "ubuntu"},
+		}
+		out := bytes.NewBufferString("")
+		context.context.Output = out
+		context.context.Containers = containers
+		context.context.Write()
+		actual := out.String()
+		if actual != context.expected {
+			t.Fatalf("Expected \n%s, got \n%s", context.expected, actual)
+		}
+		// Clean buffer
+		out.Reset()
+	}
+}
+
+func TestContainerContextWriteJSON(t *testing.T) {
+	containers := []types.Container{
+		{
+			ID:      "containerID1",
+			Names:   []string{"/web"},
+			Image:   "nginx",
+			ImageID: "sha256:imageID1",
+			Command: "nginx -g 'daemon off;'",
+			Created: 0,
+			Status:  "Up 2 minutes",
+			Ports: []types.Port{
+				{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+				{PrivatePort: 443, Type: "tcp"},
+			},
+			Labels: map[string]string{"role": "proxy"},
+		},
+		{ID: "containerID2", Names: []string{"/db"}, Image: "postgres", ImageID: "sha256:imageID2", Created: 0},
+	}
+	expected := `{"ID":"containerID1","Names":["web"],"Image":"nginx","ImageID":"sha256:imageID1","Command":"nginx -g 'daemon off;'","CreatedAt":"1970-01-01T00:00:00Z","Status":"Up 2 minutes","Ports":[{"ip":"0.0.0.0","privatePort":80,"publicPort":8080,"type":"tcp"},{"privatePort":443,"type":"tcp"}],"Labels":{"role":"proxy"},"Size":0,"VirtualSize":0}
+{"ID":"containerID2","Names":["db"],"Image":"postgres","ImageID":"sha256:imageID2","Command":"","CreatedAt":"1970-01-01T00:00:00Z","Status":"","Ports":[],"Labels":null,"Size":0,"VirtualSize":0}
+`
+
+	for _, format := range []string{"{{json .}}", "json"} {
+		out := bytes.NewBufferString("")
+		ctx := ContainerContext{
+			Context: Context{
+				Format: format,
+				Output: out,
+				Trunc:  true,
+			},
+			Containers: containers,
+		}
+		ctx.Write()
+		if actual := out.String(); actual != expected {
+			t.Fatalf("Expected with format %s\n%s, got\n%s", format, expected, actual)
+		}
+	}
+}
+
+func TestContainerContextWriteWithNoContainers(t *testing.T) {
+	out := bytes.NewBufferString("")
+	containers := []types.Container{}
+
+	contexts := []struct {
+		context  ContainerContext
+		expected string
+	}{
+		{
+			ContainerContext{
+				Context: Context{
+					Format: "{{.Image}}",
+					Output: out,
+				},
+			},
+			"",
+		},
+		{
+			ContainerContext{
+				Context: Context{
+					Format: "table {{.Image}}",
+					Output: out,
+				},
+			},
+			"IMAGE\n",
+		},
+		{
+			ContainerContext{
+				Context: Context{
+					Format: "{{.Image}}",
+					Output: out,
+				},
+				Size: true,
+			},
+			"",
+		},
+		{
+			ContainerContext{
+				Context: Context{
+					Format: "table {{.Image}}",
+					Output: out,
+				},
+				Size: true,
+			},
+			"IMAGE               SIZE\n",
+		},
+	}
+
+	for _, context := range contexts {
+		context.context.Containers = containers
+		context.context.Write()
+		actual := out.String()
+		if actual != context.expected {
+			t.Fatalf("Expected \n%s, got \n%s", context.expected, actual)
+		}
+		// Clean buffer
+		out.Reset()
+	}
+}
+
+func TestImageContextWrite(t *testing.T) {
+	unixTime := time.Now().AddDate(0, 0, -1).Unix()
+	expectedTime := time.Unix(unixTime, 0).String()
+	rfc3339Time := time.Unix(unixTime, 0).UTC().Format(time.RFC3339)
+
+	contexts := []struct {
+		context  ImageContext
+		expected string
+	}{
+		// Errors
+		{
+			ImageContext{
+				Context: Context{
+					Format: "{{InvalidFunction}}",
+				},
+			},
+			`Template parsing error: template: :1: function "InvalidFunction" not defined
+`,
+		},
+		{
+			ImageContext{
+				Context: Context{
+					Format: "{{nil}}",
+				},
+			},
+			`Template parsing error: template: :1:2: executing "" at <nil>: nil is not a command
+`,
+		},
+		// Table Format
+		{
+			ImageContext{
+				Context: Context{
+					Format: "table",
+				},
+			},
+			`REPOSITORY          TAG                 IMAGE ID            CREATED             SIZE
+image               tag1                imageID1            24 hours ago        0 B
+image               <none>              imageID1            24 hours ago        0 B
+image               tag2                imageID2            24 hours ago        0 B
+<none>              <none>              imageID3            24 hours ago        0 B
+`,
+		},
+		{
+			ImageContext{
+				Context: Context{
+					Format: "table {{.Repository}}",
+				},
+			},
+			"REPOSITORY\nimage\nimage\nimage\n<none>\n",
+		},
+		{
+			ImageContext{
+				Context: Context{
+					Format: "table {{.Repository}}",
+				},
+				Digest: true,
+			},
+			`REPOSITORY          DIGEST
+image               <none>
+image               sha256:cbbf2f9a99b47fc460d422812b6a5adff7dfee951d8fa2e4a98caa0382cfbdbf
+image               <none>
+<none>              <none>
+`,
+		},
+		{
+			ImageContext{
+				Context: Context{
+					Format: "table {{.Repository}}",
+					Quiet:  true,
+				},
+			},
+			"REPOSITORY\nimage\nimage\nimage\n<none>\n",
+		},
+		{
+			ImageContext{
+				Context: Context{
+					Format:   "table {{.Repository}}\t{{.Tag}}",
+					NoHeader: true,
+				},
+			},
+			"image               tag1\nimage               <none>\nimage               tag2\n<none>              <none>\n",
+		},
+		{
+			ImageContext{
+				Context: Context{
+					Format: "table",
+					Quiet:  true,
+				},
+			},
+			"imageID1\nimageID1\nimageID2\nimageID3\n",
+		},
+		{
+			ImageContext{
+				Context: Context{
+					Format: "table",
+					Quiet:  false,
+				},
+				Digest: true,
+			},
+			`REPOSITORY          TAG                 DIGEST                                                                    IMAGE ID            CREATED             SIZE
+image               tag1                <none>                                                                    imageID1            24 hours ago        0 B
+image               <none>              sha256:cbbf2f9a99b47fc460d422812b6a5adff7dfee951d8fa2e4a98caa0382cfbdbf   imageID1            24 hours ago        0 B
+image               tag2                <none>                                                                    imageID2            24 hours ago        0 B
+<none>              <none>              <none>                                                                    imageID3            24 hours ago        0 B
+`,
+		},
+		{
+			ImageContext{
+				Context: Context{
+					Format: "table",
+					Quiet:  true,
+				},
+				Digest: true,
+			},
+			"imageID1\nimageID1\nimageID2\nimageID3\n",
+		},
+		{
+			ImageContext{
+				Context: Context{
+					Format:     "table",
+					TimeFormat: "rfc3339",
+				},
+			},
+			fmt.Sprintf(`REPOSITORY          TAG                 IMAGE ID            CREATED AT             SIZE
+image               tag1                imageID1            %s   0 B
+image               <none>              imageID1            %s   0 B
+image               tag2                imageID2            %s   0 B
+<none>              <none>              imageID3            %s   0 B
+`, rfc3339Time, rfc3339Time, rfc3339Time, rfc3339Time),
+		},
+		{
+			ImageContext{
+				Context: Context{
+					Format:     "{{.CreatedAt}}",
+					TimeFormat: "2006-01-02",
+				},
+			},
+			strings.Repeat(time.Unix(unixTime, 0).UTC().Format("2006-01-02")+"\n", 4),
+		},
+		// Raw Format
+		{
+			ImageContext{
+				Context: Context{
+					Format: "raw",
+				},
+			},
+			fmt.Sprintf(`repository: image
+tag: tag1
+image_id: imageID1
+created_at: %s
+virtual_size: 0 B
+
+repository: image
+tag: <none>
+image_id: imageID1
+created_at: %s
+virtual_size: 0 B
+
+repository: image
+tag: tag2
+image_id: imageID2
+created_at: %s
+virtual_size: 0 B
+
+repository: <none>
+tag: <none>
+image_id: imageID3
+created_at: %s
+virtual_size: 0 B
+
+`, expectedTime, expectedTime, expectedTime, expectedTime),
+		},
+		{
+			ImageContext{
+				Context: Context{
+					Format: "raw",
+					Quiet:  true,
+				},
+			},
+			`image_id: imageID1
+image_id: imageID1
+image_id: imageID2
+image_id: imageID3
+`,
+		},
+		// Custom Format
+		{
+			ImageContext{
+				Context: Context{
+					Format: "{{.Repository}}",
+				},
+			},
+			"image\nimage\nimage\n<none>\n",
+		},
+		{
+			ImageContext{
+				Context: Context{
+					Format: "{{.Repository}}",
+				},
+				Digest: true,
+			},
+			"image\nimage\nimage\n<none>\n",
+		},
+		// JSON Format
+		{
+			ImageContext{
+				Context: Context{
+					Format: "{{json .}}",
+					Trunc:  true,
+				},
+			},
+			fmt.Sprintf(`{"Repository":"image","Tag":"tag1","Digest":"\u003cnone\u003e","ID":"imageID1","CreatedAt":"%s","Size":0,"VirtualSize":0}
+{"Repository":"image","Tag":"\u003cnone\u003e","Digest":"sha256:cbbf2f9a99b47fc460d422812b6a5adff7dfee951d8fa2e4a98caa0382cfbdbf","ID":"imageID1","CreatedAt":"%s","Size":0,"VirtualSize":0}
+{"Repository":"image","Tag":"tag2","Digest":"\u003cnone\u003e","ID":"imageID2","CreatedAt":"%s","Size":0,"VirtualSize":0}
+{"Repository":"\u003cnone\u003e","Tag":"\u003cnone\u003e","Digest":"\u003cnone\u003e","ID":"imageID3","CreatedAt":"%s","Size":0,"VirtualSize":0}
+`, rfc3339Time, rfc3339Time, rfc3339Time, rfc3339Time),
+		},
+		{
+			ImageContext{
+				Context: Context{
+					Format: "json",
+				},
+			},
+			fmt.Sprintf(`{"Repository":"image","Tag":"tag1","Digest":"\u003cnone\u003e","ID":"imageID1","CreatedAt":"%s","Size":0,"VirtualSize":0}
+{"Repository":"image","Tag":"\u003cnone\u003e","Digest":"sha256:cbbf2f9a99b47fc460d422812b6a5adff7dfee951d8fa2e4a98caa0382cfbdbf","ID":"imageID1","CreatedAt":"%s","Size":0,"VirtualSize":0}
+{"Repository":"image","Tag":"tag2","Digest":"\u003cnone\u003e","ID":"imageID2","CreatedAt":"%s","Size":0,"VirtualSize":0}
+{"Repository":"\u003cnone\u003e","Tag":"\u003cnone\u003e","Digest":"\u003cnone\u003e","ID":"imageID3","CreatedAt":"%s","Size":0,"VirtualSize":0}
+`, rfc3339Time, rfc3339Time, rfc3339Time, rfc3339Time),
+		},
+		// Template functions
+		{
+			ImageContext{
+				Context: Context{
+					Format: "{{.ID}} {{humanSize 1536}}",
+				},
+			},
+			"imageID1 1.536 kB\nimageID1 1.536 kB\nimageID2 1.536 kB\nimageID3 1.536 kB\n",
+		},
+	}
+
+	for _, context := range contexts {
+		images := []types.Image{
+			{ID: "imageID1", RepoTags: []string{"image:tag1"}, RepoDigests: []string{"image@sha256:cbbf2f9a99b47fc460d422812b6a5adff7dfee951d8fa2e4a98caa0382cfbdbf"}, Created: unixTime},
+			{ID: "imageID2", RepoTags: []string{"image:tag2"}, Created: unixTime},
+			{ID: "imageID3", RepoTags: []string{"<none>:<none>"}, RepoDigests: []string{"<none>@<none>"}, Created: unixTime},
+		}
+		out := bytes.NewBufferString("")
+		context.context.Output = out
+		context.context.Images = images
+		context.context.Write()
+		actual := out.String()
+		if actual != context.expected {
+			t.Fatalf("Expected \n%s, got \n%s", context.expected, actual)
+		}
+		// Clean buffer
+		out.Reset()
+	}
+}
+
+func TestImageContextWriteWithNoImage(t *testing.T) {
+	out := bytes.NewBufferString("")
+	images := []types.Image{}
+
+	contexts := []struct {
+		context  ImageContext
+		expected string
+	}{
+		{
+			ImageContext{
+				Context: Context{
+					Format: "{{.Repository}}",
+					Output: out,
+				},
+			},
+			"",
+		},
+		{
+			ImageContext{
+				Context: Context{
+					Format: "table {{.Repository}}",
+					Output: out,
+				},
+			},
+			"REPOSITORY\n",
+		},
+		{
+			ImageContext{
+				Context: Context{
+					Format: "{{.Repository}}",
+					Output: out,
+				},
+				Digest: true,
+			},
+			"",
+		},
+		{
+			ImageContext{
+				Context: Context{
+					Format: "table {{.Repository}}",
+					Output: out,
+				},
+				Digest: true,
+			},
+			"REPOSITORY          DIGEST\n",
+		},
+	}
+
+	for _, context := range contexts {
+		context.context.Images = images
+		context.context.Write()
+		actual := out.String()
+		if actual != context.expected {
+			t.Fatalf("Expected \n%s, got \n%s", context.expected, actual)
+		}
+		// Clean buffer
+		out.Reset()
+	}
+}
+
+func TestImageContextWriteDoesntModifyImages(t *testing.T) {
+	digest := "sha256:cbbf2f9a99b47fc460d422812b6a5adff7dfee951d8fa2e4a98caa0382cfbdbf"
+	// the tags have spare capacity, which appending the digests to them
+	// would write to
+	repoTags := make([]string, 1, 3)
+	repoTags[0] = "image:tag1"
+	spare := repoTags[:cap(repoTags)]
+	spare[1], spare[2] = "spare1", "spare2"
+
+	images := []types.Image{
+		{ID: "imageID1", RepoTags: repoTags, RepoDigests: []string{"image@" + digest}},
+	}
+	out := bytes.NewBufferString("")
+	ctx := ImageContext{
+		Context: Context{
+			Format: "{{.Repository}} {{.Tag}} {{.Digest}}",
+			Output: out,
+		},
+		Images: images,
+	}
+	ctx.Write()
+
+	expected := "image tag1 <none>\nimage <none> " + digest + "\n"
+	if out.String() != expected {
+		t.Fatalf("Expected \n%s, got \n%s", expected, out.String())
+	}
+	if len(images[0].RepoTags) != 1 || images[0].RepoTags[0] != "image:tag1" {
+		t.Fatalf("Expected the tags of the image to be unchanged, got %v", images[0].RepoTags)
+	}
+	if spare[1] != "spare1" || spare[2] != "spare2" {
+		t.Fatalf("Expected the spare capacity of the tags to be untouched, got %v", spare)
+	}
+}
+
+func TestImageContextWriteInvalidReferences(t *testing.T) {
+	digest := "sha256:cbbf2f9a99b47fc460d422812b6a5adff7dfee951d8fa2e4a98caa0382cfbdbf"
+	images := []types.Image{
+		{ID: "imageID1", RepoTags: []string{"image:tag1", "Invalid:tag"}, RepoDigests: []string{"image@" + digest}},
+		{ID: "imageID2", RepoTags: []string{"image:tag2"}, RepoDigests: []string{"image@sha256:short"}},
+	}
+
+	for _, group := range []bool{false, true} {
+		out := bytes.NewBufferString("")
+		var invalid []string
+		ctx := ImageContext{
+			Context: Context{
+				Format: "{{.ID}} {{.Repository}} {{.Tag}} {{.Digest}}",
+				Output: out,
+			},
+			Group:  group,
+			Images: images,
+			InvalidReference: func(imageID, ref string, err error) {
+				invalid = append(invalid, imageID+" "+ref)
+			},
+		}
+		ctx.Write()
+
+		expected := `imageID1 image tag1 <none>
+imageID1 Invalid:tag <invalid> <none>
+imageID1 image <none> ` + digest + `
+imageID2 image tag2 <none>
+imageID2 image@sha256:short <invalid> <none>
+`
+		if group {
+			expected = `imageID1 image,Invalid:tag tag1,<invalid> ` + digest + `
+imageID2 image,image@sha256:short tag2,<invalid> <none>
+`
+		}
+		if out.String() != expected {
+			t.Fatalf("Expected \n%s, got \n%s", expected, out.String())
+		}
+		expectedInvalid := []string{"imageID1 Invalid:tag", "imageID2 image@sha256:short"}
+		if !reflect.DeepEqual(invalid, expectedInvalid) {
+			t.Fatalf("Expected the invalid references %v, got %v", expectedInvalid, invalid)
+		}
+	}
+}
+
+func TestImageContextWriteLimit(t *testing.T) {
+	images := []types.Image{
+		{ID: "imageID1", RepoTags: []string{"image:tag1", "image:tag2"}},
+		{ID: "imageID2", RepoTags: []string{"image:tag3"}},
+		{ID: "imageID3", RepoTags: []string{"<none>:<none>"}, RepoDigests: []string{"<none>@<none>"}},
+	}
+
+	cases := []struct {
+		format   string
+		limit    int
+		group    bool
+		expected string
+	}{
+		{"{{.ID}} {{.Tag}}", 0, false, "imageID1 tag1\nimageID1 tag2\nimageID2 tag3\nimageID3 <none>\n"},
+		{"{{.ID}} {{.Tag}}", 2, false, "imageID1 tag1\nimageID1 tag2\n"},
+		{"{{.ID}} {{.Tag}}", 4, false, "imageID1 tag1\nimageID1 tag2\nimageID2 tag3\nimageID3 <none>\n"},
+		{"{{.ID}} {{.Tag}}", 2, true, "imageID1 tag1,tag2\nimageID2 tag3\n"},
+		{"table {{.ID}}\t{{.Tag}}", 3, false, "IMAGE ID            TAG\nimageID1            tag1\nimageID1            tag2\nimageID2            tag3\n... (1 more)\n"},
+		{"table {{.ID}}\t{{.Tag}}", 1, true, "IMAGE ID            TAG\nimageID1            tag1,tag2\n... (2 more)\n"},
+	}
+
+	for _, c := range cases {
+		out := bytes.NewBufferString("")
+		ctx := ImageContext{
+			Context: Context{
+				Format: c.format,
+				Output: out,
+			},
+			Group:  c.group,
+			Limit:  c.limit,
+			Images: images,
+		}
+		ctx.Write()
+		if out.String() != c.expected {
+			t.Fatalf("Expected %s with a limit of %d to render \n%s, got \n%s", c.format, c.limit, c.expected, out.String())
+		}
+	}
+}
+
+func TestImageContextWriteGroup(t *testing.T) {
+	digest := "sha256:cbbf2f9a99b47fc460d422812b6a5adff7dfee951d8fa2e4a98caa0382cfbdbf"
+	images := []types.Image{
+		{ID: "imageID1", RepoTags: []string{"image:tag1", "image:tag2"}, RepoDigests: []string{"image@" + digest}},
+		{ID: "imageID2", RepoTags: []string{"image:tag3", "other:tag3"}},
+		{ID: "imageID3", RepoTags: []string{"<none>:<none>"}, RepoDigests: []string{"<none>@<none>"}},
+		{ID: "imageID4", RepoTags: []string{"<none>:<none>"}, RepoDigests: []string{"image@" + digest}},
+	}
+
+	out := bytes.NewBufferString("")
+	ctx := ImageContext{
+		Context: Context{
+			Format: "{{.ID}} {{.Repository}} {{.Tag}} {{.Digest}}",
+			Output: out,
+		},
+		Group:  true,
+		Images: images,
+	}
+	ctx.Write()
+
+	expected := `imageID1 image tag1,tag2 ` + digest + `
+imageID2 image,other tag3 <none>
+imageID3 <none> <none> <none>
+imageID4 image <none> ` + digest + `
+`
+	if out.String() != expected {
+		t.Fatalf("Expected \n%s, got \n%s", expected, out.String())
+	}
+}
+
+func TestImageContextWriteHeaders(t *testing.T) {
+	images := []types.Image{
+		{ID: "imageID1", RepoTags: []string{"foo:bar"}, Size: 10},
+	}
+
+	cases := []struct {
+		format   string
+		expected string
+	}{
+		{"table {{.Repository}}\t{{.Size}}", "REPOSITORY          SIZE"},
+		{`table {{.Repository | header "IMAGE"}}\t{{header "DISK" .Size}}`, "IMAGE               DISK"},
+		{`table {{header "NAME"}}{{.Repository}}:{{.Tag}}\t{{.Size}}`, "NAME                SIZE"},
+		// a column gets the header of its first field
+		{"table {{.Repository}}:{{.Tag}}\t{{.ID}}", "REPOSITORY          IMAGE ID"},
+		// a column without fields has no header
+		{"table {{.Repository}}\tsize:\t{{.Size}}", "REPOSITORY                              SIZE"},
+	}
+
+	for _, c := range cases {
+		for _, images := range [][]types.Image{images, nil} {
+			out := bytes.NewBufferString("")
+			ctx := ImageContext{
+				Context: Context{
+					Format: c.format,
+					Output: out,
+				},
+				Images: images,
+			}
+			ctx.Write()
+			header := strings.SplitN(out.String(), "\n", 2)[0]
+			if header != c.expected {
+				t.Fatalf("Expected the header of %s with %d images to be %q, got %q", c.format, len(images), c.expected, header)
+			}
+		}
+	}
+}
+
+// TestDefaultHeaders checks the headers of the fields of the images and the
+// containers. They're the names of the fields in upper case, with spaces
+// between the words, except where noted.
+func TestDefaultHeaders(t *testing.T) {
+	imageHeaders := map[string]string{
+		"ID":           "IMAGE ID",
+		"Repository":   "REPOSITORY",
+		"Tag":          "TAG",
+		"Digest":       "DIGEST",
+		"CreatedSince": "CREATED",
+		"CreatedAt":    "CREATED AT",
+		"Size":         "SIZE",
+		"VirtualSize":  "VIRTUAL SIZE",
+	}
+	for field, expected := range imageHeaders {
+		out := bytes.NewBufferString("")
+		ImageContext{Context: Context{Format: "table {{." + field + "}}", Output: out}}.Write()
+		if header := strings.TrimSuffix(out.String(), "\n"); header != expected {
+			t.Fatalf("Expected the header of image field %s to be %q, got %q", field, expected, header)
+		}
+	}
+
+	containerHeaders := map[string]string{
+		"ID":                "CONTAINER ID",
+		"Names":             "NAMES",
+		"Image":             "IMAGE",
+		"Command":           "COMMAND",
+		"CreatedAt":         "CREATED AT",
+		"RunningFor":        "CREATED",
+		"Ports":             "PORTS",
+		"Status":            "STATUS",
+		"Size":              "SIZE",
+		"VirtualSize":       "VIRTUAL SIZE",
+		"Mounts":            "MOUNTS",
+		"Labels":            "LABELS",
+		`Label "com.a.b-c"`: "B C",
+	}
+	for field, expected := range containerHeaders {
+		out := bytes.NewBufferString("")
+		ContainerContext{Context: Context{Format: "table {{." + field + "}}", Output: out}}.Write()
+		if header := strings.TrimSuffix(out.String(), "\n"); header != expected {
+			t.Fatalf("Expected the header of container field %s to be %q, got %q", field, expected, header)
+		}
+	}
+}
+
+func TestStatsContextWrite(t *testing.T) {
+	stats := []ContainerStats{
+		{
+			Container:        "app",
+			Name:             "web",
+			CPUPercentage:    30.0,
+			Memory:           100 * 1024 * 1024.0,
+			MemoryLimit:      2048 * 1024 * 1024.0,
+			MemoryPercentage: 100.0 / 2048.0 * 100.0,
+			NetworkRx:        100 * 1024 * 1024,
+			NetworkTx:        800 * 1024 * 1024,
+			BlockRead:        100 * 1024 * 1024,
+			BlockWrite:       800 * 1024 * 1024,
+			PidsCurrent:      3,
+		},
+	}
+
+	contexts := []struct {
+		context  StatsContext
+		expected string
+	}{
+		{
+			StatsContext{Context: Context{Format: "table"}},
+			`CONTAINER           CPU %               MEM USAGE / LIMIT     MEM %               NET I/O               BLOCK I/O
+app                 30.00%              104.9 MB / 2.147 GB   4.88%               104.9 MB / 838.9 MB   104.9 MB / 838.9 MB
+`,
+		},
+		{
+			StatsContext{Context: Context{Format: "table {{.Name}}\t{{.PIDs}}"}},
+			"NAME                PIDS\nweb                 3\n",
+		},
+		{
+			StatsContext{Context: Context{Format: "{{.Container}}: {{.CPUPerc}} {{.MemPerc}}"}},
+			"app: 30.00% 4.88%\n",
+		},
+		{
+			StatsContext{Context: Context{Format: "raw"}},
+			`container: app
+name: web
+cpu: 30.00%
+mem_usage: 104.9 MB / 2.147 GB
+mem: 4.88%
+net_io: 104.9 MB / 838.9 MB
+block_io: 104.9 MB / 838.9 MB
+pids: 3
+
+`,
+		},
+	}
+
+	for _, context := range contexts {
+		out := bytes.NewBufferString("")
+		context.context.Output = out
+		context.context.Stats = stats
+		if err := context.context.Write(); err != nil {
+			t.Fatal(err)
+		}
+		if actual := out.String(); actual != context.expected {
+			t.Fatalf("Expected \n%q, got \n%q", context.expected, actual)
+		}
+	}
+}
+
+func TestStatsContextWriteTemplateError(t *testing.T) {
+	out := bytes.NewBufferString("")
+	context := StatsContext{
+		Context: Context{Format: "{{InvalidFunction}}", Output: out},
+		Stats:   []ContainerStats{{Container: "app"}},
+	}
+	if err := context.Write(); err == nil {
+		t.Fatal("Expected a template error")
+	}
+	if !strings.HasPrefix(out.String(), "Template parsing error") {
+		t.Fatalf("Expected a template parsing error, got %q", out.String())
+	}
+}
+
+func TestStatsContextWriteWithNoStats(t *testing.T) {
+	out := bytes.NewBufferString("")
+	context := StatsContext{Context: Context{Format: "table {{.Container}}\t{{.PIDs}}", Output: out}}
+	if err := context.Write(); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "CONTAINER           PIDS\n"; out.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestHistoryContextWrite(t *testing.T) {
+	created := time.Now().Add(-2 * time.Hour)
+	createdAt := created.Format(time.RFC3339)
+	history := []types.ImageHistory{
+		{
+			ID:        "sha256:1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b",
+			Created:   created.Unix(),
+			CreatedBy: "/bin/sh -c #(nop) ADD file:0123456789abcdef0123456789abcdef in /",
+			Size:      1024 * 1024,
+			Comment:   "imported",
+		},
+	}
+
+	contexts := []struct {
+		context  HistoryContext
+		expected string
+	}{
+		{
+			HistoryContext{Context: Context{Format: "table", Trunc: true}, Human: true},
+			`IMAGE               CREATED             CREATED BY                                      SIZE                COMMENT
+1a2b3c4d5e6f        2 hours ago         /bin/sh -c #(nop) ADD file:0123456789abcdef01   1.049 MB            imported
+`,
+		},
+		{
+			HistoryContext{Context: Context{Format: "table", Trunc: true, Quiet: true}, Human: true},
+			"1a2b3c4d5e6f\n",
+		},
+		{
+			HistoryContext{Context: Context{Format: "table {{.ID}}\t{{.Size}}", Trunc: true}},
+			"IMAGE               SIZE\n1a2b3c4d5e6f        1048576\n",
+		},
+		{
+			HistoryContext{Context: Context{Format: "{{.CreatedAt}} {{.CreatedSince}}"}},
+			fmt.Sprintf("%s %s\n", createdAt, createdAt),
+		},
+		{
+			HistoryContext{Context: Context{Format: "{{.CreatedBy}}", Trunc: true}},
+			"/bin/sh -c #(nop) ADD file:0123456789abcdef01\n",
+		},
+		{
+			HistoryContext{Context: Context{Format: "{{.ID}}|{{.CreatedBy}}"}},
+			"sha256:1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b|/bin/sh -c #(nop) ADD file:0123456789abcdef0123456789abcdef in /\n",
+		},
+	}
+
+	for _, context := range contexts {
+		out := bytes.NewBufferString("")
+		context.context.Output = out
+		context.context.History = history
+		context.context.Write()
+		if actual := out.String(); actual != context.expected {
+			t.Fatalf("Expected \n%q, got \n%q", context.expected, actual)
+		}
+	}
+}
+
+func TestSearchContextWrite(t *testing.T) {
+	results := []registrytypes.SearchResult{
+		{
+			Name:        "busybox",
+			Description: "Busybox base image.",
+			StarCount:   325,
+			IsOfficial:  true,
+		},
+		{
+			Name:        "radial/busyboxplus",
+			Description: "Full-chain, Internet enabled, busybox made from scratch.\nComes in git and cURL flavors.",
+			StarCount:   8,
+			IsAutomated: true,
+		},
+	}
+
+	contexts := []struct {
+		context  SearchContext
+		expected string
+	}{
+		{
+			SearchContext{Context: Context{Format: "table", Trunc: true}},
+			`NAME                 DESCRIPTION                                     STARS     OFFICIAL   AUTOMATED
+busybox              Busybox base image.                             325       [OK]       
+radial/busyboxplus   Full-chain, Internet enabled, busybox made...   8                    [OK]
+`,
+		},
+		{
+			SearchContext{Context: Context{Format: "{{.Name}}: {{.Description}}"}},
+			"busybox: Busybox base image.\nradial/busyboxplus: Full-chain, Internet enabled, busybox made from scratch. Comes in git and cURL flavors.\n",
+		},
+		{
+			SearchContext{Context: Context{Format: "table {{.Name}}\t{{.StarCount}}", Trunc: true}},
+			"NAME                 STARS\nbusybox              325\nradial/busyboxplus   8\n",
+		},
+		{
+			SearchContext{Context: Context{Format: "{{.Name}} {{.IsOfficial}}{{.IsAutomated}}"}},
+			"busybox [OK]\nradial/busyboxplus [OK]\n",
+		},
+	}
+
+	for _, context := range contexts {
+		out := bytes.NewBufferString("")
+		context.context.Output = out
+		context.context.Results = results
+		context.context.Write()
+		if actual := out.String(); actual != context.expected {
+			t.Fatalf("Expected \n%q, got \n%q", context.expected, actual)
+		}
+	}
+}
+
+func TestNetworkContextWrite(t *testing.T) {
+	networks := []types.NetworkResource{
+		{ID: "networkID1aaaaaaaaaaaaaaa", Name: "foobar_baz", Driver: "bridge", Scope: "local"},
+		{ID: "networkID2bbbbbbbbbbbbbbb", Name: "foobar_bar", Driver: "overlay", Scope: "global"},
+	}
+
+	contexts := []struct {
+		context  NetworkContext
+		expected string
+	}{
+		{
+			NetworkContext{Context: Context{Format: "table", Trunc: true}},
+			`NETWORK ID          NAME                DRIVER
+networkID1aa        foobar_baz          bridge
+networkID2bb        foobar_bar          overlay
+`,
+		},
+		{
+			NetworkContext{Context: Context{Format: "table", Trunc: true, Quiet: true}},
+			"networkID1aa\nnetworkID2bb\n",
+		},
+		{
+			NetworkContext{Context: Context{Format: "table {{.Name}}\t{{.Scope}}"}},
+			"NAME                SCOPE\nfoobar_baz          local\nfoobar_bar          global\n",
+		},
+		{
+			NetworkContext{Context: Context{Format: "{{.ID}} {{.Driver}}"}},
+			"networkID1aaaaaaaaaaaaaaa bridge\nnetworkID2bbbbbbbbbbbbbbb overlay\n",
+		},
+		{
+			NetworkContext{Context: Context{Format: "raw", Trunc: true}},
+			`network_id: networkID1aa
+name: foobar_baz
+driver: bridge
+scope: local
+
+network_id: networkID2bb
+name: foobar_bar
+driver: overlay
+scope: global
+
+`,
+		},
+	}
+
+	for _, context := range contexts {
+		out := bytes.NewBufferString("")
+		context.context.Output = out
+		context.context.Networks = networks
+		context.context.Write()
+		if actual := out.String(); actual != context.expected {
+			t.Fatalf("Expected \n%q, got \n%q", context.expected, actual)
+		}
+	}
+}
+
+func TestVolumeContextWrite(t *testing.T) {
+	volumes := []*types.Volume{
+		{Name: "foobar_baz", Driver: "local", Mountpoint: "/var/lib/docker/volumes/foobar_baz/_data"},
+		{Name: "foobar_bar", Driver: "flocker", Mountpoint: "/flocker/foobar_bar"},
+	}
+
+	contexts := []struct {
+		context  VolumeContext
+		expected string
+	}{
+		{
+			VolumeContext{Context: Context{Format: "table"}},
+			`DRIVER              VOLUME NAME
+local               foobar_baz
+flocker             foobar_bar
+`,
+		},
+		{
+			VolumeContext{Context: Context{Format: "table", Quiet: true}},
+			"foobar_baz\nfoobar_bar\n",
+		},
+		{
+			VolumeContext{Context: Context{Format: "table {{.Name}}\t{{.Mountpoint}}"}},
+			"VOLUME NAME         MOUNTPOINT\nfoobar_baz          /var/lib/docker/volumes/foobar_baz/_data\nfoobar_bar          /flocker/foobar_bar\n",
+		},
+		{
+			VolumeContext{Context: Context{Format: "{{.Name}} {{.Driver}}"}},
+			"foobar_baz local\nfoobar_bar flocker\n",
+		},
+		{
+			VolumeContext{Context: Context{Format: "raw"}},
+			`name: foobar_baz
+driver: local
+mountpoint: /var/lib/docker/volumes/foobar_baz/_data
+
+name: foobar_bar
+driver: flocker
+mountpoint: /flocker/foobar_bar
+
+`,
+		},
+	}
+
+	for _, context := range contexts {
+		out := bytes.NewBufferString("")
+		context.context.Output = out
+		context.context.Volumes = volumes
+		context.context.Write()
+		if actual := out.String(); actual != context.expected {
+			t.Fatalf("Expected \n%q, got \n%q", context.expected, actual)
+		}
+	}
+}
+
+func TestVolumeContextWriteEmpty(t *testing.T) {
+	out := bytes.NewBufferString("")
+	VolumeContext{Context: Context{Format: "table", Output: out}}.Write()
+	if expected := "DRIVER              VOLUME NAME\n"; out.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestHumanDuration(t *testing.T) {
+	now := time.Now().UTC()
+	values := map[string]interface{}{
+		"duration": 3 * time.Hour,
+		"time":     now.Add(-3 * time.Hour),
+		"unix":     now.Add(-3 * time.Hour).Unix(),
+		"rfc3339":  now.Add(-3 * time.Hour).Format(time.RFC3339Nano),
+	}
+	for name, v := range values {
+		d, err := humanDuration(v)
+		if err != nil {
+			t.Fatalf("Expected no error for the %s, got %v", name, err)
+		}
+		if d != "3 hours" {
+			t.Fatalf("Expected 3 hours for the %s, got %s", name, d)
+		}
+	}
+
+	for _, v := range []interface{}{"yesterday", 3.5, nil} {
+		if _, err := humanDuration(v); err == nil {
+			t.Fatalf("Expected an error for %v", v)
+		}
+	}
+}
+
+func TestFuncMap(t *testing.T) {
+	image := types.Image{
+		ID:       "sha256:cbbf2f9a99b47fc460d422812b6a5adff7dfee951d8fa2e4a98caa0382cfbdbf",
+		RepoTags: []string{"Ubuntu:Latest"},
+		Size:     188300000,
+	}
+	container := types.Container{
+		ID:    "3f2a9c1e5b8d7a6f4e3d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7f6e5d4c3b2a1f",
+		Names: []string{"/web"},
+	}
+	cases := []struct {
+		format   string
+		data     interface{}
+		expected string
+	}{
+		{`{{.ID | truncate 19}}`, image, "sha256:cbbf2f9a99b4"},
+		{`{{truncate 12 .ID}}`, container, "3f2a9c1e5b8d"},
+		{`{{truncate 12 "short"}}`, container, "short"},
+		{`{{truncate 2 "héllo"}}`, container, "hé"},
+		{`{{upper (index .RepoTags 0)}}`, image, "UBUNTU:LATEST"},
+		{`{{lower (index .RepoTags 0)}}`, image, "ubuntu:latest"},
+		{`[{{pad (index .Names 0) 8}}]`, container, "[/web    ]"},
+		{`[{{pad (index .Names 0) 2}}]`, container, "[/web]"},
+		{`{{humanSize .Size}}`, image, "188.3 MB"},
+		{`{{json .Names}}`, container, `["/web"]`},
+	}
+	for _, c := range cases {
+		tmpl, err := template.New("").Funcs(FuncMap).Parse(c.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out bytes.Buffer
+		if err := tmpl.Execute(&out, c.data); err != nil {
+			t.Fatalf("Expected no error for %s, got %v", c.format, err)
+		}
+		if out.String() != c.expected {
+			t.Fatalf("Expected %q for %s, got %q", c.expected, c.format, out.String())
+		}
+	}
+}
+
+func TestContextWriteColor(t *testing.T) {
+	images := []types.Image{
+		{ID: "imageID1", RepoTags: []string{"image:tag1"}},
+		{ID: "imageID2", RepoTags: []string{"<none>:<none>"}, RepoDigests: []string{"<none>@<none>"}},
+	}
+	containers := []types.Container{
+		{ID: "containerID1", Names: []string{"/running"}, Status: "Up 2 minutes"},
+		{ID: "containerID2", Names: []string{"/exited"}, Status: "Exited (0) 3 minutes ago"},
+		{ID: "containerID3", Names: []string{"/paused"}, Status: "Up 2 minutes (Paused)"},
+		{ID: "containerID4", Names: []string{"/created"}, Status: "Created"},
+	}
+
+	// the columns are aligned as without the escape codes
+	out := bytes.NewBufferString("")
+	ImageContext{Context: Context{Output: out, Format: "table {{.ID}}\t{{.Repository}}\t{{.Tag}}", Color: true}, Images: images}.Write()
+	expected := "IMAGE ID            REPOSITORY          TAG\n" +
+		"imageID1            image               tag1\n" +
+		colorYellow + "imageID2            <none>              <none>" + colorReset + "\n"
+	if actual := out.String(); actual != expected {
+		t.Fatalf("Expected \n%q, got \n%q", expected, actual)
+	}
+
+	out.Reset()
+	ImageContext{Context: Context{Output: out, Format: "table {{.ID}}\t{{.Repository}}", NoHeader: true, Color: true}, Images: images}.Write()
+	expected = "imageID1            image\n" +
+		colorYellow + "imageID2            <none>" + colorReset + "\n"
+	if actual := out.String(); actual != expected {
+		t.Fatalf("Expected \n%q, got \n%q", expected, actual)
+	}
+
+	out.Reset()
+	ContainerContext{Context: Context{Output: out, Format: "{{.Names}}", Color: true}, Containers: containers}.Write()
+	expected = colorGreen + "running" + colorReset + "\n" +
+		colorRed + "exited" + colorReset + "\n" +
+		colorYellow + "paused" + colorReset + "\n" +
+		"created\n"
+	if actual := out.String(); actual != expected {
+		t.Fatalf("Expected \n%q, got \n%q", expected, actual)
+	}
+}
@@ -3,14 +3,11 @@ package client
 import (
 	"fmt"
 	"strconv"
-	"strings"
-	"text/tabwriter"
-	"time"
 
+	"github.com/docker/docker/api/client/formatter"
+	"github.com/docker/docker/api/types"
 	Cli "github.com/docker/docker/cli"
 	flag "github.com/docker/docker/pkg/mflag"
-	"github.com/docker/docker/pkg/stringid"
-	"github.com/docker/docker/pkg/stringutils"
 	"github.com/docker/docker/pkg/units"
 )
 
@@ -22,6 +19,8 @@ func (cli *DockerCli) CmdHistory(args ...string) error {
 	human := cmd.Bool([]string{"H", "-human"}, true, "Print sizes and dates in human readable format")
 	quiet := cmd.Bool([]string{"q", "-quiet"}, false, "Only show numeric IDs")
 	noTrunc := cmd.Bool([]string{"-no-trunc"}, false, "Don't truncate output")
+	format := cmd.String([]string{"-format"}, "", "Pretty-print history using a Go template")
+	total := cmd.Bool([]string{"-total"}, false, "Print the total size of the layers")
 	cmd.Require(flag.Exact, 1)
 
 	cmd.ParseFlags(args, true)
@@ -31,44 +30,40 @@ func (cli *DockerCli) CmdHistory(args ...string) error {
 		return err
 	}
 
-	w := tabwriter.NewWriter(cli.out, 20, 1, 3, ' ', 0)
-
-	if *quiet {
-		for _, entry := range history {
-			if *noTrunc {
-				fmt.Fprintf(w, "%s\n", entry.ID)
-			} else {
-				fmt.Fprintf(w, "%s\n", stringid.TruncateID(entry.ID))
-			}
-		}
-		w.Flush()
-		return nil
+	f := *format
+	if len(f) == 0 {
+		f = "table"
 	}
 
-	var imageID string
-	var createdBy string
-	var created string
-	var size string
+	historyCtx := formatter.HistoryContext{
+		Context: formatter.Context{
+			Output: cli.out,
+			Format: f,
+			Quiet:  *quiet,
+			Trunc:  !*noTrunc,
+		},
+		Human:   *human,
+		History: history,
+	}
 
-	fmt.Fprintln(w, "IMAGE\tCREATED\tCREATED BY\tSIZE\tCOMMENT")
-	for _, entry := range history {
-		imageID = entry.ID
-		createdBy = strings.Replace(entry.CreatedBy, "\t", " ", -1)
-		if *noTrunc == false {
-			createdBy = stringutils.Truncate(createdBy, 45)
-			imageID = stringid.TruncateID(entry.ID)
-		}
+	historyCtx.Write()
 
+	if *total && !*quiet {
+		size := historySize(history)
 		if *human {
-			created = units.HumanDuration(time.Now().UTC().Sub(time.Unix(entry.Created, 0))) + " ago"
-			size = units.HumanSize(float64(entry.Size))
+			fmt.Fprintf(cli.out, "Total size: %s\n", units.HumanSize(float64(size)))
 		} else {
-			created = time.Unix(entry.Created, 0).Format(time.RFC3339)
-			size = strconv.FormatInt(entry.Size, 10)
+			fmt.Fprintf(cli.out, "Total size: %s\n", strconv.FormatInt(size, 10))
 		}
-
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", imageID, created, createdBy, size, entry.Comment)
 	}
-	w.Flush()
 	return nil
 }
+
+// historySize returns the sum of the sizes of the layers in the history.
+func historySize(history []types.ImageHistory) int64 {
+	var size int64
+	for _, h := range history {
+		size += h.Size
+	}
+	return size
+}
@@ -0,0 +1,21 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestHistorySize(t *testing.T) {
+	history := []types.ImageHistory{
+		{ID: "a", Size: 1245184},
+		{ID: "<missing>", Size: 0},
+		{ID: "<missing>", Size: 121000001},
+	}
+	if size := historySize(history); size != 122245185 {
+		t.Fatalf("Expected a total size of 122245185, got %d", size)
+	}
+	if size := historySize(nil); size != 0 {
+		t.Fatalf("Expected a total size of 0 without layers, got %d", size)
+	}
+}
@@ -2,29 +2,45 @@ package client
 
 import (
 	"fmt"
+	"path"
+	"sort"
 	"strings"
-	"text/tabwriter"
 	"time"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/client/formatter"
+	"github.com/docker/docker/api/client/lib"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
+	timetypes "github.com/docker/docker/api/types/time"
 	Cli "github.com/docker/docker/cli"
 	"github.com/docker/docker/opts"
 	flag "github.com/docker/docker/pkg/mflag"
 	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/docker/pkg/units"
+	"golang.org/x/net/context"
 )
 
 // CmdImages lists the images in a specified repository, or all top-level images if no repository is specified.
 //
-// Usage: docker images [OPTIONS] [REPOSITORY]
+// Usage: docker images [OPTIONS] [REPOSITORY[:TAG|@DIGEST]]
 func (cli *DockerCli) CmdImages(args ...string) error {
-	cmd := Cli.Subcmd("images", []string{"[REPOSITORY[:TAG]]"}, Cli.DockerCommands["images"].Description, true)
+	cmd := Cli.Subcmd("images", []string{"[REPOSITORY[:TAG|@DIGEST]]"}, Cli.DockerCommands["images"].Description, true)
 	quiet := cmd.Bool([]string{"q", "-quiet"}, false, "Only show numeric IDs")
 	all := cmd.Bool([]string{"a", "-all"}, false, "Show all images (default hides intermediate images)")
 	noTrunc := cmd.Bool([]string{"-no-trunc"}, false, "Don't truncate output")
 	showDigests := cmd.Bool([]string{"-digests"}, false, "Show digests")
+	group := cmd.Bool([]string{"-group"}, false, "Show each image once, with its tags and digests joined by commas")
+	strict := cmd.Bool([]string{"-strict"}, false, "Exit with an error if an image has an invalid reference")
+	limit := cmd.Int([]string{"-limit"}, 0, "Show only the first N rows, 0 for all")
+	noHeader := cmd.Bool([]string{"-no-header"}, false, "Don't print the header row")
+	format := cmd.String([]string{"-format"}, "", "Pretty-print images using a Go template")
+	sortBy := cmd.String([]string{"-sort"}, "", "Sort images by size, created or repository")
+	sortOrder := cmd.String([]string{"-sort-order"}, "asc", "Sort order used with --sort, asc or desc")
+	summary := cmd.Bool([]string{"-summary"}, false, "Print the number of images and their total size")
+	timeFormat := cmd.String([]string{"-time-format"}, "relative", "Display creation times as relative, rfc3339 or a Go time layout")
+	color := addColorFlag(cmd)
 
 	flFilter := opts.NewListOpts(nil)
 	cmd.Var(&flFilter, []string{"f", "-filter"}, "Filter output based on conditions provided")
@@ -32,11 +48,25 @@ func (cli *DockerCli) CmdImages(args ...string) error {
 
 	cmd.ParseFlags(args, true)
 
+	less, err := imagesLessFunc(*sortBy)
+	if err != nil {
+		return err
+	}
+	if *sortOrder != "asc" && *sortOrder != "desc" {
+		return fmt.Errorf("%q is not a valid value for --sort-order", *sortOrder)
+	}
+	if *limit < 0 {
+		return fmt.Errorf("%d is not a valid value for --limit, it must be positive", *limit)
+	}
+	useColor, err := colorOutput(*color, cli.isTerminalOut)
+	if err != nil {
+		return err
+	}
+
 	// Consolidate all filter flags, and sanity check them early.
 	// They'll get process in the daemon/server.
 	imageFilterArgs := filters.NewArgs()
 	for _, f := range flFilter.GetAll() {
-		var err error
 		imageFilterArgs, err = filters.ParseFlag(f, imageFilterArgs)
 		if err != nil {
 			return err
@@ -46,6 +76,29 @@ func (cli *DockerCli) CmdImages(args ...string) error {
 	var matchName string
 	if cmd.NArg() == 1 {
 		matchName = cmd.Arg(0)
+		if strings.Contains(matchName, "@") {
+			// The daemon only matches names and tags, the digest is
+			// matched by the client with a reference filter.
+			ref, err := parseDigestReference(matchName)
+			if err != nil {
+				return err
+			}
+			imageFilterArgs.Add("reference", ref.Name()+"@"+ref.Digest().String())
+			matchName = ref.Name()
+		}
+	}
+
+	f := *format
+	if len(f) == 0 {
+		f = envFormat("DOCKER_IMAGES_FORMAT", *quiet)
+	}
+	if len(f) == 0 {
+		f = "table"
+	}
+	// The summary would break the output of the other formats, which is
+	// meant for scripts.
+	if *summary && !strings.HasPrefix(f, "table") {
+		return fmt.Errorf("--summary can only be used with a table format")
 	}
 
 	options := types.ImageListOptions{
@@ -54,71 +107,417 @@ func (cli *DockerCli) CmdImages(args ...string) error {
 		Filters:   imageFilterArgs,
 	}
 
-	images, err := cli.client.ImageList(options)
+	ctx, cancel := interruptContext()
+	defer cancel()
+
+	images, err := cli.listImages(ctx, options)
 	if err != nil {
 		return err
 	}
 
-	w := tabwriter.NewWriter(cli.out, 20, 1, 3, ' ', 0)
-	if !*quiet {
-		if *showDigests {
-			fmt.Fprintln(w, "REPOSITORY\tTAG\tDIGEST\tIMAGE ID\tCREATED\tSIZE")
-		} else {
-			fmt.Fprintln(w, "REPOSITORY\tTAG\tIMAGE ID\tCREATED\tSIZE")
+	if less != nil {
+		// Sort the images themselves rather than the rendered rows, so the
+		// rows for each repository tag or digest of an image stay grouped.
+		var sorter sort.Interface = imagesSorter{images, less}
+		if *sortOrder == "desc" {
+			sorter = sort.Reverse(sorter)
+		}
+		sort.Stable(sorter)
+	}
+
+	imagesCtx := formatter.ImageContext{
+		Context: formatter.Context{
+			Output:     cli.out,
+			Format:     f,
+			Quiet:      *quiet,
+			Trunc:      !*noTrunc,
+			NoHeader:   *noHeader,
+			TimeFormat: *timeFormat,
+			Color:      useColor,
+		},
+		Digest: *showDigests,
+		Group:  *group,
+		Limit:  *limit,
+		Images: images,
+	}
+	invalidRefs := 0
+	imagesCtx.InvalidReference = func(imageID, ref string, err error) {
+		invalidRefs++
+		fmt.Fprintf(cli.err, "WARNING: image %s has an invalid reference %q: %v\n", stringid.TruncateID(imageID), ref, err)
+	}
+
+	imagesCtx.Write()
+
+	if *summary && !*quiet {
+		inUse, err := cli.imagesInUse(ctx)
+		if err != nil {
+			return err
+		}
+		s := imagesSummary(images, inUse)
+		fmt.Fprintf(cli.out, "%d images, %s total, %s active, %s reclaimable\n", s.count,
+			units.HumanSize(float64(s.size)), units.HumanSize(float64(s.active)), units.HumanSize(float64(s.reclaimable)))
+	}
+
+	if *strict && invalidRefs > 0 {
+		return fmt.Errorf("Error: found %d invalid image references", invalidRefs)
+	}
+	return nil
+}
+
+// listImages lists the images matching the options. The filters the daemon
+// doesn't know about, reference, before, since, until and intermediate, are
+// applied by the client on the listed images and aren't sent to the daemon.
+// The listing is aborted when the context is canceled.
+func (cli *DockerCli) listImages(ctx context.Context, options types.ImageListOptions) ([]types.Image, error) {
+	imageFilterArgs := options.Filters
+	if err := validateLabelFilter(imageFilterArgs); err != nil {
+		return nil, err
+	}
+
+	referencePatterns := imageFilterArgs.Get("reference")
+	for _, pattern := range referencePatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid filter 'reference': %q is not a valid pattern", pattern)
 		}
+		imageFilterArgs.Del("reference", pattern)
 	}
 
+	var (
+		now                         = time.Now()
+		beforeCreated, sinceCreated []int64
+	)
+	for _, name := range imageFilterArgs.Get("before") {
+		created, err := cli.imageCreated(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter 'before': %v", err)
+		}
+		beforeCreated = append(beforeCreated, created)
+		imageFilterArgs.Del("before", name)
+	}
+	for _, value := range imageFilterArgs.Get("until") {
+		created, err := parseFilterTime(value, now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter 'until': %q is not a duration or a timestamp", value)
+		}
+		beforeCreated = append(beforeCreated, created)
+		imageFilterArgs.Del("until", value)
+	}
+	for _, value := range imageFilterArgs.Get("since") {
+		// since takes an image, or else a duration or a timestamp. The
+		// image comes first, so that a short image ID made of digits isn't
+		// taken as a timestamp.
+		created, err := cli.imageCreated(ctx, value)
+		if lib.IsErrImageNotFound(err) {
+			if created, err = parseFilterTime(value, now); err != nil {
+				return nil, fmt.Errorf("invalid filter 'since': %q is not an image, a duration or a timestamp", value)
+			}
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid filter 'since': %v", err)
+		}
+		sinceCreated = append(sinceCreated, created)
+		imageFilterArgs.Del("since", value)
+	}
+
+	// intermediate=true lists only the intermediate images, whether or not
+	// --all is set, and intermediate=false hides them even if it is.
+	var intermediate []bool
+	for _, value := range imageFilterArgs.Get("intermediate") {
+		switch value {
+		case "true":
+			intermediate = append(intermediate, true)
+		case "false":
+			intermediate = append(intermediate, false)
+		default:
+			return nil, fmt.Errorf("invalid filter 'intermediate': %q is not true or false", value)
+		}
+		imageFilterArgs.Del("intermediate", value)
+	}
+	if len(intermediate) > 1 && intermediate[0] != intermediate[1] {
+		return nil, fmt.Errorf("invalid filter 'intermediate': it can't be both true and false")
+	}
+	if len(intermediate) > 0 && intermediate[0] {
+		options.All = true
+	}
+
+	if logrus.GetLevel() >= logrus.DebugLevel {
+		filterJSON, _ := filters.ToParam(imageFilterArgs)
+		logrus.Debugf("Listing images: name %q, all %t, filters %s", options.MatchName, options.All, filterJSON)
+	}
+
+	images, err := cli.client.ImageListWithContext(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(intermediate) > 0 && options.All {
+		// The children of an image may not be listed if the daemon
+		// filtered the images, they're looked for in all the images.
+		parents := images
+		if options.MatchName != "" || imageFilterArgs.Include("label") || imageFilterArgs.Include("dangling") {
+			if parents, err = cli.client.ImageListWithContext(ctx, types.ImageListOptions{All: true}); err != nil {
+				return nil, err
+			}
+		}
+		images = filterImagesIntermediate(images, parents, intermediate[0])
+	}
+	if len(referencePatterns) > 0 {
+		images = filterImagesByReference(images, referencePatterns)
+	}
+	if len(beforeCreated) > 0 {
+		images = filterImagesCreatedBefore(images, beforeCreated)
+	}
+	if len(sinceCreated) > 0 {
+		images = filterImagesCreatedSince(images, sinceCreated)
+	}
+	return images, nil
+}
+
+// filterImagesIntermediate keeps the intermediate images if intermediate is
+// true, or the other images if it's false. An intermediate image is an
+// untagged image which is the parent of one of the given images.
+func filterImagesIntermediate(images, parents []types.Image, intermediate bool) []types.Image {
+	isParent := make(map[string]bool)
+	for _, image := range parents {
+		if image.ParentID != "" {
+			isParent[image.ParentID] = true
+		}
+	}
+	var filtered []types.Image
 	for _, image := range images {
-		ID := image.ID
-		if !*noTrunc {
-			ID = stringid.TruncateID(ID)
-		}
-
-		repoTags := image.RepoTags
-		repoDigests := image.RepoDigests
-
-		if len(repoTags) == 1 && repoTags[0] == "<none>:<none>" && len(repoDigests) == 1 && repoDigests[0] == "<none>@<none>" {
-			// dangling image - clear out either repoTags or repoDigsts so we only show it once below
-			repoDigests = []string{}
-		}
-
-		// combine the tags and digests lists
-		tagsAndDigests := append(repoTags, repoDigests...)
-		for _, repoAndRef := range tagsAndDigests {
-			// default repo, tag, and digest to none - if there's a value, it'll be set below
-			repo := "<none>"
-			tag := "<none>"
-			digest := "<none>"
-
-			if !strings.HasPrefix(repoAndRef, "<none>") {
-				ref, err := reference.ParseNamed(repoAndRef)
-				if err != nil {
-					return err
-				}
-				repo = ref.Name()
-
-				switch x := ref.(type) {
-				case reference.Digested:
-					digest = x.Digest().String()
-				case reference.Tagged:
-					tag = x.Tag()
-				}
+		if (isParent[image.ID] && isUntagged(image)) == intermediate {
+			filtered = append(filtered, image)
+		}
+	}
+	return filtered
+}
+
+// imageCreated returns the creation time of an image given to a filter, as a
+// Unix timestamp. The inspection is aborted when the context is canceled.
+func (cli *DockerCli) imageCreated(ctx context.Context, name string) (int64, error) {
+	image, _, err := cli.client.ImageInspectWithRawWithContext(ctx, name, false)
+	if err != nil {
+		return 0, err
+	}
+	created, err := time.Parse(time.RFC3339Nano, image.Created)
+	if err != nil {
+		return 0, err
+	}
+	return created.Unix(), nil
+}
+
+// parseFilterTime parses a duration, relative to now, or a timestamp given to
+// a filter, and returns it as a Unix timestamp.
+func parseFilterTime(value string, now time.Time) (int64, error) {
+	timestamp, err := timetypes.GetTimestamp(value, now)
+	if err != nil {
+		return 0, err
+	}
+	seconds, _, err := timetypes.ParseTimestamps(timestamp, 0)
+	return seconds, err
+}
+
+// filterImagesCreatedSince keeps the images created after all the given
+// times.
+func filterImagesCreatedSince(images []types.Image, times []int64) []types.Image {
+	var filtered []types.Image
+	for _, image := range images {
+		since := true
+		for _, t := range times {
+			if image.Created <= t {
+				since = false
+				break
 			}
+		}
+		if since {
+			filtered = append(filtered, image)
+		}
+	}
+	return filtered
+}
 
-			if !*quiet {
-				if *showDigests {
-					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s ago\t%s\n", repo, tag, digest, ID, units.HumanDuration(time.Now().UTC().Sub(time.Unix(int64(image.Created), 0))), units.HumanSize(float64(image.Size)))
-				} else {
-					fmt.Fprintf(w, "%s\t%s\t%s\t%s ago\t%s\n", repo, tag, ID, units.HumanDuration(time.Now().UTC().Sub(time.Unix(int64(image.Created), 0))), units.HumanSize(float64(image.Size)))
-				}
-			} else {
-				fmt.Fprintln(w, ID)
+// filterImagesCreatedBefore keeps the images created before all the given
+// times.
+func filterImagesCreatedBefore(images []types.Image, times []int64) []types.Image {
+	var filtered []types.Image
+	for _, image := range images {
+		before := true
+		for _, t := range times {
+			if image.Created >= t {
+				before = false
+				break
 			}
 		}
+		if before {
+			filtered = append(filtered, image)
+		}
 	}
+	return filtered
+}
 
-	if !*quiet {
-		w.Flush()
+// filterImagesByReference keeps the repository tags and digests of each image
+// that match at least one of the reference patterns, and drops the images
+// left without any of them.
+func filterImagesByReference(images []types.Image, patterns []string) []types.Image {
+	var filtered []types.Image
+	for _, image := range images {
+		image.RepoTags = matchingReferences(image.RepoTags, patterns)
+		image.RepoDigests = matchingReferences(image.RepoDigests, patterns)
+		if len(image.RepoTags) == 0 && len(image.RepoDigests) == 0 {
+			continue
+		}
+		filtered = append(filtered, image)
 	}
-	return nil
+	return filtered
 }
+
+// matchingReferences returns the references matching at least one of the
+// patterns. A pattern without a tag or digest is matched against the
+// repository name only, so that `ubuntu` matches `ubuntu:latest` but not
+// `myubuntu:latest`.
+func matchingReferences(refs []string, patterns []string) []string {
+	var matched []string
+	for _, repoAndRef := range refs {
+		if strings.HasPrefix(repoAndRef, "<none>") {
+			continue
+		}
+		ref, err := reference.ParseNamed(repoAndRef)
+		if err != nil {
+			continue
+		}
+		for _, pattern := range patterns {
+			source := repoAndRef
+			if !hasTagOrDigest(pattern) {
+				source = ref.Name()
+			}
+			if ok, _ := path.Match(pattern, source); ok {
+				matched = append(matched, repoAndRef)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// parseDigestReference parses a reference to an image by digest, as in
+// busybox@sha256:e9f8....
+func parseDigestReference(s string) (reference.Canonical, error) {
+	ref, err := reference.ParseNamed(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reference %q: %v", s, err)
+	}
+	canonical, ok := ref.(reference.Canonical)
+	if !ok {
+		return nil, fmt.Errorf("invalid reference %q: a reference with a digest must be of the form REPOSITORY@DIGEST", s)
+	}
+	if err := canonical.Digest().Validate(); err != nil {
+		return nil, fmt.Errorf("invalid reference %q: %v", s, err)
+	}
+	return canonical, nil
+}
+
+// hasTagOrDigest returns whether a reference pattern specifies a tag or a
+// digest, ignoring the port of a registry hostname.
+func hasTagOrDigest(pattern string) bool {
+	if strings.Contains(pattern, "@") {
+		return true
+	}
+	return strings.Contains(pattern[strings.LastIndex(pattern, "/")+1:], ":")
+}
+
+// imageSummary is the footer printed by docker images --summary.
+type imageSummary struct {
+	count       int
+	size        int64
+	active      int64
+	reclaimable int64
+}
+
+// imagesInUse returns the IDs of the images used by a container, running or
+// not. The listing is aborted when the context is canceled.
+func (cli *DockerCli) imagesInUse(ctx context.Context) (map[string]bool, error) {
+	containers, err := cli.client.ContainerListWithContext(ctx, types.ContainerListOptions{All: true})
+	if err != nil {
+		return nil, err
+	}
+	inUse := make(map[string]bool, len(containers))
+	for _, c := range containers {
+		inUse[c.ImageID] = true
+	}
+	return inUse, nil
+}
+
+// imagesSummary returns the number of unique images in the list and their
+// total size. An image listed under several tags is only counted once. The
+// size of the images used by a container is also summed as active, and the
+// size of the untagged images which aren't used as reclaimable.
+func imagesSummary(images []types.Image, inUse map[string]bool) imageSummary {
+	var (
+		s    imageSummary
+		seen = make(map[string]bool)
+	)
+	for _, image := range images {
+		if seen[image.ID] {
+			continue
+		}
+		seen[image.ID] = true
+		s.size += image.Size
+		switch {
+		case inUse[image.ID]:
+			s.active += image.Size
+		case isUntagged(image):
+			s.reclaimable += image.Size
+		}
+	}
+	s.count = len(seen)
+	return s
+}
+
+// isUntagged returns true if the image has no repository tag.
+func isUntagged(image types.Image) bool {
+	for _, tag := range image.RepoTags {
+		if tag != "<none>:<none>" {
+			return false
+		}
+	}
+	return true
+}
+
+// imagesLessFunc returns the comparison function used to sort images by the
+// given key, or nil if the images should be kept in the order the daemon
+// returned them.
+func imagesLessFunc(sortBy string) (func(a, b types.Image) bool, error) {
+	switch sortBy {
+	case "":
+		return nil, nil
+	case "size":
+		return func(a, b types.Image) bool { return a.Size < b.Size }, nil
+	case "created":
+		return func(a, b types.Image) bool { return a.Created < b.Created }, nil
+	case "repository":
+		return func(a, b types.Image) bool { return imageRepository(a) < imageRepository(b) }, nil
+	}
+	return nil, fmt.Errorf("%q is not a valid value for --sort", sortBy)
+}
+
+// imageRepository returns the first repository reference an image is known
+// by, used as its sort key.
+func imageRepository(image types.Image) string {
+	for _, refs := range [][]string{image.RepoTags, image.RepoDigests} {
+		for _, ref := range refs {
+			if !strings.HasPrefix(ref, "<none>") {
+				return ref
+			}
+		}
+	}
+	return "<none>"
+}
+
+// imagesSorter sorts a list of images using the provided less function.
+type imagesSorter struct {
+	images []types.Image
+	less   func(a, b types.Image) bool
+}
+
+func (s imagesSorter) Len() int           { return len(s.images) }
+func (s imagesSorter) Swap(i, j int)      { s.images[i], s.images[j] = s.images[j], s.images[i] }
+func (s imagesSorter) Less(i, j int) bool { return s.less(s.images[i], s.images[j]) }
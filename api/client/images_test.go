@@ -0,0 +1,290 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/client/lib"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"golang.org/x/net/context"
+)
+
+func TestImagesLessFunc(t *testing.T) {
+	images := []types.Image{
+		{ID: "b", RepoTags: []string{"busybox:latest"}, Size: 30, Created: 1},
+		{ID: "a", RepoTags: []string{"<none>:<none>"}, RepoDigests: []string{"alpine@sha256:abc"}, Size: 10, Created: 3},
+		{ID: "c", RepoTags: []string{"centos:7"}, Size: 20, Created: 2},
+	}
+
+	cases := []struct {
+		sortBy   string
+		expected string
+	}{
+		{"size", "acb"},
+		{"created", "bca"},
+		{"repository", "abc"},
+	}
+	for _, c := range cases {
+		less, err := imagesLessFunc(c.sortBy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sorted := append([]types.Image{}, images...)
+		sort.Stable(imagesSorter{sorted, less})
+		var ids string
+		for _, image := range sorted {
+			ids += image.ID
+		}
+		if ids != c.expected {
+			t.Fatalf("Expected order %s when sorting by %s, got %s", c.expected, c.sortBy, ids)
+		}
+	}
+
+	if less, err := imagesLessFunc(""); err != nil || less != nil {
+		t.Fatalf("Expected no sorting by default, got %v", err)
+	}
+	if _, err := imagesLessFunc("name"); err == nil {
+		t.Fatal("Expected an error for an unknown sort key")
+	}
+}
+
+func TestImagesSummary(t *testing.T) {
+	images := []types.Image{
+		{ID: "a", RepoTags: []string{"busybox:latest", "busybox:1"}, Size: 10},
+		{ID: "b", RepoTags: []string{"centos:7"}, Size: 20},
+		{ID: "a", RepoTags: []string{"busybox:latest", "busybox:1"}, Size: 10},
+		{ID: "c", RepoTags: []string{"<none>:<none>"}, Size: 5},
+		{ID: "d", Size: 7},
+		{ID: "e", RepoTags: []string{"<none>:<none>"}, Size: 3},
+	}
+	s := imagesSummary(images, map[string]bool{"a": true, "e": true, "x": true})
+	if s.count != 5 {
+		t.Fatalf("Expected 5 unique images, got %d", s.count)
+	}
+	if s.size != 45 {
+		t.Fatalf("Expected a total size of 45, got %d", s.size)
+	}
+	if s.active != 13 {
+		t.Fatalf("Expected an active size of 13, got %d", s.active)
+	}
+	if s.reclaimable != 12 {
+		t.Fatalf("Expected a reclaimable size of 12, got %d", s.reclaimable)
+	}
+}
+
+func TestCmdImagesSummaryWithoutTable(t *testing.T) {
+	cli := &DockerCli{}
+	for _, format := range []string{"{{json .}}", "{{.ID}}"} {
+		err := cli.CmdImages("--summary", "--format", format)
+		if err == nil || err.Error() != "--summary can only be used with a table format" {
+			t.Fatalf("%s: expected the summary to be rejected, got %v", format, err)
+		}
+	}
+}
+
+func TestFilterImagesByReference(t *testing.T) {
+	images := []types.Image{
+		{ID: "a", RepoTags: []string{"ubuntu:latest", "ubuntu:14.04"}},
+		{ID: "b", RepoTags: []string{"myubuntu:latest"}},
+		{ID: "c", RepoTags: []string{"myrepo/app:v1.0", "myrepo/app:v2.0"}, RepoDigests: []string{"myrepo/app@sha256:cbbf2f9a99b47fc460d422812b6a5adff7dfee951d8fa2e4a98caa0382cfbdbf"}},
+		{ID: "d", RepoTags: []string{"<none>:<none>"}, RepoDigests: []string{"<none>@<none>"}},
+		{ID: "e", RepoTags: []string{"localhost:5000/ubuntu:latest"}},
+	}
+
+	cases := []struct {
+		patterns []string
+		expected map[string][]string
+	}{
+		{[]string{"ubuntu"}, map[string][]string{"a": {"ubuntu:latest", "ubuntu:14.04"}}},
+		{[]string{"ubuntu:14.*"}, map[string][]string{"a": {"ubuntu:14.04"}}},
+		{[]string{"myrepo/*:v1.*"}, map[string][]string{"c": {"myrepo/app:v1.0"}}},
+		{[]string{"myrepo/*"}, map[string][]string{"c": {"myrepo/app:v1.0", "myrepo/app:v2.0", "myrepo/app@sha256:cbbf2f9a99b47fc460d422812b6a5adff7dfee951d8fa2e4a98caa0382cfbdbf"}}},
+		{[]string{"localhost:5000/*"}, map[string][]string{"e": {"localhost:5000/ubuntu:latest"}}},
+		{[]string{"myrepo/app@sha256:cbbf2f9a99b47fc460d422812b6a5adff7dfee951d8fa2e4a98caa0382cfbdbf"}, map[string][]string{"c": {"myrepo/app@sha256:cbbf2f9a99b47fc460d422812b6a5adff7dfee951d8fa2e4a98caa0382cfbdbf"}}},
+		{[]string{"ubuntu", "myubuntu"}, map[string][]string{"a": {"ubuntu:latest", "ubuntu:14.04"}, "b": {"myubuntu:latest"}}},
+		{[]string{"centos"}, map[string][]string{}},
+	}
+	for _, c := range cases {
+		filtered := filterImagesByReference(images, c.patterns)
+		if len(filtered) != len(c.expected) {
+			t.Fatalf("Expected %d images for %v, got %v", len(c.expected), c.patterns, filtered)
+		}
+		for _, image := range filtered {
+			refs := append(image.RepoTags, image.RepoDigests...)
+			if !reflect.DeepEqual(refs, c.expected[image.ID]) {
+				t.Fatalf("Expected references %v for image %s with %v, got %v", c.expected[image.ID], image.ID, c.patterns, refs)
+			}
+		}
+	}
+}
+
+func TestFilterImagesCreatedBefore(t *testing.T) {
+	images := []types.Image{
+		{ID: "a", Created: 1},
+		{ID: "b", Created: 5},
+		{ID: "c", Created: 10},
+	}
+
+	filtered := filterImagesCreatedBefore(images, []int64{10})
+	if len(filtered) != 2 || filtered[0].ID != "a" || filtered[1].ID != "b" {
+		t.Fatalf("Expected images a and b, got %v", filtered)
+	}
+
+	filtered = filterImagesCreatedBefore(images, []int64{10, 5})
+	if len(filtered) != 1 || filtered[0].ID != "a" {
+		t.Fatalf("Expected image a, got %v", filtered)
+	}
+}
+
+func TestFilterImagesCreatedSince(t *testing.T) {
+	images := []types.Image{
+		{ID: "a", Created: 1},
+		{ID: "b", Created: 5},
+		{ID: "c", Created: 10},
+	}
+
+	filtered := filterImagesCreatedSince(images, []int64{1})
+	if len(filtered) != 2 || filtered[0].ID != "b" || filtered[1].ID != "c" {
+		t.Fatalf("Expected images b and c, got %v", filtered)
+	}
+
+	filtered = filterImagesCreatedSince(images, []int64{1, 5})
+	if len(filtered) != 1 || filtered[0].ID != "c" {
+		t.Fatalf("Expected image c, got %v", filtered)
+	}
+}
+
+func TestFilterImagesIntermediate(t *testing.T) {
+	// a <- b <- c, b is intermediate, d is dangling and e is tagged but
+	// has a child listed only in the parents.
+	images := []types.Image{
+		{ID: "a", RepoTags: []string{"busybox:latest"}},
+		{ID: "b", ParentID: "a", RepoTags: []string{"<none>:<none>"}},
+		{ID: "c", ParentID: "b", RepoTags: []string{"myapp:latest"}},
+		{ID: "d", RepoTags: []string{"<none>:<none>"}},
+		{ID: "e"},
+	}
+	parents := append(images, types.Image{ID: "f", ParentID: "e"})
+
+	cases := []struct {
+		parents      []types.Image
+		intermediate bool
+		expected     string
+	}{
+		{images, true, "b"},
+		{images, false, "a,c,d,e"},
+		{parents, true, "b,e"},
+		{parents, false, "a,c,d"},
+	}
+	for _, c := range cases {
+		var ids []string
+		for _, image := range filterImagesIntermediate(images, c.parents, c.intermediate) {
+			ids = append(ids, image.ID)
+		}
+		if strings.Join(ids, ",") != c.expected {
+			t.Fatalf("Expected images %s with intermediate=%t, got %v", c.expected, c.intermediate, ids)
+		}
+	}
+}
+
+func TestParseFilterTime(t *testing.T) {
+	now := time.Unix(1000000, 0)
+	valid := map[string]int64{
+		"168h":                 1000000 - 168*3600,
+		"90m":                  1000000 - 90*60,
+		"1136073600":           1136073600,
+		"2006-01-01T00:00:00Z": 1136073600,
+	}
+	for value, expected := range valid {
+		seconds, err := parseFilterTime(value, now)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", value, err)
+		}
+		if seconds != expected {
+			t.Fatalf("Expected %d for %s, got %d", expected, value, seconds)
+		}
+	}
+
+	// images are given to the since filter as such
+	for _, value := range []string{"ubuntu", "ubuntu:14.04", "my-image", "localhost:5000/app", "4986bf8c1536"} {
+		if _, err := parseFilterTime(value, now); err == nil {
+			t.Fatalf("Expected an error for %s", value)
+		}
+	}
+}
+
+func TestListImagesSinceImageFirst(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.22/images/json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"Id":"old","Created":1000},{"Id":"new","Created":3000}]`)
+	})
+	mux.HandleFunc("/v1.22/images/12345/json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"Id":"sha256:12345","Created":"1970-01-01T00:33:20Z"}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client, err := lib.NewClient(strings.Replace(server.URL, "http://", "tcp://", 1), "1.22", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cli := &DockerCli{client: client}
+
+	// 12345 is the ID of an image created at 2000, not a timestamp.
+	args := filters.NewArgs()
+	args.Add("since", "12345")
+	images, err := cli.listImages(context.Background(), types.ImageListOptions{Filters: args})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(images) != 1 || images[0].ID != "new" {
+		t.Fatalf("Expected only the new image, got %v", images)
+	}
+
+	// A value which isn't an image is taken as a time.
+	args = filters.NewArgs()
+	args.Add("since", "500")
+	if images, err = cli.listImages(context.Background(), types.ImageListOptions{Filters: args}); err != nil {
+		t.Fatal(err)
+	}
+	if len(images) != 2 {
+		t.Fatalf("Expected both images, got %v", images)
+	}
+}
+
+func TestParseDigestReference(t *testing.T) {
+	digest := "sha256:cbbf2f9a99b47fc460d422812b6a5adff7dfee951d8fa2e4a98caa0382cfbdbf"
+	valid := map[string]string{
+		"busybox@" + digest:                   "busybox",
+		"myrepo/app@" + digest:                "myrepo/app",
+		"localhost:5000/myrepo/app@" + digest: "localhost:5000/myrepo/app",
+	}
+	for s, name := range valid {
+		ref, err := parseDigestReference(s)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", s, err)
+		}
+		if ref.Name() != name || ref.Digest().String() != digest {
+			t.Fatalf("Expected %s and %s for %s, got %s and %s", name, digest, s, ref.Name(), ref.Digest())
+		}
+	}
+
+	invalid := []string{
+		"busybox@",
+		"busybox@sha256:cbbf",
+		"busybox@sha256:" + strings.Repeat("z", 64),
+		"Busybox@" + digest,
+		"@" + digest,
+	}
+	for _, s := range invalid {
+		if _, err := parseDigestReference(s); err == nil {
+			t.Fatalf("Expected an error for %s", s)
+		}
+	}
+}
@@ -3,12 +3,13 @@ package client
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/types"
 	Cli "github.com/docker/docker/cli"
-	"github.com/docker/docker/opts"
 	"github.com/docker/docker/pkg/jsonmessage"
 	flag "github.com/docker/docker/pkg/mflag"
 	"github.com/docker/docker/pkg/urlutil"
@@ -22,9 +23,10 @@ import (
 // Usage: docker import [OPTIONS] file|URL|- [REPOSITORY[:TAG]]
 func (cli *DockerCli) CmdImport(args ...string) error {
 	cmd := Cli.Subcmd("import", []string{"file|URL|- [REPOSITORY[:TAG]]"}, Cli.DockerCommands["import"].Description, true)
-	flChanges := opts.NewListOpts(nil)
-	cmd.Var(&flChanges, []string{"c", "-change"}, "Apply Dockerfile instruction to the created image")
-	message := cmd.String([]string{"m", "-message"}, "", "Set commit message for imported image")
+	flChanges := &changesOpts{}
+	cmd.Var(changeFlag{flChanges}, []string{"c", "-change"}, "Apply Dockerfile instruction to the created image")
+	cmd.Var(changeFileFlag{flChanges}, []string{"-change-file"}, "Apply the Dockerfile instructions of a file to the created image")
+	message := cmd.String([]string{"m", "-message"}, "", "Set commit message for imported image, or read it from a file with @path")
 	cmd.Require(flag.Min, 1)
 
 	cmd.ParseFlags(args, true)
@@ -43,6 +45,11 @@ func (cli *DockerCli) CmdImport(args ...string) error {
 		tag = cmd.Arg(2)
 	}
 
+	commitMessage, err := readImportMessage(*message)
+	if err != nil {
+		return err
+	}
+
 	if repository != "" {
 		//Check if the given image name can be resolved
 		ref, err := reference.ParseNamed(repository)
@@ -71,7 +78,7 @@ func (cli *DockerCli) CmdImport(args ...string) error {
 		Source:         in,
 		SourceName:     srcName,
 		RepositoryName: repository,
-		Message:        *message,
+		Message:        commitMessage,
 		Tag:            tag,
 		Changes:        changes,
 	}
@@ -84,3 +91,17 @@ func (cli *DockerCli) CmdImport(args ...string) error {
 
 	return jsonmessage.DisplayJSONMessagesStream(responseBody, cli.out, cli.outFd, cli.isTerminalOut)
 }
+
+// readImportMessage returns the commit message given with the --message flag
+// of docker import: the content of the file if message is @path, without its
+// trailing new lines, otherwise message itself.
+func readImportMessage(message string) (string, error) {
+	if !strings.HasPrefix(message, "@") {
+		return message, nil
+	}
+	content, err := ioutil.ReadFile(message[1:])
+	if err != nil {
+		return "", fmt.Errorf("cannot read the commit message: %v", err)
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadImportMessage(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "docker-import-message")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	path := filepath.Join(tmpDir, "message")
+	if err := ioutil.WriteFile(path, []byte("Import the root filesystem\n\nBuilt from release 1.2\n\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		message  string
+		expected string
+	}{
+		{"", ""},
+		{"plain message", "plain message"},
+		{"@" + path, "Import the root filesystem\n\nBuilt from release 1.2"},
+	}
+	for _, c := range cases {
+		message, err := readImportMessage(c.message)
+		if err != nil {
+			t.Fatalf("%q: %v", c.message, err)
+		}
+		if message != c.expected {
+			t.Fatalf("%q: expected %q, got %q", c.message, c.expected, message)
+		}
+	}
+
+	if _, err := readImportMessage("@" + filepath.Join(tmpDir, "missing")); err == nil {
+		t.Fatal("expected an error reading a missing message file")
+	}
+}
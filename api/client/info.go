@@ -2,7 +2,9 @@ package client
 
 import (
 	"fmt"
+	"text/template"
 
+	"github.com/docker/docker/api/types"
 	Cli "github.com/docker/docker/cli"
 	"github.com/docker/docker/pkg/ioutils"
 	flag "github.com/docker/docker/pkg/mflag"
@@ -11,18 +13,37 @@ import (
 
 // CmdInfo displays system-wide information.
 //
-// Usage: docker info
+// Usage: docker info [OPTIONS]
 func (cli *DockerCli) CmdInfo(args ...string) error {
 	cmd := Cli.Subcmd("info", nil, Cli.DockerCommands["info"].Description, true)
+	format := cmd.String([]string{"f", "-format"}, "", "Format the output using the given go template")
 	cmd.Require(flag.Exact, 0)
 
 	cmd.ParseFlags(args, true)
 
+	var tmpl *template.Template
+	if *format != "" {
+		var err error
+		if tmpl, err = template.New("").Funcs(funcMap).Parse(*format); err != nil {
+			return Cli.StatusError{StatusCode: 64,
+				Status: "Template parsing error: " + err.Error()}
+		}
+	}
+
 	info, err := cli.client.Info()
 	if err != nil {
 		return err
 	}
 
+	if tmpl != nil {
+		if err := tmpl.Execute(cli.out, normalizeInfo(info)); err != nil {
+			return Cli.StatusError{StatusCode: 64,
+				Status: "Template parsing error: " + err.Error()}
+		}
+		cli.out.Write([]byte{'\n'})
+		return nil
+	}
+
 	fmt.Fprintf(cli.out, "Containers: %d\n", info.Containers)
 	fmt.Fprintf(cli.out, "Images: %d\n", info.Images)
 	ioutils.FprintfIfNotEmpty(cli.out, "Server Version: %s\n", info.ServerVersion)
@@ -136,3 +157,21 @@ func (cli *DockerCli) CmdInfo(args ...string) error {
 	}
 	return nil
 }
+
+// normalizeInfo returns info with its lists that the daemon may leave unset
+// made empty, so that they are always JSON arrays in the formatted output.
+func normalizeInfo(info types.Info) types.Info {
+	if info.DriverStatus == nil {
+		info.DriverStatus = [][2]string{}
+	}
+	if info.Plugins.Volume == nil {
+		info.Plugins.Volume = []string{}
+	}
+	if info.Plugins.Network == nil {
+		info.Plugins.Network = []string{}
+	}
+	if info.Labels == nil {
+		info.Labels = []string{}
+	}
+	return info
+}
@@ -0,0 +1,26 @@
+package client
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestNormalizeInfo(t *testing.T) {
+	out, err := json.Marshal(normalizeInfo(types.Info{Driver: "vfs"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{`"DriverStatus":[]`, `"Plugins":{"Volume":[],"Network":[]}`, `"Labels":[]`, `"KernelVersion":""`} {
+		if !strings.Contains(string(out), expected) {
+			t.Fatalf("expected %s in %s", expected, out)
+		}
+	}
+
+	info := types.Info{Labels: []string{"storage=ssd"}}
+	if labels := normalizeInfo(info).Labels; len(labels) != 1 || labels[0] != "storage=ssd" {
+		t.Fatalf("expected the labels to be kept, got %v", labels)
+	}
+}
@@ -1,21 +1,36 @@
 package client
 
 import (
-	"encoding/json"
 	"fmt"
 	"text/template"
 
+	"github.com/docker/docker/api/client/formatter"
 	"github.com/docker/docker/api/client/inspect"
 	"github.com/docker/docker/api/client/lib"
+	"github.com/docker/docker/api/types"
 	Cli "github.com/docker/docker/cli"
 	flag "github.com/docker/docker/pkg/mflag"
 )
 
-var funcMap = template.FuncMap{
-	"json": func(v interface{}) string {
-		a, _ := json.Marshal(v)
-		return string(a)
-	},
+// funcMap is the template functions available to the --format flags of the
+// commands not using a formatter context.
+var funcMap = formatter.FuncMap
+
+// inspectedImage adds fields computed from the image inspect response to
+// the ones available to the format template. The JSON output is unchanged.
+type inspectedImage struct {
+	types.ImageInspect
+}
+
+// LayerCount returns the number of layers in the image's root filesystem.
+func (i inspectedImage) LayerCount() int {
+	return len(i.RootFS.Layers)
+}
+
+// TotalSize returns the combined size of all the layers in the image's root
+// filesystem, as reported by the daemon.
+func (i inspectedImage) TotalSize() int64 {
+	return i.VirtualSize
 }
 
 // CmdInspect displays low-level information on one or more containers or images.
@@ -24,27 +39,36 @@ var funcMap = template.FuncMap{
 func (cli *DockerCli) CmdInspect(args ...string) error {
 	cmd := Cli.Subcmd("inspect", []string{"CONTAINER|IMAGE [CONTAINER|IMAGE...]"}, Cli.DockerCommands["inspect"].Description, true)
 	tmplStr := cmd.String([]string{"f", "-format"}, "", "Format the output using the given go template")
-	inspectType := cmd.String([]string{"-type"}, "", "Return JSON for specified type, (e.g image or container)")
+	formatArray := cmd.Bool([]string{"-format-array"}, false, "Apply the format template to the list of all results")
+	inspectType := cmd.String([]string{"-type"}, "auto", "Return JSON for specified type, (e.g auto, image or container)")
 	size := cmd.Bool([]string{"s", "-size"}, false, "Display total file sizes if the type is container")
+	continueOnError := cmd.Bool([]string{"-continue-on-error"}, false, "Keep inspecting the remaining targets when one fails")
 	cmd.Require(flag.Min, 1)
 
 	cmd.ParseFlags(args, true)
 
-	if *inspectType != "" && *inspectType != "container" && *inspectType != "image" {
-		return fmt.Errorf("%q is not a valid value for --type", *inspectType)
-	}
-
 	var elementSearcher inspectSearcher
 	switch *inspectType {
 	case "container":
 		elementSearcher = cli.inspectContainers(*size)
 	case "image":
 		elementSearcher = cli.inspectImages(*size)
-	default:
+	case "", "auto":
 		elementSearcher = cli.inspectAll(*size)
+	default:
+		return fmt.Errorf("%q is not a valid value for --type", *inspectType)
+	}
+
+	if *formatArray && *tmplStr == "" {
+		return fmt.Errorf("--format-array requires --format")
 	}
 
-	return cli.inspectElements(*tmplStr, cmd.Args(), elementSearcher)
+	elementInspector, err := cli.newInspectorWithTemplate(*tmplStr, *formatArray)
+	if err != nil {
+		return Cli.StatusError{StatusCode: 64, Status: err.Error()}
+	}
+
+	return cli.runInspector(elementInspector, cmd.Args(), elementSearcher, *continueOnError)
 }
 
 func (cli *DockerCli) inspectContainers(getSize bool) inspectSearcher {
@@ -55,7 +79,8 @@ func (cli *DockerCli) inspectContainers(getSize bool) inspectSearcher {
 
 func (cli *DockerCli) inspectImages(getSize bool) inspectSearcher {
 	return func(ref string) (interface{}, []byte, error) {
-		return cli.client.ImageInspectWithRaw(ref, getSize)
+		i, raw, err := cli.client.ImageInspectWithRaw(ref, getSize)
+		return inspectedImage{i}, raw, err
 	}
 }
 
@@ -72,7 +97,7 @@ func (cli *DockerCli) inspectAll(getSize bool) inspectSearcher {
 					}
 					return nil, nil, err
 				}
-				return i, rawImage, err
+				return inspectedImage{i}, rawImage, err
 			}
 			return nil, nil, err
 		}
@@ -83,30 +108,47 @@ func (cli *DockerCli) inspectAll(getSize bool) inspectSearcher {
 type inspectSearcher func(ref string) (interface{}, []byte, error)
 
 func (cli *DockerCli) inspectElements(tmplStr string, references []string, searchByReference inspectSearcher) error {
-	elementInspector, err := cli.newInspectorWithTemplate(tmplStr)
+	elementInspector, err := cli.newInspectorWithTemplate(tmplStr, false)
 	if err != nil {
 		return Cli.StatusError{StatusCode: 64, Status: err.Error()}
 	}
+	return cli.runInspector(elementInspector, references, searchByReference, false)
+}
 
-	var inspectErr error
+// runInspector looks up every reference and feeds the results to the
+// inspector. It stops at the first failure unless continueOnError is set, in
+// which case failures are reported as they happen and the results of the
+// successful lookups are still printed. If more than one reference is given,
+// template errors are prefixed with the reference they failed on.
+func (cli *DockerCli) runInspector(elementInspector inspect.Inspector, references []string, searchByReference inspectSearcher, continueOnError bool) error {
+	var (
+		inspectErr error
+		status     int
+	)
 	for _, ref := range references {
 		element, raw, err := searchByReference(ref)
-		if err != nil {
-			inspectErr = err
-			break
+		if err == nil {
+			if err = elementInspector.Inspect(element, raw); err != nil && len(references) > 1 {
+				err = fmt.Errorf("%s: %v", ref, err)
+			}
 		}
-
-		if err := elementInspector.Inspect(element, raw); err != nil {
-			inspectErr = err
-			break
+		if err != nil {
+			if !continueOnError {
+				inspectErr = err
+				break
+			}
+			status = cli.inspectErrorStatus(err)
 		}
 	}
 
 	if err := elementInspector.Flush(); err != nil {
-		cli.inspectErrorStatus(err)
+		status = cli.inspectErrorStatus(err)
 	}
 
-	if status := cli.inspectErrorStatus(inspectErr); status != 0 {
+	if s := cli.inspectErrorStatus(inspectErr); s != 0 {
+		status = s
+	}
+	if status != 0 {
 		return Cli.StatusError{StatusCode: status}
 	}
 	return nil
@@ -120,13 +162,16 @@ func (cli *DockerCli) inspectErrorStatus(err error) (status int) {
 	return
 }
 
-func (cli *DockerCli) newInspectorWithTemplate(tmplStr string) (inspect.Inspector, error) {
+func (cli *DockerCli) newInspectorWithTemplate(tmplStr string, formatArray bool) (inspect.Inspector, error) {
 	elementInspector := inspect.NewIndentedInspector(cli.out)
 	if tmplStr != "" {
 		tmpl, err := template.New("").Funcs(funcMap).Parse(tmplStr)
 		if err != nil {
 			return nil, fmt.Errorf("Template parsing error: %s", err)
 		}
+		if formatArray {
+			return inspect.NewArrayTemplateInspector(cli.out, tmpl), nil
+		}
 		elementInspector = inspect.NewTemplateInspector(cli.out, tmpl)
 	}
 	return elementInspector, nil
@@ -46,6 +46,24 @@ func (i *TemplateInspector) Inspect(typedElement interface{}, rawElement []byte)
 	return nil
 }
 
+// tryRawInspectFallback executes the inspect template with the raw element
+// decoded into a generic value.
+func (i *TemplateInspector) tryRawInspectFallback(rawElement []byte, originalErr error) error {
+	raw, err := decodeRawElement(rawElement)
+	if err != nil {
+		return err
+	}
+
+	buffer, err := executeRawTemplate(i.tmpl, raw, originalErr)
+	if err != nil {
+		return err
+	}
+
+	i.buffer.Write(buffer.Bytes())
+	i.buffer.WriteByte('\n')
+	return nil
+}
+
 // Flush write the result of inspecting all elements into the output stream.
 func (i *TemplateInspector) Flush() error {
 	if i.buffer.Len() == 0 {
@@ -56,6 +74,66 @@ func (i *TemplateInspector) Flush() error {
 	return err
 }
 
+// ArrayTemplateInspector executes a text template once, on the list of all
+// inspected elements, instead of once per element.
+type ArrayTemplateInspector struct {
+	outputStream io.Writer
+	tmpl         *template.Template
+	elements     []interface{}
+	rawElements  [][]byte
+}
+
+// NewArrayTemplateInspector creates a new inspector that applies the template
+// to the whole list of elements.
+func NewArrayTemplateInspector(outputStream io.Writer, tmpl *template.Template) Inspector {
+	return &ArrayTemplateInspector{
+		outputStream: outputStream,
+		tmpl:         tmpl,
+	}
+}
+
+// Inspect stores the element until the inspector is flushed.
+func (i *ArrayTemplateInspector) Inspect(typedElement interface{}, rawElement []byte) error {
+	i.elements = append(i.elements, typedElement)
+	i.rawElements = append(i.rawElements, rawElement)
+	return nil
+}
+
+// Flush executes the template on all the elements and writes the result
+// into the output stream.
+// It decodes the raw elements into maps if the initial execution fails.
+func (i *ArrayTemplateInspector) Flush() error {
+	elements := i.elements
+	if elements == nil {
+		elements = []interface{}{}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := i.tmpl.Execute(buffer, elements); err != nil {
+		if buffer, err = i.tryRawInspectFallback(err); err != nil {
+			return err
+		}
+	}
+	buffer.WriteByte('\n')
+	_, err := io.Copy(i.outputStream, buffer)
+	return err
+}
+
+func (i *ArrayTemplateInspector) tryRawInspectFallback(originalErr error) (*bytes.Buffer, error) {
+	raw := make([]interface{}, 0, len(i.rawElements))
+	for _, rawElement := range i.rawElements {
+		if rawElement == nil {
+			return nil, fmt.Errorf("Template parsing error: %v", originalErr)
+		}
+		element, err := decodeRawElement(rawElement)
+		if err != nil {
+			return nil, err
+		}
+		raw = append(raw, element)
+	}
+	return executeRawTemplate(i.tmpl, raw, originalErr)
+}
+
 // IndentedInspector uses a buffer to stop the indented representation of an element.
 type IndentedInspector struct {
 	outputStream io.Writer
@@ -93,3 +171,12 @@ func (i *IndentedInspector) Flush() error {
 	_, err = io.WriteString(i.outputStream, "\n")
 	return err
 }
+
+func decodeRawElement(rawElement []byte) (interface{}, error) {
+	var raw interface{}
+	dec := json.NewDecoder(bytes.NewReader(rawElement))
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("unable to read inspect data: %v", err)
+	}
+	return raw, nil
+}
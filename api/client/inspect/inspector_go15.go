@@ -4,26 +4,17 @@ package inspect
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
+	"text/template"
 )
 
-func (i *TemplateInspector) tryRawInspectFallback(rawElement []byte, _ error) error {
-	var raw interface{}
+// executeRawTemplate executes the inspect template with decoded raw data,
+// failing on keys missing from the data.
+func executeRawTemplate(tmpl *template.Template, raw interface{}, _ error) (*bytes.Buffer, error) {
 	buffer := new(bytes.Buffer)
-	rdr := bytes.NewReader(rawElement)
-	dec := json.NewDecoder(rdr)
-
-	if rawErr := dec.Decode(&raw); rawErr != nil {
-		return fmt.Errorf("unable to read inspect data: %v", rawErr)
-	}
-
-	tmplMissingKey := i.tmpl.Option("missingkey=error")
+	tmplMissingKey := tmpl.Option("missingkey=error")
 	if rawErr := tmplMissingKey.Execute(buffer, raw); rawErr != nil {
-		return fmt.Errorf("Template parsing error: %v", rawErr)
+		return nil, fmt.Errorf("Template parsing error: %v", rawErr)
 	}
-
-	i.buffer.Write(buffer.Bytes())
-	i.buffer.WriteByte('\n')
-	return nil
+	return buffer, nil
 }
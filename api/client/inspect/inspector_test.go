@@ -187,3 +187,80 @@ func TestIndentedInspectorEmpty(t *testing.T) {
 		t.Fatalf("Expected `%s`, got `%s`", expected, b.String())
 	}
 }
+
+func TestArrayTemplateInspector(t *testing.T) {
+	b := new(bytes.Buffer)
+	tmpl, err := template.New("test").Parse("{{range .}}{{.DNS}} {{end}}{{len .}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := NewArrayTemplateInspector(b, tmpl)
+	if err := i.Inspect(testElement{"0.0.0.0"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := i.Inspect(testElement{"1.1.1.1"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := i.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "0.0.0.0 1.1.1.1 2\n" {
+		t.Fatalf("Expected `0.0.0.0 1.1.1.1 2\\n`, got `%s`", b.String())
+	}
+}
+
+func TestArrayTemplateInspectorEmpty(t *testing.T) {
+	b := new(bytes.Buffer)
+	tmpl, err := template.New("test").Parse("{{len .}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := NewArrayTemplateInspector(b, tmpl)
+
+	if err := i.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "0\n" {
+		t.Fatalf("Expected `0\\n`, got `%s`", b.String())
+	}
+}
+
+func TestArrayTemplateInspectorRawFallback(t *testing.T) {
+	b := new(bytes.Buffer)
+	tmpl, err := template.New("test").Parse("{{range .}}{{.Dns}}{{end}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := NewArrayTemplateInspector(b, tmpl)
+	if err := i.Inspect(testElement{"0.0.0.0"}, []byte(`{"Dns": "0.0.0.0"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := i.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "0.0.0.0\n" {
+		t.Fatalf("Expected `0.0.0.0\\n`, got `%s`", b.String())
+	}
+}
+
+func TestArrayTemplateInspectorTemplateError(t *testing.T) {
+	b := new(bytes.Buffer)
+	tmpl, err := template.New("test").Parse("{{range .}}{{.Foo}}{{end}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := NewArrayTemplateInspector(b, tmpl)
+	if err := i.Inspect(testElement{"0.0.0.0"}, []byte(`{"Dns": "0.0.0.0"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	err = i.Flush()
+	if err == nil {
+		t.Fatal("Expected error got nil")
+	}
+	if !strings.HasPrefix(err.Error(), "Template parsing error") {
+		t.Fatalf("Expected template error, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/docker/docker/api/client/inspect"
+	"github.com/docker/docker/api/types"
+)
+
+func TestInspectedImageTemplateFields(t *testing.T) {
+	image := inspectedImage{types.ImageInspect{
+		VirtualSize: 2048,
+		RootFS: types.RootFS{
+			Type:   "layers",
+			Layers: []string{"sha256:a", "sha256:b", "sha256:c"},
+		},
+	}}
+
+	tmpl, err := template.New("").Funcs(funcMap).Parse("{{.LayerCount}} {{len .RootFS.Layers}} {{.TotalSize}} {{humanSize .TotalSize}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := new(bytes.Buffer)
+	if err := tmpl.Execute(b, image); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "3 3 2048 2.048 kB"; b.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, b.String())
+	}
+}
+
+func TestInspectedImageNoRootFS(t *testing.T) {
+	image := inspectedImage{types.ImageInspect{}}
+	if count := image.LayerCount(); count != 0 {
+		t.Fatalf("Expected 0 layers, got %d", count)
+	}
+}
+
+func TestInspectedImageJSON(t *testing.T) {
+	image := types.ImageInspect{
+		ID:          "sha256:abc",
+		VirtualSize: 2048,
+		RootFS:      types.RootFS{Type: "layers", Layers: []string{"sha256:a"}},
+	}
+	expected, err := json.Marshal(image)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := json.Marshal(inspectedImage{image})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(actual) != string(expected) {
+		t.Fatalf("Expected %s, got %s", expected, actual)
+	}
+}
+
+func TestRunInspectorTemplateErrorNamesReference(t *testing.T) {
+	volumes := map[string]interface{}{
+		"data":  &types.Volume{Name: "data", Mountpoint: "/var/lib/docker/volumes/data/_data"},
+		"other": &types.Volume{Name: "other"},
+	}
+	searcher := func(ref string) (interface{}, []byte, error) {
+		v, ok := volumes[ref]
+		if !ok {
+			return nil, nil, fmt.Errorf("Error: No such volume: %s", ref)
+		}
+		return v, nil, nil
+	}
+	tmpl := template.Must(template.New("").Parse("{{.Mountpoint.Foo}}"))
+
+	stderr := new(bytes.Buffer)
+	cli := &DockerCli{out: new(bytes.Buffer), err: stderr}
+	if err := cli.runInspector(inspect.NewTemplateInspector(cli.out, tmpl), []string{"data", "other"}, searcher, true); err == nil {
+		t.Fatal("Expected an error for a failing template")
+	}
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "data: Template parsing error: ") || !strings.HasPrefix(lines[1], "other: Template parsing error: ") {
+		t.Fatalf("Expected the template errors to name the volumes, got %q", stderr.String())
+	}
+
+	stderr.Reset()
+	if err := cli.runInspector(inspect.NewTemplateInspector(cli.out, tmpl), []string{"data"}, searcher, false); err == nil {
+		t.Fatal("Expected an error for a failing template")
+	}
+	if !strings.HasPrefix(stderr.String(), "Template parsing error: ") {
+		t.Fatalf("Expected a single volume's error to be unprefixed, got %q", stderr.String())
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	Cli "github.com/docker/docker/cli"
 	flag "github.com/docker/docker/pkg/mflag"
+	"github.com/docker/docker/pkg/signal"
 )
 
 // CmdKill kills one or more running container using SIGKILL or a specified signal.
@@ -12,14 +13,18 @@ import (
 // Usage: docker kill [OPTIONS] CONTAINER [CONTAINER...]
 func (cli *DockerCli) CmdKill(args ...string) error {
 	cmd := Cli.Subcmd("kill", []string{"CONTAINER [CONTAINER...]"}, Cli.DockerCommands["kill"].Description, true)
-	signal := cmd.String([]string{"s", "-signal"}, "KILL", "Signal to send to the container")
+	sig := cmd.String([]string{"s", "-signal"}, "KILL", "Signal to send to the container")
 	cmd.Require(flag.Min, 1)
 
 	cmd.ParseFlags(args, true)
 
+	if err := validateKillSignal(*sig); err != nil {
+		return err
+	}
+
 	var errNames []string
 	for _, name := range cmd.Args() {
-		if err := cli.client.ContainerKill(name, *signal); err != nil {
+		if err := cli.client.ContainerKill(name, *sig); err != nil {
 			fmt.Fprintf(cli.err, "%s\n", err)
 			errNames = append(errNames, name)
 		} else {
@@ -31,3 +36,17 @@ func (cli *DockerCli) CmdKill(args ...string) error {
 	}
 	return nil
 }
+
+// validateKillSignal checks that sig is the number or the name of a signal of
+// the platform, with or without the SIG prefix, so that unknown signals are
+// rejected before any container is contacted.
+func validateKillSignal(sig string) error {
+	s, err := signal.ParseSignal(sig)
+	if err != nil {
+		return err
+	}
+	if !signal.ValidSignalForPlatform(s) {
+		return fmt.Errorf("Invalid signal: %s", sig)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package client
+
+import "testing"
+
+func TestValidateKillSignal(t *testing.T) {
+	for _, sig := range []string{"KILL", "SIGKILL", "term", "15", "9"} {
+		if err := validateKillSignal(sig); err != nil {
+			t.Fatalf("%s: %v", sig, err)
+		}
+	}
+	for _, sig := range []string{"", "0", "-9", "SIGFOO", "KILL9", "999"} {
+		if err := validateKillSignal(sig); err == nil {
+			t.Fatalf("expected %q to be rejected", sig)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/pkg/sockets"
 	"github.com/docker/docker/pkg/tlsconfig"
@@ -30,6 +31,20 @@ type Client struct {
 	version string
 	// custom http headers configured by users
 	customHTTPHeaders map[string]string
+	// requestTimeout bounds the time to wait for the response of GET and HEAD requests.
+	requestTimeout time.Duration
+	// requestRetries is the number of times failed GET and HEAD requests are retried.
+	requestRetries int
+}
+
+// ClientOptions holds the optional settings of a Client.
+type ClientOptions struct {
+	// RequestTimeout bounds the time to connect to the daemon and receive the
+	// response headers of GET and HEAD requests. Zero means no timeout.
+	RequestTimeout time.Duration
+	// RequestRetries is the number of times GET and HEAD requests are retried
+	// when they fail before the daemon responds.
+	RequestRetries int
 }
 
 // NewClient initializes a new API client for the given host and API version.
@@ -37,6 +52,12 @@ type Client struct {
 // It uses the tlsOptions to decide whether to use a secure connection or not.
 // It also initializes the custom http headers to add to each request.
 func NewClient(host string, version string, tlsOptions *tlsconfig.Options, httpHeaders map[string]string) (*Client, error) {
+	return NewClientWithOptions(host, version, tlsOptions, httpHeaders, ClientOptions{})
+}
+
+// NewClientWithOptions initializes a new API client like NewClient, with the
+// given request timeout and retries.
+func NewClientWithOptions(host string, version string, tlsOptions *tlsconfig.Options, httpHeaders map[string]string, options ClientOptions) (*Client, error) {
 	var (
 		basePath       string
 		tlsConfig      *tls.Config
@@ -78,6 +99,8 @@ func NewClient(host string, version string, tlsOptions *tlsconfig.Options, httpH
 		httpClient:        &http.Client{Transport: transport},
 		version:           version,
 		customHTTPHeaders: httpHeaders,
+		requestTimeout:    options.RequestTimeout,
+		requestRetries:    options.RequestRetries,
 	}, nil
 }
 
@@ -7,10 +7,17 @@ import (
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
+	"golang.org/x/net/context"
 )
 
 // ContainerList returns the list of containers in the docker host.
 func (cli *Client) ContainerList(options types.ContainerListOptions) ([]types.Container, error) {
+	return cli.ContainerListWithContext(context.Background(), options)
+}
+
+// ContainerListWithContext returns the list of containers in the docker host.
+// The request is aborted when the context is canceled.
+func (cli *Client) ContainerListWithContext(ctx context.Context, options types.ContainerListOptions) ([]types.Container, error) {
 	query := url.Values{}
 
 	if options.All {
@@ -42,7 +49,7 @@ func (cli *Client) ContainerList(options types.ContainerListOptions) ([]types.Co
 		query.Set("filters", filterJSON)
 	}
 
-	resp, err := cli.get("/containers/json", query, nil)
+	resp, err := cli.getWithContext(ctx, "/containers/json", query, nil)
 	if err != nil {
 		return nil, err
 	}
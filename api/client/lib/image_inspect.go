@@ -8,15 +8,22 @@ import (
 	"net/url"
 
 	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
 )
 
 // ImageInspectWithRaw returns the image information and it's raw representation.
 func (cli *Client) ImageInspectWithRaw(imageID string, getSize bool) (types.ImageInspect, []byte, error) {
+	return cli.ImageInspectWithRawWithContext(context.Background(), imageID, getSize)
+}
+
+// ImageInspectWithRawWithContext returns the image information and it's raw
+// representation. The request is aborted when the context is canceled.
+func (cli *Client) ImageInspectWithRawWithContext(ctx context.Context, imageID string, getSize bool) (types.ImageInspect, []byte, error) {
 	query := url.Values{}
 	if getSize {
 		query.Set("size", "1")
 	}
-	serverResp, err := cli.get("/images/"+imageID+"/json", query, nil)
+	serverResp, err := cli.getWithContext(ctx, "/images/"+imageID+"/json", query, nil)
 	if err != nil {
 		if serverResp.statusCode == http.StatusNotFound {
 			return types.ImageInspect{}, nil, imageNotFoundError{imageID}
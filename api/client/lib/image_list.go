@@ -6,10 +6,17 @@ import (
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
+	"golang.org/x/net/context"
 )
 
 // ImageList returns a list of images in the docker host.
 func (cli *Client) ImageList(options types.ImageListOptions) ([]types.Image, error) {
+	return cli.ImageListWithContext(context.Background(), options)
+}
+
+// ImageListWithContext returns a list of images in the docker host.
+// The request is aborted when the context is canceled.
+func (cli *Client) ImageListWithContext(ctx context.Context, options types.ImageListOptions) ([]types.Image, error) {
 	var images []types.Image
 	query := url.Values{}
 
@@ -28,7 +35,7 @@ func (cli *Client) ImageList(options types.ImageListOptions) ([]types.Image, err
 		query.Set("all", "1")
 	}
 
-	serverResp, err := cli.get("/images/json", query, nil)
+	serverResp, err := cli.getWithContext(ctx, "/images/json", query, nil)
 	if err != nil {
 		return images, err
 	}
@@ -3,15 +3,27 @@ package lib
 import (
 	"io"
 	"net/url"
+
+	"github.com/docker/docker/api/types"
 )
 
 // ImageLoad loads an image in the docker host from the client host.
+// Unless quiet is set, the daemon reports the progress of the load as a JSON
+// stream, and the JSON field of the response is set.
 // It's up to the caller to close the io.ReadCloser returned by
 // this function.
-func (cli *Client) ImageLoad(input io.Reader) (io.ReadCloser, error) {
-	resp, err := cli.postRaw("/images/load", url.Values{}, input, nil)
+func (cli *Client) ImageLoad(input io.Reader, quiet bool) (types.ImageLoadResponse, error) {
+	query := url.Values{}
+	query.Set("quiet", "0")
+	if quiet {
+		query.Set("quiet", "1")
+	}
+	resp, err := cli.postRaw("/images/load", query, input, nil)
 	if err != nil {
-		return nil, err
+		return types.ImageLoadResponse{}, err
 	}
-	return resp.body, nil
+	return types.ImageLoadResponse{
+		Body: resp.body,
+		JSON: resp.header.Get("Content-Type") == "application/json",
+	}, nil
 }
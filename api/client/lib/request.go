@@ -9,6 +9,10 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"golang.org/x/net/context"
 )
 
 // serverResponse is a wrapper for http API responses.
@@ -20,40 +24,52 @@ type serverResponse struct {
 
 // head sends an http request to the docker API using the method HEAD.
 func (cli *Client) head(path string, query url.Values, headers map[string][]string) (*serverResponse, error) {
-	return cli.sendRequest("HEAD", path, query, nil, headers)
+	return cli.sendRequest(context.Background(), "HEAD", path, query, nil, headers)
 }
 
 // get sends an http request to the docker API using the method GET.
 func (cli *Client) get(path string, query url.Values, headers map[string][]string) (*serverResponse, error) {
-	return cli.sendRequest("GET", path, query, nil, headers)
+	return cli.getWithContext(context.Background(), path, query, headers)
+}
+
+// getWithContext sends an http request to the docker API using the method GET.
+// The request is aborted when the context is canceled.
+func (cli *Client) getWithContext(ctx context.Context, path string, query url.Values, headers map[string][]string) (*serverResponse, error) {
+	return cli.sendRequest(ctx, "GET", path, query, nil, headers)
 }
 
 // post sends an http request to the docker API using the method POST.
 func (cli *Client) post(path string, query url.Values, body interface{}, headers map[string][]string) (*serverResponse, error) {
-	return cli.sendRequest("POST", path, query, body, headers)
+	return cli.postWithContext(context.Background(), path, query, body, headers)
+}
+
+// postWithContext sends an http request to the docker API using the method POST.
+// The request is aborted when the context is canceled.
+func (cli *Client) postWithContext(ctx context.Context, path string, query url.Values, body interface{}, headers map[string][]string) (*serverResponse, error) {
+	return cli.sendRequest(ctx, "POST", path, query, body, headers)
 }
 
 // postRaw sends the raw input to the docker API using the method POST.
 func (cli *Client) postRaw(path string, query url.Values, body io.Reader, headers map[string][]string) (*serverResponse, error) {
-	return cli.sendClientRequest("POST", path, query, body, headers)
+	return cli.sendClientRequest(context.Background(), "POST", path, query, body, headers)
 }
 
 // put sends an http request to the docker API using the method PUT.
 func (cli *Client) put(path string, query url.Values, body interface{}, headers map[string][]string) (*serverResponse, error) {
-	return cli.sendRequest("PUT", path, query, body, headers)
+	return cli.sendRequest(context.Background(), "PUT", path, query, body, headers)
 }
 
 // putRaw sends the raw input to the docker API using the method PUT.
 func (cli *Client) putRaw(path string, query url.Values, body io.Reader, headers map[string][]string) (*serverResponse, error) {
-	return cli.sendClientRequest("PUT", path, query, body, headers)
+	return cli.sendClientRequest(context.Background(), "PUT", path, query, body, headers)
 }
 
 // delete sends an http request to the docker API using the method DELETE.
 func (cli *Client) delete(path string, query url.Values, headers map[string][]string) (*serverResponse, error) {
-	return cli.sendRequest("DELETE", path, query, nil, headers)
+	return cli.sendRequest(context.Background(), "DELETE", path, query, nil, headers)
 }
 
-func (cli *Client) sendRequest(method, path string, query url.Values, body interface{}, headers map[string][]string) (*serverResponse, error) {
+func (cli *Client) sendRequest(ctx context.Context, method, path string, query url.Values, body interface{}, headers map[string][]string) (*serverResponse, error) {
 	params, err := encodeData(body)
 	if err != nil {
 		return nil, err
@@ -66,10 +82,39 @@ func (cli *Client) sendRequest(method, path string, query url.Values, body inter
 		headers["Content-Type"] = []string{"application/json"}
 	}
 
-	return cli.sendClientRequest(method, path, query, params, headers)
+	return cli.sendClientRequest(ctx, method, path, query, params, headers)
+}
+
+// retryDelay is the time to wait before retrying a failed request.
+var retryDelay = time.Second
+
+// sendClientRequest sends the request. GET and HEAD requests are bounded by
+// the request timeout of the client, and retried when they fail before the
+// daemon responds, up to the number of retries of the client. Other requests
+// may not be idempotent and are sent once.
+func (cli *Client) sendClientRequest(ctx context.Context, method, path string, query url.Values, body io.Reader, headers map[string][]string) (*serverResponse, error) {
+	if method != "GET" && method != "HEAD" {
+		return cli.doRequest(ctx, method, path, query, body, headers, 0)
+	}
+
+	for attempt := 0; ; attempt++ {
+		serverResp, err := cli.doRequest(ctx, method, path, query, body, headers, cli.requestTimeout)
+		if err == nil || serverResp.statusCode != -1 || ctx.Err() != nil || attempt >= cli.requestRetries {
+			return serverResp, err
+		}
+		logrus.Debugf("Retrying %s %s: %v", method, path, err)
+		select {
+		case <-time.After(retryDelay):
+		case <-ctx.Done():
+			return serverResp, ctx.Err()
+		}
+	}
 }
 
-func (cli *Client) sendClientRequest(method, path string, query url.Values, body io.Reader, headers map[string][]string) (*serverResponse, error) {
+// doRequest sends the request once. If timeout isn't zero, the request is
+// aborted if the daemon doesn't respond within the timeout. The timeout
+// doesn't apply to reading the body of the response, which may be streamed.
+func (cli *Client) doRequest(ctx context.Context, method, path string, query url.Values, body io.Reader, headers map[string][]string, timeout time.Duration) (*serverResponse, error) {
 	serverResp := &serverResponse{
 		body:       nil,
 		statusCode: -1,
@@ -81,19 +126,40 @@ func (cli *Client) sendClientRequest(method, path string, query url.Values, body
 	}
 
 	req, err := cli.newRequest(method, path, query, body, headers)
+	if err != nil {
+		return serverResp, err
+	}
 	req.URL.Host = cli.addr
 	req.URL.Scheme = cli.scheme
+	reqCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithCancel(ctx)
+		timer := time.AfterFunc(timeout, cancel)
+		defer timer.Stop()
+	}
+	req.Cancel = reqCtx.Done()
 
 	if expectedPayload && req.Header.Get("Content-Type") == "" {
 		req.Header.Set("Content-Type", "text/plain")
 	}
 
+	// Only the method and the URL are logged, the headers may hold
+	// registry credentials.
+	logrus.Debugf("Calling %s %s", method, req.URL.RequestURI())
+
 	resp, err := cli.httpClient.Do(req)
 	if resp != nil {
 		serverResp.statusCode = resp.StatusCode
 	}
 
 	if err != nil {
+		if ctx.Err() != nil {
+			return serverResp, ctx.Err()
+		}
+		if reqCtx.Err() != nil {
+			return serverResp, fmt.Errorf("Error: the Docker daemon didn't respond within %s", timeout)
+		}
 		if isTimeout(err) || strings.Contains(err.Error(), "connection refused") || strings.Contains(err.Error(), "dial unix") {
 			return serverResp, ErrConnectionFailed
 		}
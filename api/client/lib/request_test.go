@@ -0,0 +1,224 @@
+package lib
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestSendRequestDebugLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(strings.Replace(server.URL, "http://", "tcp://", 1), "1.22", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	level := logrus.GetLevel()
+	logrus.SetOutput(&buf)
+	logrus.SetLevel(logrus.DebugLevel)
+	defer func() {
+		logrus.SetOutput(os.Stderr)
+		logrus.SetLevel(level)
+	}()
+
+	headers := map[string][]string{"X-Registry-Auth": {"c2VjcmV0"}}
+	resp, err := c.get("/images/json", url.Values{"all": {"1"}}, headers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ensureReaderClosed(resp)
+
+	out := buf.String()
+	if !strings.Contains(out, "Calling GET /v1.22/images/json?all=1") {
+		t.Fatalf("Expected the request in the debug log, got %q", out)
+	}
+	if strings.Contains(out, "c2VjcmV0") {
+		t.Fatalf("Expected the debug log not to contain the auth header, got %q", out)
+	}
+}
+
+func TestGetWithContextCanceled(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	c, err := NewClient(strings.Replace(server.URL, "http://", "tcp://", 1), "1.22", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	if _, err := c.ImageListWithContext(ctx, types.ImageListOptions{}); err != context.Canceled {
+		t.Fatalf("Expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestContainerWaitWithContextCanceled(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	c, err := NewClient(strings.Replace(server.URL, "http://", "tcp://", 1), "1.22", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	if _, err := c.ContainerWaitWithContext(ctx, "container"); err != context.Canceled {
+		t.Fatalf("Expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestImageInspectWithRawWithContextCanceled(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	c, err := NewClient(strings.Replace(server.URL, "http://", "tcp://", 1), "1.22", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	if _, _, err := c.ImageInspectWithRawWithContext(ctx, "busybox", false); err != context.Canceled {
+		t.Fatalf("Expected %v, got %v", context.Canceled, err)
+	}
+}
+
+// failingServer returns a server which closes the connection of the first
+// failures requests without responding.
+func failingServer(t *testing.T, failures int) (*httptest.Server, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= failures {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatal(err)
+			}
+			conn.Close()
+			return
+		}
+		w.Write([]byte("[]"))
+	}))
+	return server, &requests
+}
+
+func TestSendRequestRetries(t *testing.T) {
+	defer func(delay time.Duration) { retryDelay = delay }(retryDelay)
+	retryDelay = time.Millisecond
+
+	cases := []struct {
+		method   string
+		failures int
+		retries  int
+		requests int
+		success  bool
+	}{
+		{"GET", 2, 2, 3, true},
+		{"GET", 3, 2, 3, false},
+		{"GET", 1, 0, 1, false},
+		{"HEAD", 1, 1, 2, true},
+		{"POST", 1, 2, 1, false},
+	}
+
+	for _, cs := range cases {
+		server, requests := failingServer(t, cs.failures)
+		c, err := NewClientWithOptions(strings.Replace(server.URL, "http://", "tcp://", 1), "1.22", nil, nil, ClientOptions{RequestRetries: cs.retries})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := c.sendClientRequest(context.Background(), cs.method, "/images/json", nil, nil, nil)
+		ensureReaderClosed(resp)
+		server.Close()
+
+		if cs.success && err != nil {
+			t.Fatalf("%s with %d failures and %d retries: unexpected error %v", cs.method, cs.failures, cs.retries, err)
+		}
+		if !cs.success && err == nil {
+			t.Fatalf("%s with %d failures and %d retries: expected an error", cs.method, cs.failures, cs.retries)
+		}
+		if *requests != cs.requests {
+			t.Fatalf("%s with %d failures and %d retries: expected %d requests, got %d", cs.method, cs.failures, cs.retries, cs.requests, *requests)
+		}
+	}
+}
+
+func TestSendRequestTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1.22/stream" {
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			time.Sleep(50 * time.Millisecond)
+			w.Write([]byte("data"))
+			return
+		}
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	c, err := NewClientWithOptions(strings.Replace(server.URL, "http://", "tcp://", 1), "1.22", nil, nil, ClientOptions{RequestTimeout: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.get("/images/json", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "didn't respond within 20ms") {
+		t.Fatalf("Expected a timeout error, got %v", err)
+	}
+
+	// The timeout doesn't apply to the body of the response.
+	resp, err := c.get("/stream", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ensureReaderClosed(resp)
+	data, err := ioutil.ReadAll(resp.body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "data" {
+		t.Fatalf("Expected data, got %q", data)
+	}
+}
@@ -4,12 +4,19 @@ import (
 	"encoding/json"
 
 	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
 )
 
 // ContainerWait pauses execution util a container is exits.
 // It returns the API status code as response of its readiness.
 func (cli *Client) ContainerWait(containerID string) (int, error) {
-	resp, err := cli.post("/containers/"+containerID+"/wait", nil, nil, nil)
+	return cli.ContainerWaitWithContext(context.Background(), containerID)
+}
+
+// ContainerWaitWithContext pauses execution until a container exits.
+// The wait is aborted when the context is canceled.
+func (cli *Client) ContainerWaitWithContext(ctx context.Context, containerID string) (int, error) {
+	resp, err := cli.postWithContext(ctx, "/containers/"+containerID+"/wait", nil, nil, nil)
 	if err != nil {
 		return -1, err
 	}
@@ -5,17 +5,21 @@ import (
 	"os"
 
 	Cli "github.com/docker/docker/cli"
+	"github.com/docker/docker/pkg/jsonmessage"
 	flag "github.com/docker/docker/pkg/mflag"
 )
 
 // CmdLoad loads an image from a tar archive.
 //
 // The tar archive is read from STDIN by default, or from a tar archive file.
+// The archive is sent as is, the daemon decompresses a gzip, bzip2 or xz
+// compressed archive itself.
 //
 // Usage: docker load [OPTIONS]
 func (cli *DockerCli) CmdLoad(args ...string) error {
 	cmd := Cli.Subcmd("load", nil, Cli.DockerCommands["load"].Description, true)
 	infile := cmd.String([]string{"i", "-input"}, "", "Read from a tar archive file, instead of STDIN")
+	quiet := cmd.Bool([]string{"q", "-quiet"}, false, "Suppress the load progress, only print the loaded images")
 	cmd.Require(flag.Exact, 0)
 	cmd.ParseFlags(args, true)
 
@@ -29,12 +33,16 @@ func (cli *DockerCli) CmdLoad(args ...string) error {
 		input = file
 	}
 
-	responseBody, err := cli.client.ImageLoad(input)
+	// The progress bars only make sense on a terminal.
+	response, err := cli.client.ImageLoad(input, *quiet || !cli.isTerminalOut)
 	if err != nil {
 		return err
 	}
-	defer responseBody.Close()
+	defer response.Body.Close()
 
-	_, err = io.Copy(cli.out, responseBody)
+	if response.JSON {
+		return jsonmessage.DisplayJSONMessagesStream(response.Body, cli.out, cli.outFd, cli.isTerminalOut)
+	}
+	_, err = io.Copy(cli.out, response.Body)
 	return err
 }
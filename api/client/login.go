@@ -2,8 +2,10 @@ package client
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"runtime"
 	"strings"
@@ -30,6 +32,7 @@ func (cli *DockerCli) CmdLogin(args ...string) error {
 	cmd.StringVar(&username, []string{"u", "-username"}, "", "Username")
 	cmd.StringVar(&password, []string{"p", "-password"}, "", "Password")
 	cmd.StringVar(&email, []string{"e", "-email"}, "", "Email")
+	passwordStdin := cmd.Bool([]string{"-password-stdin"}, false, "Take the password from stdin")
 
 	cmd.ParseFlags(args, true)
 
@@ -38,6 +41,19 @@ func (cli *DockerCli) CmdLogin(args ...string) error {
 		cli.in = os.Stdin
 	}
 
+	if *passwordStdin {
+		if password != "" {
+			return errors.New("--password and --password-stdin are mutually exclusive")
+		}
+		if username == "" {
+			return errors.New("Must provide --username with --password-stdin")
+		}
+		var err error
+		if password, err = readPasswordStdin(cli.in); err != nil {
+			return err
+		}
+	}
+
 	serverAddress := registry.IndexServer
 	if len(cmd.Args()) > 0 {
 		serverAddress = cmd.Arg(0)
@@ -94,12 +110,13 @@ func (cli *DockerCli) CmdLogin(args ...string) error {
 			}
 		}
 
-		if email == "" {
+		// The standard input was read for the password already.
+		if email == "" && !*passwordStdin {
 			promptDefault("Email", authconfig.Email)
 			email = readInput(cli.in, cli.out)
-			if email == "" {
-				email = authconfig.Email
-			}
+		}
+		if email == "" {
+			email = authconfig.Email
 		}
 	} else {
 		// However, if they don't override the username use the
@@ -141,3 +158,21 @@ func (cli *DockerCli) CmdLogin(args ...string) error {
 	}
 	return nil
 }
+
+// readPasswordStdin reads the password given on the standard input with
+// --password-stdin: a single line, with its trailing new line trimmed.
+func readPasswordStdin(in io.Reader) (string, error) {
+	content, err := ioutil.ReadAll(in)
+	if err != nil {
+		return "", fmt.Errorf("Error reading the password from stdin: %v", err)
+	}
+	password := strings.TrimSuffix(string(content), "\n")
+	password = strings.TrimSuffix(password, "\r")
+	if strings.ContainsAny(password, "\r\n") {
+		return "", errors.New("The password read from stdin must be a single line")
+	}
+	if password == "" {
+		return "", errors.New("Error : Password Required")
+	}
+	return password, nil
+}
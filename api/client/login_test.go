@@ -0,0 +1,30 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadPasswordStdin(t *testing.T) {
+	cases := map[string]string{
+		"secret":           "secret",
+		"secret\n":         "secret",
+		"secret\r\n":       "secret",
+		" spaced secret\n": " spaced secret",
+	}
+	for in, expected := range cases {
+		password, err := readPasswordStdin(strings.NewReader(in))
+		if err != nil {
+			t.Fatalf("%q: %v", in, err)
+		}
+		if password != expected {
+			t.Fatalf("%q: expected %q, got %q", in, expected, password)
+		}
+	}
+
+	for _, in := range []string{"", "\n", "\r\n", "first\nsecond\n"} {
+		if _, err := readPasswordStdin(strings.NewReader(in)); err == nil {
+			t.Fatalf("%q: expected an error", in)
+		}
+	}
+}
@@ -1,8 +1,12 @@
 package client
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"regexp"
+	"strconv"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	Cli "github.com/docker/docker/cli"
@@ -15,6 +19,50 @@ var validDrivers = map[string]bool{
 	"journald":  true,
 }
 
+const (
+	rfc3339TimestampFormat  = "rfc3339"
+	unixTimestampFormat     = "unix"
+	relativeTimestampFormat = "relative"
+)
+
+// timestampsOpt is the value of the --timestamps flag. It can be used as a
+// boolean flag, in which case the timestamps are displayed as sent by the
+// daemon, or be given a format.
+type timestampsOpt struct {
+	enabled bool
+	format  string
+}
+
+func (o *timestampsOpt) Set(value string) error {
+	if enabled, err := strconv.ParseBool(value); err == nil {
+		o.enabled, o.format = enabled, ""
+		return nil
+	}
+	switch value {
+	case rfc3339TimestampFormat, unixTimestampFormat, relativeTimestampFormat:
+	default:
+		// A layout without any of the reference time elements would
+		// display the same string for every line.
+		if time.Unix(0, 0).UTC().Format(value) == value {
+			return fmt.Errorf("invalid timestamps format %q: expected rfc3339, unix, relative or a Go time layout", value)
+		}
+	}
+	o.enabled, o.format = true, value
+	return nil
+}
+
+func (o *timestampsOpt) String() string {
+	if o.format != "" {
+		return o.format
+	}
+	return strconv.FormatBool(o.enabled)
+}
+
+// IsBoolFlag allows --timestamps to be given without a value.
+func (o *timestampsOpt) IsBoolFlag() bool {
+	return true
+}
+
 // CmdLogs fetches the logs of a given container.
 //
 // docker logs [OPTIONS] CONTAINER
@@ -22,14 +70,22 @@ func (cli *DockerCli) CmdLogs(args ...string) error {
 	cmd := Cli.Subcmd("logs", []string{"CONTAINER"}, Cli.DockerCommands["logs"].Description, true)
 	follow := cmd.Bool([]string{"f", "-follow"}, false, "Follow log output")
 	since := cmd.String([]string{"-since"}, "", "Show logs since timestamp")
-	times := cmd.Bool([]string{"t", "-timestamps"}, false, "Show timestamps")
+	times := &timestampsOpt{}
+	cmd.Var(times, []string{"t", "-timestamps"}, "Show timestamps, optionally in the given format (rfc3339, unix, relative or a Go layout)")
 	tail := cmd.String([]string{"-tail"}, "all", "Number of lines to show from the end of the logs")
+	grep := cmd.String([]string{"-grep"}, "", "Only show lines matching the regular expression")
+	grepV := cmd.String([]string{"-grep-v"}, "", "Only show lines not matching the regular expression")
 	cmd.Require(flag.Exact, 1)
 
 	cmd.ParseFlags(args, true)
 
 	name := cmd.Arg(0)
 
+	filter, err := newLogLineFilter(*grep, *grepV)
+	if err != nil {
+		return err
+	}
+
 	c, err := cli.client.ContainerInspect(name)
 	if err != nil {
 		return err
@@ -39,12 +95,26 @@ func (cli *DockerCli) CmdLogs(args ...string) error {
 		return fmt.Errorf("\"logs\" command is supported only for \"json-file\" and \"journald\" logging drivers (got: %s)", c.HostConfig.LogConfig.Type)
 	}
 
+	var rewrites []func(line []byte) []byte
+	if filter != nil {
+		// The filter runs before the timestamp is rewritten, while it can
+		// still be told apart from the message.
+		rewrites = append(rewrites, newLogFilterRewriter(filter, times.enabled))
+	}
+	if times.format != "" {
+		rewrite, err := newTimestampRewriter(times.format, c.State.StartedAt)
+		if err != nil {
+			return err
+		}
+		rewrites = append(rewrites, rewrite)
+	}
+
 	options := types.ContainerLogsOptions{
 		ContainerID: name,
 		ShowStdout:  true,
 		ShowStderr:  true,
 		Since:       *since,
-		Timestamps:  *times,
+		Timestamps:  times.enabled,
 		Follow:      *follow,
 		Tail:        *tail,
 	}
@@ -54,10 +124,173 @@ func (cli *DockerCli) CmdLogs(args ...string) error {
 	}
 	defer responseBody.Close()
 
+	stdout, stderr := cli.out, cli.err
+	if len(rewrites) > 0 {
+		outWriter := &logLineWriter{out: cli.out, rewrites: rewrites}
+		errWriter := &logLineWriter{out: cli.err, rewrites: rewrites}
+		defer outWriter.Flush()
+		defer errWriter.Flush()
+		stdout, stderr = outWriter, errWriter
+	}
+
 	if c.Config.Tty {
-		_, err = io.Copy(cli.out, responseBody)
+		_, err = io.Copy(stdout, responseBody)
 	} else {
-		_, err = stdcopy.StdCopy(cli.out, cli.err, responseBody)
+		_, err = stdcopy.StdCopy(stdout, stderr, responseBody)
+	}
+	return err
+}
+
+// newLogLineFilter returns a function reporting whether a log line matches the
+// grep pattern and doesn't match the inverse pattern. It returns nil if there
+// are no patterns.
+func newLogLineFilter(pattern, inversePattern string) (func(line []byte) bool, error) {
+	if pattern == "" && inversePattern == "" {
+		return nil, nil
+	}
+
+	var re, inverseRe *regexp.Regexp
+	if pattern != "" {
+		var err error
+		if re, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid --grep pattern %q: %v", pattern, err)
+		}
+	}
+	if inversePattern != "" {
+		var err error
+		if inverseRe, err = regexp.Compile(inversePattern); err != nil {
+			return nil, fmt.Errorf("invalid --grep-v pattern %q: %v", inversePattern, err)
+		}
+	}
+
+	return func(line []byte) bool {
+		if re != nil && !re.Match(line) {
+			return false
+		}
+		return inverseRe == nil || !inverseRe.Match(line)
+	}, nil
+}
+
+// newLogFilterRewriter returns a function dropping the log lines whose message
+// doesn't match the filter. If the lines have timestamps, the timestamp the
+// daemon prepends isn't part of the message.
+func newLogFilterRewriter(filter func(line []byte) bool, timestamps bool) func(line []byte) []byte {
+	return func(line []byte) []byte {
+		message := line
+		if timestamps {
+			if _, rest, ok := splitLogTimestamp(line); ok {
+				message = rest
+			}
+		}
+		if filter(bytes.TrimRight(message, "\r\n")) {
+			return line
+		}
+		return nil
+	}
+}
+
+// newTimestampRewriter returns a function replacing the RFC3339Nano timestamp
+// the daemon prepends to a log line with the same time in the given format.
+// Relative timestamps are computed from the container's start time.
+func newTimestampRewriter(format, startedAt string) (func(line []byte) []byte, error) {
+	var start time.Time
+	if format == relativeTimestampFormat {
+		var err error
+		start, err = time.Parse(time.RFC3339Nano, startedAt)
+		if err != nil || start.IsZero() {
+			return nil, fmt.Errorf("cannot show relative timestamps: the container has not been started")
+		}
+	}
+
+	formatTime := func(t time.Time) string {
+		switch format {
+		case rfc3339TimestampFormat:
+			return t.UTC().Format(time.RFC3339)
+		case unixTimestampFormat:
+			return fmt.Sprintf("%d.%09d", t.Unix(), t.Nanosecond())
+		case relativeTimestampFormat:
+			return formatRelativeTimestamp(t.Sub(start))
+		}
+		return t.UTC().Format(format)
+	}
+
+	return func(line []byte) []byte {
+		t, rest, ok := splitLogTimestamp(line)
+		if !ok {
+			return line
+		}
+		return append([]byte(formatTime(t)+" "), rest...)
+	}, nil
+}
+
+// splitLogTimestamp splits the RFC3339Nano timestamp the daemon prepends to a
+// log line, followed by a space, from the message. It returns false if the
+// line doesn't start with a timestamp.
+func splitLogTimestamp(line []byte) (time.Time, []byte, bool) {
+	i := bytes.IndexByte(line, ' ')
+	if i < 0 {
+		return time.Time{}, nil, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, string(line[:i]))
+	if err != nil {
+		return time.Time{}, nil, false
+	}
+	return t, line[i+1:], true
+}
+
+// formatRelativeTimestamp formats a duration as +HH:MM:SS.
+func formatRelativeTimestamp(d time.Duration) string {
+	sign := "+"
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	d /= time.Second
+	return fmt.Sprintf("%s%02d:%02d:%02d", sign, d/3600, d/60%60, d%60)
+}
+
+// logLineWriter passes every line through the rewrites before writing it to
+// out, a rewrite returning nil drops the line. Partial lines are kept until
+// they're completed by a later write, or until Flush.
+type logLineWriter struct {
+	out      io.Writer
+	rewrites []func(line []byte) []byte
+	buf      []byte
+}
+
+func (w *logLineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	start := 0
+	for {
+		i := bytes.IndexByte(w.buf[start:], '\n')
+		if i < 0 {
+			break
+		}
+		end := start + i + 1
+		if err := w.writeLine(w.buf[start:end]); err != nil {
+			return 0, err
+		}
+		start = end
+	}
+	w.buf = append(w.buf[:0], w.buf[start:]...)
+	return len(p), nil
+}
+
+// Flush writes the last line if it wasn't terminated by a newline.
+func (w *logLineWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.writeLine(w.buf)
+	w.buf = w.buf[:0]
+	return err
+}
+
+func (w *logLineWriter) writeLine(line []byte) error {
+	for _, rewrite := range w.rewrites {
+		if line = rewrite(line); line == nil {
+			return nil
+		}
 	}
+	_, err := w.out.Write(line)
 	return err
 }
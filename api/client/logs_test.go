@@ -0,0 +1,182 @@
+package client
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestNewLogLineFilter(t *testing.T) {
+	cases := []struct {
+		grep, grepV string
+		line        string
+		expected    bool
+	}{
+		{"error", "", "an error occurred", true},
+		{"error", "", "all good", false},
+		{"", "debug", "debug: details", false},
+		{"", "debug", "info: started", true},
+		{"^web", "health", "web: GET /", true},
+		{"^web", "health", "web: GET /health", false},
+		{"^web", "health", "db: ready", false},
+	}
+	for _, c := range cases {
+		filter, err := newLogLineFilter(c.grep, c.grepV)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := filter([]byte(c.line)); actual != c.expected {
+			t.Fatalf("grep %q, grep-v %q on %q: expected %v, got %v", c.grep, c.grepV, c.line, c.expected, actual)
+		}
+	}
+}
+
+func TestNewLogLineFilterNoPatterns(t *testing.T) {
+	filter, err := newLogLineFilter("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filter != nil {
+		t.Fatal("Expected no filter without patterns")
+	}
+}
+
+func TestNewLogLineFilterInvalid(t *testing.T) {
+	if _, err := newLogLineFilter("(", ""); err == nil {
+		t.Fatal("Expected an error for an invalid --grep pattern")
+	}
+	if _, err := newLogLineFilter("", "["); err == nil {
+		t.Fatal("Expected an error for an invalid --grep-v pattern")
+	}
+}
+
+func TestLogLineWriterChunks(t *testing.T) {
+	filter, err := newLogLineFilter("keep", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := new(bytes.Buffer)
+	w := &logLineWriter{out: out, rewrites: []func([]byte) []byte{
+		func(line []byte) []byte {
+			if filter(bytes.TrimRight(line, "\r\n")) {
+				return line
+			}
+			return nil
+		},
+	}}
+
+	chunks := []string{"kee", "p 1\ndrop 1\nke", "ep 2\r\n", "drop 2\nkeep", " 3"}
+	for _, chunk := range chunks {
+		if _, err := w.Write([]byte(chunk)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if expected := "keep 1\nkeep 2\r\n"; out.String() != expected {
+		t.Fatalf("Expected %q before flush, got %q", expected, out.String())
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "keep 1\nkeep 2\r\nkeep 3"; out.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestTimestampsOpt(t *testing.T) {
+	cases := []struct {
+		value   string
+		enabled bool
+		format  string
+	}{
+		{"true", true, ""},
+		{"false", false, ""},
+		{"rfc3339", true, "rfc3339"},
+		{"unix", true, "unix"},
+		{"relative", true, "relative"},
+		{"15:04:05", true, "15:04:05"},
+	}
+	for _, c := range cases {
+		o := &timestampsOpt{}
+		if err := o.Set(c.value); err != nil {
+			t.Fatalf("%q: %v", c.value, err)
+		}
+		if o.enabled != c.enabled || o.format != c.format {
+			t.Fatalf("%q: expected (%v, %q), got (%v, %q)", c.value, c.enabled, c.format, o.enabled, o.format)
+		}
+	}
+
+	o := &timestampsOpt{}
+	if err := o.Set("compact"); err == nil {
+		t.Fatal("Expected an error for a format without any time element")
+	}
+}
+
+func TestTimestampRewriter(t *testing.T) {
+	line := []byte("2016-09-16T06:17:46.000000042Z hello world\n")
+	cases := []struct {
+		format   string
+		expected string
+	}{
+		{"rfc3339", "2016-09-16T06:17:46Z hello world\n"},
+		{"unix", "1474006666.000000042 hello world\n"},
+		{"relative", "+01:02:03 hello world\n"},
+		{"15:04", "06:17 hello world\n"},
+	}
+	for _, c := range cases {
+		rewrite, err := newTimestampRewriter(c.format, "2016-09-16T05:15:43Z")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := string(rewrite(line)); actual != c.expected {
+			t.Fatalf("%s: expected %q, got %q", c.format, c.expected, actual)
+		}
+	}
+}
+
+func TestLogFilterRewriterWithTimestamps(t *testing.T) {
+	filter, err := newLogLineFilter("^ERROR", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rewrite, err := newTimestampRewriter("unix", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := new(bytes.Buffer)
+	w := &logLineWriter{out: out, rewrites: []func([]byte) []byte{newLogFilterRewriter(filter, true), rewrite}}
+	w.Write([]byte("2016-09-16T06:17:46.000000042Z ERROR: failed\n2016-09-16T06:17:47Z INFO: ERROR in a message\n"))
+	if expected := "1474006666.000000042 ERROR: failed\n"; out.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestTimestampRewriterWithoutTimestamp(t *testing.T) {
+	rewrite, err := newTimestampRewriter("unix", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := string(rewrite([]byte("hello world\n"))); actual != "hello world\n" {
+		t.Fatalf("Expected the line to be unchanged, got %q", actual)
+	}
+}
+
+func TestTimestampRewriterRelativeNotStarted(t *testing.T) {
+	if _, err := newTimestampRewriter("relative", "0001-01-01T00:00:00Z"); err == nil {
+		t.Fatal("Expected an error for a container that has not been started")
+	}
+}
+
+func TestFormatRelativeTimestamp(t *testing.T) {
+	cases := map[time.Duration]string{
+		0:                                    "+00:00:00",
+		83 * time.Second:                     "+00:01:23",
+		26*time.Hour + 1500*time.Millisecond: "+26:00:01",
+		-5 * time.Second:                     "-00:00:05",
+	}
+	for d, expected := range cases {
+		if actual := formatRelativeTimestamp(d); actual != expected {
+			t.Fatalf("%s: expected %q, got %q", d, expected, actual)
+		}
+	}
+}
@@ -4,14 +4,14 @@ import (
 	"fmt"
 	"net"
 	"strings"
-	"text/tabwriter"
 
+	"github.com/docker/docker/api/client/formatter"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/network"
 	Cli "github.com/docker/docker/cli"
 	"github.com/docker/docker/opts"
 	flag "github.com/docker/docker/pkg/mflag"
-	"github.com/docker/docker/pkg/stringid"
 )
 
 // CmdNetwork is the parent subcommand for all network commands
@@ -137,42 +137,89 @@ func (cli *DockerCli) CmdNetworkLs(args ...string) error {
 	cmd := Cli.Subcmd("network ls", nil, "Lists networks", true)
 	quiet := cmd.Bool([]string{"q", "-quiet"}, false, "Only display numeric IDs")
 	noTrunc := cmd.Bool([]string{"-no-trunc"}, false, "Do not truncate the output")
+	format := cmd.String([]string{"-format"}, "", "Pretty-print networks using a Go template")
+	flFilter := opts.NewListOpts(nil)
+	cmd.Var(&flFilter, []string{"f", "-filter"}, "Filter output based on conditions provided")
 
 	cmd.Require(flag.Exact, 0)
 	if err := cmd.ParseFlags(args, true); err != nil {
 		return err
 	}
 
-	networkResources, err := cli.client.NetworkList()
+	netFilterArgs := filters.NewArgs()
+	for _, f := range flFilter.GetAll() {
+		var err error
+		if netFilterArgs, err = filters.ParseFlag(f, netFilterArgs); err != nil {
+			return err
+		}
+	}
+	if err := validateNetworkFilters(netFilterArgs); err != nil {
+		return err
+	}
+
+	networkResources, err := cli.client.NetworkList(netFilterArgs)
 	if err != nil {
 		return err
 	}
 
-	wr := tabwriter.NewWriter(cli.out, 20, 1, 3, ' ', 0)
+	f := *format
+	if len(f) == 0 {
+		f = envFormat("DOCKER_NETWORKS_FORMAT", *quiet)
+	}
+	if len(f) == 0 {
+		f = "table"
+	}
 
-	// unless quiet (-q) is specified, print field titles
-	if !*quiet {
-		fmt.Fprintln(wr, "NETWORK ID\tNAME\tDRIVER")
+	networkCtx := formatter.NetworkContext{
+		Context: formatter.Context{
+			Output: cli.out,
+			Format: f,
+			Quiet:  *quiet,
+			Trunc:  !*noTrunc,
+		},
+		Networks: networkResources,
 	}
 
-	for _, networkResource := range networkResources {
-		ID := networkResource.ID
-		netName := networkResource.Name
-		if !*noTrunc {
-			ID = stringid.TruncateID(ID)
-		}
-		if *quiet {
-			fmt.Fprintln(wr, ID)
+	networkCtx.Write()
+	return nil
+}
+
+// acceptedNetworkFilters are the filters of docker network ls, with the
+// values they accept if they're limited.
+var acceptedNetworkFilters = map[string][]string{
+	"driver": nil,
+	"id":     nil,
+	"name":   nil,
+	"scope":  {"local", "global", "swarm"},
+	"type":   {"builtin", "custom"},
+}
+
+// validateNetworkFilters checks the filters of docker network ls and their
+// values before the networks are listed.
+func validateNetworkFilters(netFilterArgs filters.Args) error {
+	accepted := map[string]bool{}
+	for field := range acceptedNetworkFilters {
+		accepted[field] = true
+	}
+	if err := netFilterArgs.Validate(accepted); err != nil {
+		return fmt.Errorf("%v (valid filters are driver, id, name, scope and type)", err)
+	}
+	for field, values := range acceptedNetworkFilters {
+		if values == nil {
 			continue
 		}
-		driver := networkResource.Driver
-		fmt.Fprintf(wr, "%s\t%s\t%s\t",
-			ID,
-			netName,
-			driver)
-		fmt.Fprint(wr, "\n")
-	}
-	wr.Flush()
+		err := netFilterArgs.WalkValues(field, func(value string) error {
+			for _, v := range values {
+				if value == v {
+					return nil
+				}
+			}
+			return fmt.Errorf("Invalid filter '%s=%s' (valid values are %s)", field, value, strings.Join(values, ", "))
+		})
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -200,123 +247,114 @@ func (cli *DockerCli) CmdNetworkInspect(args ...string) error {
 // user can configure network with multiple non-overlapping subnets and hence it is
 // possible to correlate the various related parameters and consolidate them.
 // consoidateIpam consolidates subnets, ip-ranges, gateways and auxiliary addresses into
-// structured ipam data.
+// structured ipam data, in the order the subnets are given. The addresses are
+// validated against the subnets so that an invalid configuration is rejected
+// before the network is created.
 func consolidateIpam(subnets, ranges, gateways []string, auxaddrs map[string]string) ([]network.IPAMConfig, error) {
 	if len(subnets) < len(ranges) || len(subnets) < len(gateways) {
 		return nil, fmt.Errorf("every ip-range or gateway must have a corresponding subnet")
 	}
-	iData := map[string]*network.IPAMConfig{}
+	nets := make([]*net.IPNet, len(subnets))
+	iData := make([]network.IPAMConfig, len(subnets))
 
-	// Populate non-overlapping subnets into consolidation map
-	for _, s := range subnets {
-		for k := range iData {
-			ok1, err := subnetMatches(s, k)
-			if err != nil {
-				return nil, err
-			}
-			ok2, err := subnetMatches(k, s)
-			if err != nil {
-				return nil, err
-			}
-			if ok1 || ok2 {
-				return nil, fmt.Errorf("multiple overlapping subnet configuration is not supported")
+	// Populate non-overlapping subnets
+	for i, s := range subnets {
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid subnet %s : %v", s, err)
+		}
+		for j, k := range nets[:i] {
+			if k.Contains(n.IP) || n.Contains(k.IP) {
+				return nil, fmt.Errorf("subnet %s overlaps with subnet %s", s, subnets[j])
 			}
 		}
-		iData[s] = &network.IPAMConfig{Subnet: s, AuxAddress: map[string]string{}}
+		nets[i] = n
+		iData[i] = network.IPAMConfig{Subnet: s, AuxAddress: map[string]string{}}
 	}
 
 	// Validate and add valid ip ranges
 	for _, r := range ranges {
-		match := false
-		for _, s := range subnets {
-			ok, err := subnetMatches(s, r)
-			if err != nil {
-				return nil, err
-			}
-			if !ok {
-				continue
-			}
-			if iData[s].IPRange != "" {
-				return nil, fmt.Errorf("cannot configure multiple ranges (%s, %s) on the same subnet (%s)", r, iData[s].IPRange, s)
-			}
-			d := iData[s]
-			d.IPRange = r
-			match = true
+		_, rn, err := net.ParseCIDR(r)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid ip-range %s : %v", r, err)
 		}
-		if !match {
-			return nil, fmt.Errorf("no matching subnet for range %s", r)
+		i := matchingSubnet(nets, rn.IP)
+		if i < 0 {
+			return nil, noMatchingSubnetError("range", r, subnets)
 		}
+		rOnes, rBits := rn.Mask.Size()
+		sOnes, sBits := nets[i].Mask.Size()
+		if rBits != sBits || rOnes < sOnes {
+			return nil, fmt.Errorf("ip-range %s is not a subset of subnet %s", r, subnets[i])
+		}
+		if iData[i].IPRange != "" {
+			return nil, fmt.Errorf("cannot configure multiple ranges (%s, %s) on the same subnet (%s)", r, iData[i].IPRange, subnets[i])
+		}
+		iData[i].IPRange = r
 	}
 
 	// Validate and add valid gateways
 	for _, g := range gateways {
-		match := false
-		for _, s := range subnets {
-			ok, err := subnetMatches(s, g)
-			if err != nil {
-				return nil, err
-			}
-			if !ok {
-				continue
-			}
-			if iData[s].Gateway != "" {
-				return nil, fmt.Errorf("cannot configure multiple gateways (%s, %s) for the same subnet (%s)", g, iData[s].Gateway, s)
-			}
-			d := iData[s]
-			d.Gateway = g
-			match = true
+		ip, err := parseIPAMAddress(g)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid gateway %s : %v", g, err)
 		}
-		if !match {
-			return nil, fmt.Errorf("no matching subnet for gateway %s", g)
+		i := matchingSubnet(nets, ip)
+		if i < 0 {
+			return nil, noMatchingSubnetError("gateway", g, subnets)
 		}
+		if iData[i].Gateway != "" {
+			return nil, fmt.Errorf("cannot configure multiple gateways (%s, %s) for the same subnet (%s)", g, iData[i].Gateway, subnets[i])
+		}
+		iData[i].Gateway = g
 	}
 
 	// Validate and add aux-addresses
 	for key, aa := range auxaddrs {
-		match := false
-		for _, s := range subnets {
-			ok, err := subnetMatches(s, aa)
-			if err != nil {
-				return nil, err
-			}
-			if !ok {
-				continue
-			}
-			iData[s].AuxAddress[key] = aa
-			match = true
+		ip, err := parseIPAMAddress(aa)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid aux-address %s=%s : %v", key, aa, err)
 		}
-		if !match {
-			return nil, fmt.Errorf("no matching subnet for aux-address %s", aa)
+		i := matchingSubnet(nets, ip)
+		if i < 0 {
+			return nil, noMatchingSubnetError("aux-address", aa, subnets)
 		}
+		iData[i].AuxAddress[key] = aa
 	}
 
-	idl := []network.IPAMConfig{}
-	for _, v := range iData {
-		idl = append(idl, *v)
-	}
-	return idl, nil
+	return iData, nil
 }
 
-func subnetMatches(subnet, data string) (bool, error) {
-	var (
-		ip net.IP
-	)
-
-	_, s, err := net.ParseCIDR(subnet)
-	if err != nil {
-		return false, fmt.Errorf("Invalid subnet %s : %v", s, err)
+// matchingSubnet returns the index of the subnet containing ip, or -1 if none
+// of them contains it. As the subnets don't overlap, there is at most one.
+func matchingSubnet(nets []*net.IPNet, ip net.IP) int {
+	for i, n := range nets {
+		if n.Contains(ip) {
+			return i
+		}
 	}
+	return -1
+}
 
-	if strings.Contains(data, "/") {
-		ip, _, err = net.ParseCIDR(data)
-		if err != nil {
-			return false, fmt.Errorf("Invalid cidr %s : %v", data, err)
-		}
-	} else {
-		ip = net.ParseIP(data)
+// parseIPAMAddress parses a gateway or an auxiliary address, given either as
+// an IP address or in CIDR format.
+func parseIPAMAddress(addr string) (net.IP, error) {
+	if strings.Contains(addr, "/") {
+		ip, _, err := net.ParseCIDR(addr)
+		return ip, err
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return nil, fmt.Errorf("not an IP address")
 	}
+	return ip, nil
+}
 
-	return s.Contains(ip), nil
+func noMatchingSubnetError(kind, value string, subnets []string) error {
+	if len(subnets) == 1 {
+		return fmt.Errorf("%s %s is not in subnet %s", kind, value, subnets[0])
+	}
+	return fmt.Errorf("no matching subnet for %s %s (subnets are %s)", kind, value, strings.Join(subnets, ", "))
 }
 
 func networkUsage() string {
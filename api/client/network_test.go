@@ -0,0 +1,83 @@
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/filters"
+)
+
+func TestValidateNetworkFilters(t *testing.T) {
+	valid := []string{"driver=bridge", "id=7fca", "name=mynet", "scope=local", "scope=swarm", "type=builtin", "type=custom"}
+	for _, f := range valid {
+		args, err := filters.ParseFlag(f, filters.NewArgs())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := validateNetworkFilters(args); err != nil {
+			t.Fatalf("%s: %v", f, err)
+		}
+	}
+
+	invalid := map[string]string{
+		"drvier=bridge": "Invalid filter 'drvier' (valid filters are driver, id, name, scope and type)",
+		"scope=host":    "Invalid filter 'scope=host' (valid values are local, global, swarm)",
+		"type=user":     "Invalid filter 'type=user' (valid values are builtin, custom)",
+	}
+	for f, expected := range invalid {
+		args, err := filters.ParseFlag(f, filters.NewArgs())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := validateNetworkFilters(args); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("%s: expected %q, got %v", f, expected, err)
+		}
+	}
+}
+
+func TestConsolidateIpam(t *testing.T) {
+	cfg, err := consolidateIpam(
+		[]string{"192.168.0.0/16", "10.0.0.0/24"},
+		[]string{"10.0.0.128/25", "192.168.1.0/24"},
+		[]string{"10.0.0.1"},
+		map[string]string{"host1": "192.168.0.5"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg) != 2 {
+		t.Fatalf("expected 2 configurations, got %v", cfg)
+	}
+	if cfg[0].Subnet != "192.168.0.0/16" || cfg[0].IPRange != "192.168.1.0/24" || cfg[0].Gateway != "" || cfg[0].AuxAddress["host1"] != "192.168.0.5" {
+		t.Fatalf("unexpected configuration %v", cfg[0])
+	}
+	if cfg[1].Subnet != "10.0.0.0/24" || cfg[1].IPRange != "10.0.0.128/25" || cfg[1].Gateway != "10.0.0.1" || len(cfg[1].AuxAddress) != 0 {
+		t.Fatalf("unexpected configuration %v", cfg[1])
+	}
+}
+
+func TestConsolidateIpamInvalid(t *testing.T) {
+	cases := []struct {
+		subnets, ranges, gateways []string
+		auxaddrs                  map[string]string
+		expected                  string
+	}{
+		{[]string{"192.168.0.0"}, nil, nil, nil, "Invalid subnet 192.168.0.0"},
+		{[]string{"192.168.0.0/16", "192.168.1.0/24"}, nil, nil, nil, "subnet 192.168.1.0/24 overlaps with subnet 192.168.0.0/16"},
+		{[]string{"10.0.0.0/24", "10.0.0.0/8"}, nil, nil, nil, "subnet 10.0.0.0/8 overlaps with subnet 10.0.0.0/24"},
+		{[]string{"192.168.0.0/16"}, []string{"192.170.0.0/16"}, nil, nil, "range 192.170.0.0/16 is not in subnet 192.168.0.0/16"},
+		{[]string{"192.168.0.0/24"}, []string{"192.168.0.0/16"}, nil, nil, "ip-range 192.168.0.0/16 is not a subset of subnet 192.168.0.0/24"},
+		{[]string{"192.168.0.0/24"}, []string{"192.168.0.256/28"}, nil, nil, "Invalid ip-range 192.168.0.256/28"},
+		{[]string{"192.168.0.0/16", "10.0.0.0/8"}, nil, []string{"172.17.0.1"}, nil, "no matching subnet for gateway 172.17.0.1 (subnets are 192.168.0.0/16, 10.0.0.0/8)"},
+		{[]string{"192.168.0.0/16", "10.0.0.0/8"}, nil, []string{"192.168.0.1", "192.168.0.2"}, nil, "cannot configure multiple gateways (192.168.0.2, 192.168.0.1) for the same subnet (192.168.0.0/16)"},
+		{[]string{"192.168.0.0/16"}, nil, []string{"gateway"}, nil, "Invalid gateway gateway"},
+		{[]string{"192.168.0.0/16"}, nil, nil, map[string]string{"host1": "10.0.0.5"}, "aux-address 10.0.0.5 is not in subnet 192.168.0.0/16"},
+		{[]string{"192.168.0.0/16"}, []string{"192.168.1.0/24"}, []string{"192.168.0.1", "192.168.0.2"}, nil, "every ip-range or gateway must have a corresponding subnet"},
+	}
+	for _, c := range cases {
+		_, err := consolidateIpam(c.subnets, c.ranges, c.gateways, c.auxaddrs)
+		if err == nil || !strings.Contains(err.Error(), c.expected) {
+			t.Fatalf("expected %q, got %v", c.expected, err)
+		}
+	}
+}
@@ -1,29 +1,59 @@
 package client
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"text/template"
 
 	Cli "github.com/docker/docker/cli"
 	flag "github.com/docker/docker/pkg/mflag"
 	"github.com/docker/docker/pkg/nat"
 )
 
+// portEntry is a port of a container and one of its host bindings, as given
+// to the --format template and printed by the --json flag of docker port.
+// The host fields are empty if the port isn't published.
+type portEntry struct {
+	ContainerPort string `json:"containerPort"`
+	Protocol      string `json:"protocol"`
+	HostIP        string `json:"hostIp"`
+	HostPort      string `json:"hostPort"`
+}
+
 // CmdPort lists port mappings for a container.
 // If a private port is specified, it also shows the public-facing port that is NATed to the private port.
 //
-// Usage: docker port CONTAINER [PRIVATE_PORT[/PROTO]]
+// Usage: docker port [OPTIONS] CONTAINER [PRIVATE_PORT[/PROTO]]
 func (cli *DockerCli) CmdPort(args ...string) error {
 	cmd := Cli.Subcmd("port", []string{"CONTAINER [PRIVATE_PORT[/PROTO]]"}, Cli.DockerCommands["port"].Description, true)
+	format := cmd.String([]string{"-format"}, "", "Pretty-print port mappings using a Go template")
+	jsonOutput := cmd.Bool([]string{"-json"}, false, "Print the port mappings as a JSON array")
 	cmd.Require(flag.Min, 1)
 
 	cmd.ParseFlags(args, true)
 
+	if *jsonOutput && *format != "" {
+		return fmt.Errorf("--json and --format cannot be combined")
+	}
+
+	var tmpl *template.Template
+	if *format != "" {
+		var err error
+		tmpl, err = template.New("").Funcs(funcMap).Parse(strings.NewReplacer(`\t`, "\t", `\n`, "\n").Replace(*format))
+		if err != nil {
+			return Cli.StatusError{StatusCode: 64,
+				Status: "Template parsing error: " + err.Error()}
+		}
+	}
+
 	c, err := cli.client.ContainerInspect(cmd.Arg(0))
 	if err != nil {
 		return err
 	}
 
+	ports := c.NetworkSettings.Ports
 	if cmd.NArg() == 2 {
 		var (
 			port  = cmd.Arg(1)
@@ -40,20 +70,80 @@ func (cli *DockerCli) CmdPort(args ...string) error {
 		if err != nil {
 			return err
 		}
-		if frontends, exists := c.NetworkSettings.Ports[newP]; exists && frontends != nil {
-			for _, frontend := range frontends {
-				fmt.Fprintf(cli.out, "%s:%s\n", frontend.HostIP, frontend.HostPort)
+		frontends, exists := ports[newP]
+		if tmpl == nil && !*jsonOutput {
+			if exists && frontends != nil {
+				for _, frontend := range frontends {
+					fmt.Fprintf(cli.out, "%s:%s\n", frontend.HostIP, frontend.HostPort)
+				}
+				return nil
 			}
-			return nil
+			return fmt.Errorf("Error: No public port '%s' published for %s", natPort, cmd.Arg(0))
+		}
+		// The port is printed even if it isn't published, as long as the
+		// container exposes it.
+		if !exists {
+			return fmt.Errorf("Error: No public port '%s' published for %s", natPort, cmd.Arg(0))
 		}
-		return fmt.Errorf("Error: No public port '%s' published for %s", natPort, cmd.Arg(0))
+		ports = nat.PortMap{newP: frontends}
 	}
 
-	for from, frontends := range c.NetworkSettings.Ports {
-		for _, frontend := range frontends {
-			fmt.Fprintf(cli.out, "%s -> %s:%s\n", from, frontend.HostIP, frontend.HostPort)
+	entries := portEntries(ports)
+	switch {
+	case *jsonOutput:
+		return json.NewEncoder(cli.out).Encode(entries)
+	case tmpl != nil:
+		for _, entry := range entries {
+			if err := tmpl.Execute(cli.out, entry); err != nil {
+				return Cli.StatusError{StatusCode: 64,
+					Status: "Template parsing error: " + err.Error()}
+			}
+			fmt.Fprintln(cli.out)
+		}
+	default:
+		for _, entry := range entries {
+			if entry.HostPort == "" && entry.HostIP == "" {
+				continue
+			}
+			fmt.Fprintf(cli.out, "%s/%s -> %s:%s\n", entry.ContainerPort, entry.Protocol, entry.HostIP, entry.HostPort)
 		}
 	}
 
 	return nil
 }
+
+// portEntries returns an entry for each host binding of the ports, and one
+// with empty host fields for each port without bindings, sorted by port
+// and protocol.
+func portEntries(ports nat.PortMap) []portEntry {
+	keys := make([]nat.Port, 0, len(ports))
+	for port := range ports {
+		keys = append(keys, port)
+	}
+	sort.Sort(portsByNumber(keys))
+
+	entries := []portEntry{}
+	for _, port := range keys {
+		entry := portEntry{ContainerPort: port.Port(), Protocol: port.Proto()}
+		if len(ports[port]) == 0 {
+			entries = append(entries, entry)
+			continue
+		}
+		for _, binding := range ports[port] {
+			entry.HostIP, entry.HostPort = binding.HostIP, binding.HostPort
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+type portsByNumber []nat.Port
+
+func (p portsByNumber) Len() int      { return len(p) }
+func (p portsByNumber) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p portsByNumber) Less(i, j int) bool {
+	if p[i].Int() != p[j].Int() {
+		return p[i].Int() < p[j].Int()
+	}
+	return p[i].Proto() < p[j].Proto()
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/pkg/nat"
+)
+
+func TestPortEntries(t *testing.T) {
+	ports := nat.PortMap{
+		"8080/tcp": nil,
+		"53/udp":   {{HostIP: "0.0.0.0", HostPort: "32769"}},
+		"53/tcp":   {{HostIP: "0.0.0.0", HostPort: "32768"}, {HostIP: "127.0.0.1", HostPort: "5353"}},
+		"443/tcp":  {},
+	}
+	expected := []portEntry{
+		{ContainerPort: "53", Protocol: "tcp", HostIP: "0.0.0.0", HostPort: "32768"},
+		{ContainerPort: "53", Protocol: "tcp", HostIP: "127.0.0.1", HostPort: "5353"},
+		{ContainerPort: "53", Protocol: "udp", HostIP: "0.0.0.0", HostPort: "32769"},
+		{ContainerPort: "443", Protocol: "tcp"},
+		{ContainerPort: "8080", Protocol: "tcp"},
+	}
+	if entries := portEntries(ports); !reflect.DeepEqual(entries, expected) {
+		t.Fatalf("expected %v, got %v", expected, entries)
+	}
+
+	if entries := portEntries(nil); entries == nil || len(entries) != 0 {
+		t.Fatalf("expected an empty list, got %#v", entries)
+	}
+}
@@ -1,7 +1,11 @@
 package client
 
 import (
-	"github.com/docker/docker/api/client/ps"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/client/formatter"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
 	Cli "github.com/docker/docker/cli"
@@ -21,13 +25,19 @@ func (cli *DockerCli) CmdPs(args ...string) error {
 		cmd      = Cli.Subcmd("ps", nil, Cli.DockerCommands["ps"].Description, true)
 		quiet    = cmd.Bool([]string{"q", "-quiet"}, false, "Only display numeric IDs")
 		size     = cmd.Bool([]string{"s", "-size"}, false, "Display total file sizes")
+		sizeFmt  = cmd.String([]string{"-size-format"}, "split", "Display sizes in split or combined columns")
 		all      = cmd.Bool([]string{"a", "-all"}, false, "Show all containers (default shows just running)")
 		noTrunc  = cmd.Bool([]string{"-no-trunc"}, false, "Don't truncate output")
 		nLatest  = cmd.Bool([]string{"l", "-latest"}, false, "Show the latest created container (includes all states)")
+		perImage = cmd.Bool([]string{"-latest-per-image"}, false, "Show only the latest created container of each image")
 		since    = cmd.String([]string{"#-since"}, "", "Show containers created since Id or Name (includes all states)")
 		before   = cmd.String([]string{"#-before"}, "", "Only show containers created before Id or Name")
 		last     = cmd.Int([]string{"n"}, -1, "Show n last created containers (includes all states)")
 		format   = cmd.String([]string{"-format"}, "", "Pretty-print containers using a Go template")
+		timeFmt  = cmd.String([]string{"-time-format"}, "relative", "Display creation times as relative, rfc3339 or a Go time layout")
+		color    = addColorFlag(cmd)
+		watch    = cmd.Bool([]string{"-watch"}, false, "Refresh the listing until interrupted")
+		interval = cmd.Duration([]string{"-watch-interval"}, 2*time.Second, "Interval between the refreshes of --watch")
 		flFilter = opts.NewListOpts(nil)
 	)
 	cmd.Require(flag.Exact, 0)
@@ -35,9 +45,22 @@ func (cli *DockerCli) CmdPs(args ...string) error {
 	cmd.Var(&flFilter, []string{"f", "-filter"}, "Filter output based on conditions provided")
 
 	cmd.ParseFlags(args, true)
-	if *last == -1 && *nLatest {
+	if *sizeFmt != "split" && *sizeFmt != "combined" {
+		return fmt.Errorf("%q is not a valid value for --size-format", *sizeFmt)
+	}
+	if *last != -1 && *nLatest {
+		return fmt.Errorf("Conflicting options: -n and -l")
+	}
+	if *nLatest {
 		*last = 1
 	}
+	if *interval <= 0 {
+		return fmt.Errorf("%s is not a valid value for --watch-interval, it must be positive", *interval)
+	}
+	useColor, err := colorOutput(*color, cli.isTerminalOut)
+	if err != nil {
+		return err
+	}
 
 	// Consolidate all filter flags, and sanity check them.
 	// They'll get processed in the daemon/server.
@@ -46,6 +69,9 @@ func (cli *DockerCli) CmdPs(args ...string) error {
 			return err
 		}
 	}
+	if err := validateStatusFilter(psFilterArgs); err != nil {
+		return err
+	}
 
 	options := types.ContainerListOptions{
 		All:    *all,
@@ -56,12 +82,10 @@ func (cli *DockerCli) CmdPs(args ...string) error {
 		Filter: psFilterArgs,
 	}
 
-	containers, err := cli.client.ContainerList(options)
-	if err != nil {
-		return err
-	}
-
 	f := *format
+	if len(f) == 0 {
+		f = envFormat("DOCKER_PS_FORMAT", *quiet)
+	}
 	if len(f) == 0 {
 		if len(cli.PsFormat()) > 0 && !*quiet {
 			f = cli.PsFormat()
@@ -70,15 +94,91 @@ func (cli *DockerCli) CmdPs(args ...string) error {
 		}
 	}
 
-	psCtx := ps.Context{
-		Output: cli.out,
-		Format: f,
-		Quiet:  *quiet,
-		Size:   *size,
-		Trunc:  !*noTrunc,
+	ctx, cancel := interruptContext()
+	defer cancel()
+
+	for i := 0; ; i++ {
+		containers, err := cli.client.ContainerListWithContext(ctx, options)
+		if err != nil {
+			if *watch && ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		if *perImage {
+			containers = latestContainerPerImage(containers)
+		}
+
+		if *watch {
+			// Redraw the listing in place on a terminal, separate the
+			// listings otherwise.
+			if cli.isTerminalOut {
+				fmt.Fprint(cli.out, "\033[2J")
+				fmt.Fprint(cli.out, "\033[H")
+			} else if i > 0 {
+				fmt.Fprintln(cli.out, "---")
+			}
+		}
+
+		psCtx := formatter.ContainerContext{
+			Context: formatter.Context{
+				Output:     cli.out,
+				Format:     f,
+				Quiet:      *quiet,
+				Trunc:      !*noTrunc,
+				TimeFormat: *timeFmt,
+				Color:      useColor,
+			},
+			Size:       *size,
+			SplitSize:  *sizeFmt == "split",
+			Containers: containers,
+		}
+
+		psCtx.Write()
+
+		if !*watch {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(*interval):
+		}
 	}
+}
 
-	ps.Format(psCtx, containers)
+// latestContainerPerImage returns the most recently created container of
+// each image, keeping the order of the containers in the list.
+func latestContainerPerImage(containers []types.Container) []types.Container {
+	latest := make(map[string]types.Container)
+	for _, c := range containers {
+		if l, ok := latest[c.ImageID]; !ok || c.Created > l.Created {
+			latest[c.ImageID] = c
+		}
+	}
 
-	return nil
+	var filtered []types.Container
+	for _, c := range containers {
+		if latest[c.ImageID].ID == c.ID {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// containerStatuses lists the values accepted by the status filter.
+var containerStatuses = []string{"created", "restarting", "running", "paused", "exited", "dead"}
+
+// validateStatusFilter checks that every status filter is a known container
+// status. Containers matching any of the statuses are listed.
+func validateStatusFilter(psFilterArgs filters.Args) error {
+	return psFilterArgs.WalkValues("status", func(value string) error {
+		for _, status := range containerStatuses {
+			if value == status {
+				return nil
+			}
+		}
+		return fmt.Errorf("Unrecognised filter value for status: %s (valid statuses are %s)", value, strings.Join(containerStatuses, ", "))
+	})
 }
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+)
+
+func TestValidateStatusFilter(t *testing.T) {
+	args := filters.NewArgs()
+	for _, status := range []string{"running", "paused", "exited"} {
+		args.Add("status", status)
+	}
+	if err := validateStatusFilter(args); err != nil {
+		t.Fatalf("Expected valid statuses, got %v", err)
+	}
+
+	args.Add("status", "stopped")
+	if err := validateStatusFilter(args); err == nil {
+		t.Fatal("Expected an error for an unknown status")
+	}
+}
+
+func TestLatestContainerPerImage(t *testing.T) {
+	containers := []types.Container{
+		{ID: "c4", ImageID: "busybox", Created: 4},
+		{ID: "c3", ImageID: "ubuntu", Created: 3},
+		{ID: "c2", ImageID: "busybox", Created: 2},
+		{ID: "c1", ImageID: "ubuntu", Created: 1},
+		{ID: "c5", ImageID: "alpine", Created: 1},
+	}
+
+	var ids []string
+	for _, c := range latestContainerPerImage(containers) {
+		ids = append(ids, c.ID)
+	}
+	expected := []string{"c4", "c3", "c5"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Fatalf("Expected %v, got %v", expected, ids)
+	}
+}
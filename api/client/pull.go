@@ -1,52 +1,210 @@
 package client
 
 import (
+	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"regexp"
+	"sync"
+	"time"
 
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/distribution/digest"
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/client/lib"
 	"github.com/docker/docker/api/types"
 	Cli "github.com/docker/docker/cli"
+	"github.com/docker/docker/pkg/ansiescape"
+	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/jsonmessage"
 	flag "github.com/docker/docker/pkg/mflag"
 	"github.com/docker/docker/registry"
 	tagpkg "github.com/docker/docker/tag"
 )
 
-var errTagCantBeUsed = errors.New("tag can't be used with --all-tags/-a")
+var (
+	errTagCantBeUsed  = errors.New("tag can't be used with --all-tags/-a")
+	errPinAllTagsUsed = errors.New("--pin can't be used with --all-tags/-a")
+)
+
+// pullDigestRegexp matches the line of the output of a pull which reports
+// the digest of the pulled manifest.
+var pullDigestRegexp = regexp.MustCompile(`Digest: ([\S]+)$`)
 
-// CmdPull pulls an image or a repository from the registry.
+// CmdPull pulls one or more images or repositories from the registry.
 //
-// Usage: docker pull [OPTIONS] IMAGENAME[:TAG|@DIGEST]
+// Usage: docker pull [OPTIONS] IMAGENAME[:TAG|@DIGEST] [IMAGENAME[:TAG|@DIGEST]...]
 func (cli *DockerCli) CmdPull(args ...string) error {
-	cmd := Cli.Subcmd("pull", []string{"NAME[:TAG|@DIGEST]"}, Cli.DockerCommands["pull"].Description, true)
+	cmd := Cli.Subcmd("pull", []string{"NAME[:TAG|@DIGEST] [NAME[:TAG|@DIGEST]...]"}, Cli.DockerCommands["pull"].Description, true)
 	allTags := cmd.Bool([]string{"a", "-all-tags"}, false, "Download all tagged images in the repository")
+	retries := cmd.Int([]string{"-retry"}, 0, "Number of times to retry a pull failing with a transient error")
+	retryDelay := cmd.Duration([]string{"-retry-delay"}, time.Second, "Delay before the first retry, doubled after each attempt")
+	parallel := cmd.Int([]string{"-parallel"}, 1, "Number of images to pull concurrently")
+	pin := cmd.Bool([]string{"-pin"}, false, "Print the digest reference of each pulled image")
 	addTrustedFlags(cmd, true)
-	cmd.Require(flag.Exact, 1)
+	cmd.Require(flag.Min, 1)
 
 	cmd.ParseFlags(args, true)
-	remote := cmd.Arg(0)
 
-	distributionRef, err := reference.ParseNamed(remote)
-	if err != nil {
+	if *retries < 0 {
+		return fmt.Errorf("invalid value for --retry: %d, must not be negative", *retries)
+	}
+	if *parallel < 1 {
+		return fmt.Errorf("invalid value for --parallel: %d, must be at least 1", *parallel)
+	}
+	if *pin && *allTags {
+		return errPinAllTagsUsed
+	}
+
+	// Validate all the references before pulling anything.
+	var refs []reference.Named
+	for _, remote := range cmd.Args() {
+		distributionRef, err := reference.ParseNamed(remote)
+		if err != nil {
+			return err
+		}
+		switch distributionRef.(type) {
+		case reference.Digested, reference.Tagged:
+			if *allTags {
+				return errTagCantBeUsed
+			}
+		}
+		refs = append(refs, distributionRef)
+	}
+
+	pull := func(c *DockerCli, distributionRef reference.Named) error {
+		return c.retryRegistryOperation(*retries, *retryDelay, func() error {
+			return c.pullRepository(distributionRef, *allTags, *pin)
+		})
+	}
+
+	if len(refs) == 1 {
+		return pull(cli, refs[0])
+	}
+
+	var errNames []string
+	if *parallel > 1 {
+		errNames = cli.pullConcurrently(refs, *parallel, pull)
+	} else {
+		for _, distributionRef := range refs {
+			fmt.Fprintf(cli.out, "Pulling %s\n", distributionRef.String())
+			if err := pull(cli, distributionRef); err != nil {
+				fmt.Fprintf(cli.err, "%s\n", err)
+				errNames = append(errNames, distributionRef.String())
+			}
+		}
+	}
+	if len(errNames) > 0 {
+		return fmt.Errorf("Error: failed to pull images: %v", errNames)
+	}
+	return nil
+}
+
+// pullConcurrently pulls the images, with at most parallel pulls at a time,
+// and returns the names of the images which failed to pull. A failed pull
+// doesn't stop the others. Each line of output of a pull is prefixed with the
+// name of its image, and the progress bars are left out, so that the outputs
+// of the pulls can be interleaved.
+func (cli *DockerCli) pullConcurrently(refs []reference.Named, parallel int, pull func(*DockerCli, reference.Named) error) []string {
+	var (
+		mu     sync.Mutex // serializes the lines written by the pulls
+		wg     sync.WaitGroup
+		slots  = make(chan struct{}, parallel)
+		failed = make([]bool, len(refs))
+	)
+	for i, distributionRef := range refs {
+		wg.Add(1)
+		go func(i int, distributionRef reference.Named) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			prefix := distributionRef.String() + ": "
+			out := &prefixWriter{mu: &mu, out: cli.out, prefix: prefix}
+			errOut := &prefixWriter{mu: &mu, out: cli.err, prefix: prefix}
+			c := *cli
+			c.out, c.err, c.isTerminalOut = out, errOut, false
+
+			err := pull(&c, distributionRef)
+			out.Flush()
+			if err != nil {
+				fmt.Fprintf(errOut, "%s\n", err)
+				failed[i] = true
+			}
+			errOut.Flush()
+		}(i, distributionRef)
+	}
+	wg.Wait()
+
+	var errNames []string
+	for i, f := range failed {
+		if f {
+			errNames = append(errNames, refs[i].String())
+		}
+	}
+	return errNames
+}
+
+// prefixWriter writes each line written to it to out, prefixed with prefix.
+// A line is only written once it's complete, holding mu, so that the writers
+// sharing mu don't interleave within lines.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	prefix string
+	buf    bytes.Buffer
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		if err := w.writeLine(w.buf.Next(i + 1)); err != nil {
+			return len(p), err
+		}
+	}
+}
+
+// Flush writes the last line, if it isn't complete.
+func (w *prefixWriter) Flush() error {
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	line := append(w.buf.Bytes(), '\n')
+	w.buf.Reset()
+	return w.writeLine(line)
+}
+
+func (w *prefixWriter) writeLine(line []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := io.WriteString(w.out, w.prefix); err != nil {
 		return err
 	}
+	_, err := w.out.Write(line)
+	return err
+}
 
-	var tag string
+// pullRepository pulls a single image, or all the tagged images of the
+// repository if allTags is set. If pin is set, the digest reference of the
+// image is printed once it's pulled.
+func (cli *DockerCli) pullRepository(distributionRef reference.Named, allTags bool, pin bool) error {
+	var (
+		tag string
+		err error
+	)
 	switch x := distributionRef.(type) {
 	case reference.Digested:
-		if *allTags {
-			return errTagCantBeUsed
-		}
 		tag = x.Digest().String()
 	case reference.Tagged:
-		if *allTags {
-			return errTagCantBeUsed
-		}
 		tag = x.Tag()
 	default:
-		if !*allTags {
+		if !allTags {
 			tag = tagpkg.DefaultTag
 			distributionRef, err = reference.WithTag(distributionRef, tag)
 			if err != nil {
@@ -67,15 +225,82 @@ func (cli *DockerCli) CmdPull(args ...string) error {
 	authConfig := registry.ResolveAuthConfig(cli.configFile.AuthConfigs, repoInfo.Index)
 	requestPrivilege := cli.registryAuthenticationPrivilegedFunc(repoInfo.Index, "pull")
 
-	if isTrusted() && !ref.HasDigest() {
-		// Check if tag is digest
-		return cli.trustedPull(repoInfo, ref, authConfig, requestPrivilege)
+	pull := func(outputStream io.Writer) error {
+		if isTrusted() && !ref.HasDigest() {
+			// Check if tag is digest
+			return cli.trustedPull(repoInfo, ref, authConfig, outputStream, requestPrivilege)
+		}
+		return cli.imagePullPrivileged(authConfig, distributionRef.String(), "", outputStream, requestPrivilege)
+	}
+	if !pin {
+		return pull(cli.out)
+	}
+	return cli.pullPinned(distributionRef, pull)
+}
+
+// pullPinned pulls an image and prints its digest reference, NAME@DIGEST.
+// The digest is the one the daemon reports in the output of the pull, or the
+// one the image was pulled by.
+func (cli *DockerCli) pullPinned(distributionRef reference.Named, pull func(io.Writer) error) error {
+	streamOut, digestChan := digestStream(cli.out)
+	pullErr := pull(streamOut)
+
+	// Close stream channel to finish digest parsing
+	if err := streamOut.Close(); err != nil {
+		return err
+	}
+	digests := <-digestChan
+	if pullErr != nil {
+		return pullErr
+	}
+
+	var dgst digest.Digest
+	if digested, ok := distributionRef.(reference.Digested); ok {
+		dgst = digested.Digest()
+	} else if len(digests) > 0 {
+		dgst = digests[len(digests)-1]
+	} else {
+		return fmt.Errorf("Error: no digest was reported for %s, the registry may not support digests", distributionRef.String())
+	}
+	pinned, err := reference.WithDigest(distributionRef, dgst)
+	if err != nil {
+		return err
 	}
+	fmt.Fprintln(cli.out, pinned.String())
+	return nil
+}
+
+// digestStream returns a writer which copies everything to in, and a channel
+// receiving the digests reported in the output of a pull once the writer is
+// closed.
+func digestStream(in io.Writer) (io.WriteCloser, <-chan []digest.Digest) {
+	r, w := io.Pipe()
+	out := io.MultiWriter(in, w)
+	digestChan := make(chan []digest.Digest, 1)
+
+	go func() {
+		var digests []digest.Digest
+		scanner := bufio.NewScanner(r)
+		scanner.Split(ansiescape.ScanANSILines)
+		for scanner.Scan() {
+			matches := pullDigestRegexp.FindSubmatch(scanner.Bytes())
+			if len(matches) != 2 {
+				continue
+			}
+			dgst, err := digest.ParseDigest(string(matches[1]))
+			if err != nil {
+				logrus.Debugf("Bad digest value %q in matched line, ignoring\n", string(matches[1]))
+				continue
+			}
+			digests = append(digests, dgst)
+		}
+		digestChan <- digests
+	}()
 
-	return cli.imagePullPrivileged(authConfig, distributionRef.String(), "", requestPrivilege)
+	return ioutils.NewWriteCloserWrapper(out, w.Close), digestChan
 }
 
-func (cli *DockerCli) imagePullPrivileged(authConfig types.AuthConfig, imageID, tag string, requestPrivilege lib.RequestPrivilegeFunc) error {
+func (cli *DockerCli) imagePullPrivileged(authConfig types.AuthConfig, imageID, tag string, outputStream io.Writer, requestPrivilege lib.RequestPrivilegeFunc) error {
 
 	encodedAuth, err := encodeAuthToBase64(authConfig)
 	if err != nil {
@@ -93,5 +318,5 @@ func (cli *DockerCli) imagePullPrivileged(authConfig types.AuthConfig, imageID,
 	}
 	defer responseBody.Close()
 
-	return jsonmessage.DisplayJSONMessagesStream(responseBody, cli.out, cli.outFd, cli.isTerminalOut)
+	return jsonmessage.DisplayJSONMessagesStream(responseBody, outputStream, cli.outFd, cli.isTerminalOut)
 }
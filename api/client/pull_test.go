@@ -0,0 +1,143 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/docker/distribution/reference"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		out = new(bytes.Buffer)
+		w   = &prefixWriter{mu: &mu, out: out, prefix: "busybox: "}
+	)
+	fmt.Fprint(w, "latest: Pulling from library/busybox\nDigest: ")
+	if out.String() != "busybox: latest: Pulling from library/busybox\n" {
+		t.Fatalf("Expected only the complete line to be written, got %q", out.String())
+	}
+	fmt.Fprint(w, "sha256:abc\nStatus")
+	w.Flush()
+	expected := "busybox: latest: Pulling from library/busybox\nbusybox: Digest: sha256:abc\nbusybox: Status\n"
+	if out.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestPullConcurrently(t *testing.T) {
+	var refs []reference.Named
+	for _, name := range []string{"busybox", "ubuntu", "missing", "alpine"} {
+		ref, err := reference.ParseNamed(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		refs = append(refs, ref)
+	}
+
+	var (
+		mu               sync.Mutex
+		running, maxSeen int
+		stdout, stderr   = new(bytes.Buffer), new(bytes.Buffer)
+		cli              = &DockerCli{out: stdout, err: stderr, isTerminalOut: true}
+	)
+	pull := func(c *DockerCli, ref reference.Named) error {
+		mu.Lock()
+		running++
+		if running > maxSeen {
+			maxSeen = running
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+
+		if c.isTerminalOut {
+			t.Errorf("Expected the pull of %s not to write to a terminal", ref)
+		}
+		if ref.String() == "missing" {
+			return fmt.Errorf("Error: image library/missing not found")
+		}
+		fmt.Fprintf(c.out, "Status: Downloaded newer image for %s\n", ref)
+		return nil
+	}
+
+	errNames := cli.pullConcurrently(refs, 2, pull)
+	if !reflect.DeepEqual(errNames, []string{"missing"}) {
+		t.Fatalf("Expected only missing to fail, got %v", errNames)
+	}
+	if maxSeen > 2 {
+		t.Fatalf("Expected at most 2 concurrent pulls, got %d", maxSeen)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	sort.Strings(lines)
+	expected := []string{
+		"alpine: Status: Downloaded newer image for alpine",
+		"busybox: Status: Downloaded newer image for busybox",
+		"ubuntu: Status: Downloaded newer image for ubuntu",
+	}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Fatalf("Expected the prefixed lines %v, got %v", expected, lines)
+	}
+	if stderr.String() != "missing: Error: image library/missing not found\n" {
+		t.Fatalf("Expected the error of missing, got %q", stderr.String())
+	}
+}
+
+func TestPullPinned(t *testing.T) {
+	const (
+		dgst  = "sha256:4a5573037f358b6cdfa2f3e8a9c33a5cf11bcd1675ca72ca76fbe5bd77d0d682"
+		other = "sha256:cbbf2f9a99b47fc460d422812b6a5adff7dfee951d8fa2e4a98caa0382cfbdbf"
+	)
+	pullOutput := func(output string) func(io.Writer) error {
+		return func(w io.Writer) error {
+			_, err := io.WriteString(w, output)
+			return err
+		}
+	}
+	cases := []struct {
+		ref      string
+		output   string
+		expected string
+	}{
+		{"ubuntu:latest", "latest: Pulling from library/ubuntu\nDigest: " + dgst + "\nStatus: Downloaded newer image for ubuntu:latest\n", "ubuntu@" + dgst},
+		{"ubuntu@" + other, "Digest: " + other + "\n", "ubuntu@" + other},
+		{"localhost:5000/app:v1", "\x1b[1A\x1b[2K\rDigest: " + dgst + "\n", "localhost:5000/app@" + dgst},
+	}
+	for _, c := range cases {
+		ref, err := reference.ParseNamed(c.ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := new(bytes.Buffer)
+		cli := &DockerCli{out: out}
+		if err := cli.pullPinned(ref, pullOutput(c.output)); err != nil {
+			t.Fatalf("Expected %s to be pinned, got %v", c.ref, err)
+		}
+		if !strings.HasPrefix(out.String(), c.output) {
+			t.Fatalf("Expected the output of the pull to be printed, got %q", out.String())
+		}
+		if pinned := strings.TrimPrefix(out.String(), c.output); pinned != c.expected+"\n" {
+			t.Fatalf("Expected %s to be pinned as %s, got %q", c.ref, c.expected, pinned)
+		}
+	}
+
+	ref, _ := reference.ParseNamed("ubuntu:latest")
+	cli := &DockerCli{out: new(bytes.Buffer)}
+	if err := cli.pullPinned(ref, pullOutput("Status: Downloaded newer image for ubuntu:latest\n")); err == nil {
+		t.Fatal("Expected an error without a digest in the output")
+	}
+	pullErr := fmt.Errorf("Error: image library/ubuntu not found")
+	if err := cli.pullPinned(ref, func(io.Writer) error { return pullErr }); err != pullErr {
+		t.Fatalf("Expected the error of the pull, got %v", err)
+	}
+}
@@ -2,7 +2,11 @@ package client
 
 import (
 	"errors"
+	"fmt"
 	"io"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/client/lib"
@@ -15,14 +19,21 @@ import (
 
 // CmdPush pushes an image or repository to the registry.
 //
-// Usage: docker push NAME[:TAG]
+// Usage: docker push [OPTIONS] NAME[:TAG]
 func (cli *DockerCli) CmdPush(args ...string) error {
 	cmd := Cli.Subcmd("push", []string{"NAME[:TAG]"}, Cli.DockerCommands["push"].Description, true)
+	allTags := cmd.Bool([]string{"a", "-all-tags"}, false, "Push all the local tags of the repository one by one")
+	retries := cmd.Int([]string{"-retry"}, 0, "Number of times to retry a push failing with a transient error")
+	retryDelay := cmd.Duration([]string{"-retry-delay"}, time.Second, "Delay before the first retry, doubled after each attempt")
 	addTrustedFlags(cmd, false)
 	cmd.Require(flag.Exact, 1)
 
 	cmd.ParseFlags(args, true)
 
+	if *retries < 0 {
+		return fmt.Errorf("invalid value for --retry: %d, must not be negative", *retries)
+	}
+
 	ref, err := reference.ParseNamed(cmd.Arg(0))
 	if err != nil {
 		return err
@@ -31,8 +42,14 @@ func (cli *DockerCli) CmdPush(args ...string) error {
 	var tag string
 	switch x := ref.(type) {
 	case reference.Digested:
+		if *allTags {
+			return errTagCantBeUsed
+		}
 		return errors.New("cannot push a digest reference")
 	case reference.Tagged:
+		if *allTags {
+			return errTagCantBeUsed
+		}
 		tag = x.Tag()
 	}
 
@@ -45,11 +62,79 @@ func (cli *DockerCli) CmdPush(args ...string) error {
 	authConfig := registry.ResolveAuthConfig(cli.configFile.AuthConfigs, repoInfo.Index)
 
 	requestPrivilege := cli.registryAuthenticationPrivilegedFunc(repoInfo.Index, "push")
+
+	push := func(tag string) error {
+		return cli.retryRegistryOperation(*retries, *retryDelay, func() error {
+			return cli.pushTag(repoInfo, ref.Name(), tag, authConfig, requestPrivilege)
+		})
+	}
+
+	if !*allTags {
+		return push(tag)
+	}
+
+	tags, err := cli.localTags(ref.Name())
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return fmt.Errorf("No tags found for repository %s", ref.Name())
+	}
+	fmt.Fprintf(cli.out, "Pushing %d tags of %s: %s\n", len(tags), ref.Name(), strings.Join(tags, ", "))
+
+	var errTags []string
+	for _, tag := range tags {
+		if err := push(tag); err != nil {
+			fmt.Fprintf(cli.err, "%s\n", err)
+			errTags = append(errTags, tag)
+		}
+	}
+	if len(errTags) > 0 {
+		return fmt.Errorf("Error: failed to push tags of %s: %v", ref.Name(), errTags)
+	}
+	return nil
+}
+
+// pushTag pushes a single tag of a repository, or all of them if tag is
+// empty, and signs it if content trust is enabled.
+func (cli *DockerCli) pushTag(repoInfo *registry.RepositoryInfo, name, tag string, authConfig types.AuthConfig, requestPrivilege lib.RequestPrivilegeFunc) error {
 	if isTrusted() {
 		return cli.trustedPush(repoInfo, tag, authConfig, requestPrivilege)
 	}
+	return cli.imagePushPrivileged(authConfig, name, tag, cli.out, requestPrivilege)
+}
 
-	return cli.imagePushPrivileged(authConfig, ref.Name(), tag, cli.out, requestPrivilege)
+// localTags returns the sorted tags of the local images of the repository
+// with the given name.
+func (cli *DockerCli) localTags(name string) ([]string, error) {
+	images, err := cli.client.ImageList(types.ImageListOptions{MatchName: name})
+	if err != nil {
+		return nil, err
+	}
+	return repositoryTags(images, name), nil
+}
+
+// repositoryTags returns the sorted tags the images are known by in the
+// repository with the given name.
+func repositoryTags(images []types.Image, name string) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, image := range images {
+		for _, repoTag := range image.RepoTags {
+			ref, err := reference.ParseNamed(repoTag)
+			if err != nil || ref.Name() != name {
+				continue
+			}
+			tagged, ok := ref.(reference.NamedTagged)
+			if !ok || seen[tagged.Tag()] {
+				continue
+			}
+			seen[tagged.Tag()] = true
+			tags = append(tags, tagged.Tag())
+		}
+	}
+	sort.Strings(tags)
+	return tags
 }
 
 func (cli *DockerCli) imagePushPrivileged(authConfig types.AuthConfig, imageID, tag string, outputStream io.Writer, requestPrivilege lib.RequestPrivilegeFunc) error {
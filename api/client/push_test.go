@@ -0,0 +1,32 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestRepositoryTags(t *testing.T) {
+	images := []types.Image{
+		{RepoTags: []string{"busybox:latest", "busybox:1.24", "mybusybox:latest"}},
+		{RepoTags: []string{"<none>:<none>"}},
+		{RepoTags: []string{"busybox:1.23", "localhost:5000/busybox:1.22", "busybox:latest"}},
+	}
+
+	tags := repositoryTags(images, "busybox")
+	expected := []string{"1.23", "1.24", "latest"}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Fatalf("Expected %v, got %v", expected, tags)
+	}
+
+	tags = repositoryTags(images, "localhost:5000/busybox")
+	expected = []string{"1.22"}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Fatalf("Expected %v, got %v", expected, tags)
+	}
+
+	if tags := repositoryTags(images, "alpine"); len(tags) != 0 {
+		t.Fatalf("Expected no tags, got %v", tags)
+	}
+}
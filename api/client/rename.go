@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
 	Cli "github.com/docker/docker/cli"
 	flag "github.com/docker/docker/pkg/mflag"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/utils"
 )
 
 // CmdRename renames a container.
@@ -17,12 +21,31 @@ func (cli *DockerCli) CmdRename(args ...string) error {
 
 	cmd.ParseFlags(args, true)
 
-	oldName := strings.TrimSpace(cmd.Arg(0))
-	newName := strings.TrimSpace(cmd.Arg(1))
+	oldName := strings.TrimPrefix(strings.TrimSpace(cmd.Arg(0)), "/")
+	newName := strings.TrimPrefix(strings.TrimSpace(cmd.Arg(1)), "/")
 
 	if oldName == "" || newName == "" {
 		return fmt.Errorf("Error: Neither old nor new names may be empty")
 	}
+	if !utils.RestrictedNamePattern.MatchString(newName) {
+		return fmt.Errorf("Error: Invalid container name (%s), only %s are allowed", newName, utils.RestrictedNameChars)
+	}
+	// Nothing to do, the container already has the name.
+	if oldName == newName {
+		return nil
+	}
+
+	owner, err := cli.containerWithName(newName)
+	if err != nil {
+		return err
+	}
+	if owner != nil {
+		// The container was given by ID and already has the name.
+		if strings.HasPrefix(owner.ID, oldName) {
+			return nil
+		}
+		return fmt.Errorf("Error: name %q is already in use by container %s", newName, stringid.TruncateID(owner.ID))
+	}
 
 	if err := cli.client.ContainerRename(oldName, newName); err != nil {
 		fmt.Fprintf(cli.err, "%s\n", err)
@@ -30,3 +53,23 @@ func (cli *DockerCli) CmdRename(args ...string) error {
 	}
 	return nil
 }
+
+// containerWithName returns the container with the given name, or nil if no
+// container has it.
+func (cli *DockerCli) containerWithName(name string) (*types.Container, error) {
+	// The name filter matches names partially, the names are compared below.
+	nameFilter := filters.NewArgs()
+	nameFilter.Add("name", name)
+	containers, err := cli.client.ContainerList(types.ContainerListOptions{All: true, Filter: nameFilter})
+	if err != nil {
+		return nil, err
+	}
+	for i, c := range containers {
+		for _, n := range c.Names {
+			if n == "/"+name {
+				return &containers[i], nil
+			}
+		}
+	}
+	return nil, nil
+}
@@ -4,7 +4,7 @@ import (
 	"fmt"
 
 	Cli "github.com/docker/docker/cli"
-	flag "github.com/docker/docker/pkg/mflag"
+	"github.com/docker/docker/opts"
 )
 
 // CmdRestart restarts one or more containers.
@@ -13,12 +13,25 @@ import (
 func (cli *DockerCli) CmdRestart(args ...string) error {
 	cmd := Cli.Subcmd("restart", []string{"CONTAINER [CONTAINER...]"}, Cli.DockerCommands["restart"].Description, true)
 	nSeconds := cmd.Int([]string{"t", "-time"}, 10, "Seconds to wait for stop before killing the container")
-	cmd.Require(flag.Min, 1)
+	flFilter := opts.NewListOpts(nil)
+	cmd.Var(&flFilter, []string{"-filter"}, "Restart the containers matching the filter")
 
 	cmd.ParseFlags(args, true)
 
+	// At least one container has to be given, unless containers are selected
+	// with filters.
+	requireArgsOrFilter(cmd, flFilter)
+
+	names := cmd.Args()
+	if flFilter.Len() > 0 {
+		var err error
+		if names, err = cli.appendFilteredContainers(names, flFilter); err != nil {
+			return err
+		}
+	}
+
 	var errNames []string
-	for _, name := range cmd.Args() {
+	for _, name := range names {
 		if err := cli.client.ContainerRestart(name, *nSeconds); err != nil {
 			fmt.Fprintf(cli.err, "%s\n", err)
 			errNames = append(errNames, name)
@@ -26,6 +39,9 @@ func (cli *DockerCli) CmdRestart(args ...string) error {
 			fmt.Fprintf(cli.out, "%s\n", name)
 		}
 	}
+	if flFilter.Len() > 0 {
+		fmt.Fprintf(cli.out, "Restarted %d containers\n", len(names)-len(errNames))
+	}
 	if len(errNames) > 0 {
 		return fmt.Errorf("Error: failed to restart containers: %v", errNames)
 	}
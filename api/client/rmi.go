@@ -3,10 +3,16 @@ package client
 import (
 	"fmt"
 	"net/url"
+	"strings"
 
+	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
 	Cli "github.com/docker/docker/cli"
-	flag "github.com/docker/docker/pkg/mflag"
+	"github.com/docker/docker/opts"
+	"github.com/docker/docker/pkg/units"
+	tagpkg "github.com/docker/docker/tag"
+	"golang.org/x/net/context"
 )
 
 // CmdRmi removes all images with the specified name(s).
@@ -16,10 +22,48 @@ func (cli *DockerCli) CmdRmi(args ...string) error {
 	cmd := Cli.Subcmd("rmi", []string{"IMAGE [IMAGE...]"}, Cli.DockerCommands["rmi"].Description, true)
 	force := cmd.Bool([]string{"f", "-force"}, false, "Force removal of the image")
 	noprune := cmd.Bool([]string{"-no-prune"}, false, "Do not delete untagged parents")
-	cmd.Require(flag.Min, 1)
+	dryRun := cmd.Bool([]string{"-dry-run"}, false, "Show what would be untagged or deleted without removing anything")
+	all := cmd.Bool([]string{"-all"}, false, "Also remove the dangling images left once the images are removed")
+	flFilter := opts.NewListOpts(nil)
+	cmd.Var(&flFilter, []string{"-filter"}, "Remove the images matching the filter")
 
 	cmd.ParseFlags(args, true)
 
+	// At least one image has to be given, unless images are selected with
+	// filters.
+	requireArgsOrFilter(cmd, flFilter)
+
+	if *all && *noprune {
+		return fmt.Errorf("--all and --no-prune cannot be combined")
+	}
+
+	names := cmd.Args()
+	if flFilter.Len() > 0 {
+		filterArgs := filters.NewArgs()
+		for _, f := range flFilter.GetAll() {
+			var err error
+			if filterArgs, err = filters.ParseFlag(f, filterArgs); err != nil {
+				return err
+			}
+		}
+		images, err := cli.listImages(context.Background(), types.ImageListOptions{Filters: filterArgs})
+		if err != nil {
+			return err
+		}
+		names = append(names, imageIDs(images)...)
+	}
+
+	var sizes map[string]int64
+	if flFilter.Len() > 0 || *all {
+		// The sizes of all the images, to tell how much space the deleted
+		// images and their pruned parents reclaimed.
+		images, err := cli.client.ImageList(types.ImageListOptions{All: true})
+		if err != nil {
+			return err
+		}
+		sizes = imageOwnSizes(images)
+	}
+
 	v := url.Values{}
 	if *force {
 		v.Set("force", "1")
@@ -28,26 +72,84 @@ func (cli *DockerCli) CmdRmi(args ...string) error {
 		v.Set("noprune", "1")
 	}
 
-	var errNames []string
-	for _, name := range cmd.Args() {
-		options := types.ImageRemoveOptions{
-			ImageID:       name,
-			Force:         *force,
-			PruneChildren: !*noprune,
-		}
+	untaggedPrefix, deletedPrefix := "Untagged:", "Deleted:"
+	if *dryRun {
+		untaggedPrefix, deletedPrefix = "Would untag:", "Would delete:"
+	}
 
-		dels, err := cli.client.ImageRemove(options)
+	var (
+		errNames  []string
+		isDeleted = make(map[string]bool)
+		// The references a dry run reported as untagged, which the
+		// following images to remove don't hold anymore.
+		isUntagged = make(map[string]bool)
+		deleted    int
+		reclaimed  int64
+	)
+	remove := func(name string, options types.ImageRemoveOptions) {
+		var (
+			dels []types.ImageDelete
+			err  error
+		)
+		if *dryRun {
+			dels, err = cli.imageRemoveTargets(options.ImageID, isUntagged)
+		} else {
+			dels, err = cli.client.ImageRemove(options)
+		}
 		if err != nil {
 			fmt.Fprintf(cli.err, "%s\n", err)
 			errNames = append(errNames, name)
-		} else {
-			for _, del := range dels {
-				if del.Deleted != "" {
-					fmt.Fprintf(cli.out, "Deleted: %s\n", del.Deleted)
-				} else {
-					fmt.Fprintf(cli.out, "Untagged: %s\n", del.Untagged)
+			return
+		}
+		for _, del := range dels {
+			if del.Deleted != "" {
+				fmt.Fprintf(cli.out, "%s %s\n", deletedPrefix, del.Deleted)
+				isDeleted[del.Deleted] = true
+				if size, ok := sizes[del.Deleted]; ok {
+					deleted++
+					reclaimed += size
 				}
+			} else {
+				fmt.Fprintf(cli.out, "%s %s\n", untaggedPrefix, del.Untagged)
+				isUntagged[del.Untagged] = true
+			}
+		}
+	}
+
+	for _, name := range names {
+		remove(name, types.ImageRemoveOptions{
+			ImageID:       name,
+			Force:         *force,
+			PruneChildren: !*noprune,
+		})
+	}
+
+	if *all {
+		// Once the images are removed, the images left dangling are removed
+		// too, the same as `docker rmi $(docker images -qf dangling=true)`.
+		danglingArgs := filters.NewArgs()
+		danglingArgs.Add("dangling", "true")
+		images, err := cli.client.ImageList(types.ImageListOptions{Filters: danglingArgs})
+		if err != nil {
+			return err
+		}
+		containers, err := cli.client.ContainerList(types.ContainerListOptions{All: true})
+		if err != nil {
+			return err
+		}
+		for _, id := range danglingImageIDs(images, containers) {
+			if isDeleted[id] {
+				continue
 			}
+			// Removing a dangling image prunes its untagged parents.
+			remove(id, types.ImageRemoveOptions{ImageID: id, PruneChildren: true})
+		}
+	}
+	if sizes != nil {
+		if *dryRun {
+			fmt.Fprintf(cli.out, "Would remove %d images, reclaiming %s\n", deleted, units.HumanSize(float64(reclaimed)))
+		} else {
+			fmt.Fprintf(cli.out, "Removed %d images, reclaimed %s\n", deleted, units.HumanSize(float64(reclaimed)))
 		}
 	}
 	if len(errNames) > 0 {
@@ -55,3 +157,114 @@ func (cli *DockerCli) CmdRmi(args ...string) error {
 	}
 	return nil
 }
+
+// imageIDs returns the IDs of the images, without duplicates.
+func imageIDs(images []types.Image) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, image := range images {
+		if !seen[image.ID] {
+			seen[image.ID] = true
+			ids = append(ids, image.ID)
+		}
+	}
+	return ids
+}
+
+// imageOwnSizes returns the size each image adds to its parent, that is the
+// space deleting the image alone would reclaim. The size of an image includes
+// the size of its parents.
+func imageOwnSizes(images []types.Image) map[string]int64 {
+	sizes := make(map[string]int64, len(images))
+	for _, image := range images {
+		sizes[image.ID] = image.Size
+	}
+	own := make(map[string]int64, len(images))
+	for _, image := range images {
+		own[image.ID] = image.Size
+		if parentSize, ok := sizes[image.ParentID]; ok && parentSize <= image.Size {
+			own[image.ID] = image.Size - parentSize
+		}
+	}
+	return own
+}
+
+// danglingImageIDs returns the IDs of the dangling images which aren't used by
+// any of the containers, even a stopped one.
+func danglingImageIDs(images []types.Image, containers []types.Container) []string {
+	used := make(map[string]bool, len(containers))
+	for _, c := range containers {
+		used[c.ImageID] = true
+	}
+	var ids []string
+	for _, id := range imageIDs(images) {
+		if !used[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// imageRemoveTargets resolves the image to remove the same way the daemon
+// does and returns the references and the image which removing it targets,
+// for `docker rmi --dry-run`.
+func (cli *DockerCli) imageRemoveTargets(imageRef string, untagged map[string]bool) ([]types.ImageDelete, error) {
+	image, _, err := cli.client.ImageInspectWithRaw(imageRef, false)
+	if err != nil {
+		return nil, err
+	}
+	return imageRemoveTargets(imageRef, image, untagged)
+}
+
+// imageRemoveTargets returns what removing the image referred to by imageRef
+// targets: the given reference, or all of them if it's an image ID, and the
+// image itself once it has no references left. The references in untagged
+// are already removed. The daemon's conflicts and the pruning of the parents
+// aren't checked, they're left to the daemon.
+func imageRemoveTargets(imageRef string, image types.ImageInspect, untagged map[string]bool) ([]types.ImageDelete, error) {
+	var refs []string
+	for _, ref := range append(append([]string{}, image.RepoTags...), image.RepoDigests...) {
+		if !strings.HasPrefix(ref, "<none>") && !untagged[ref] {
+			refs = append(refs, ref)
+		}
+	}
+
+	records := []types.ImageDelete{}
+	if isImageIDPrefix(image.ID, imageRef) {
+		for _, ref := range refs {
+			records = append(records, types.ImageDelete{Untagged: ref})
+		}
+		return append(records, types.ImageDelete{Deleted: image.ID}), nil
+	}
+
+	parsedRef, err := reference.ParseNamed(imageRef)
+	if err != nil {
+		return nil, err
+	}
+	switch parsedRef.(type) {
+	case reference.Tagged, reference.Digested:
+	default:
+		if parsedRef, err = reference.WithTag(parsedRef, tagpkg.DefaultTag); err != nil {
+			return nil, err
+		}
+	}
+	records = append(records, types.ImageDelete{Untagged: parsedRef.String()})
+	if len(refs) > 1 {
+		return records, nil
+	}
+	return append(records, types.ImageDelete{Deleted: image.ID}), nil
+}
+
+// isImageIDPrefix returns whether the given possiblePrefix is a prefix of the
+// given imageID.
+func isImageIDPrefix(imageID, possiblePrefix string) bool {
+	if strings.HasPrefix(imageID, possiblePrefix) {
+		return true
+	}
+
+	if i := strings.IndexRune(imageID, ':'); i >= 0 {
+		return strings.HasPrefix(imageID[i+1:], possiblePrefix)
+	}
+
+	return false
+}
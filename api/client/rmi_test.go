@@ -0,0 +1,69 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+const (
+	testBaseImageID   = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	testMiddleImageID = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+	testAppImageID    = "sha256:3333333333333333333333333333333333333333333333333333333333333333"
+)
+
+func TestImageRemoveTargets(t *testing.T) {
+	image := types.ImageInspect{ID: testAppImageID, RepoTags: []string{"app:1", "app:latest"}, RepoDigests: []string{"<none>@<none>"}}
+	cases := []struct {
+		imageRef string
+		image    types.ImageInspect
+		untagged map[string]bool
+		expected []types.ImageDelete
+	}{
+		{"app:1", image, nil, []types.ImageDelete{{Untagged: "app:1"}}},
+		{"app", image, nil, []types.ImageDelete{{Untagged: "app:latest"}}},
+		{"app", image, map[string]bool{"app:1": true}, []types.ImageDelete{{Untagged: "app:latest"}, {Deleted: testAppImageID}}},
+		{"3333", image, nil, []types.ImageDelete{{Untagged: "app:1"}, {Untagged: "app:latest"}, {Deleted: testAppImageID}}},
+		{"busybox", types.ImageInspect{ID: testBaseImageID, RepoTags: []string{"busybox:latest"}}, nil, []types.ImageDelete{{Untagged: "busybox:latest"}, {Deleted: testBaseImageID}}},
+		{"2222", types.ImageInspect{ID: testMiddleImageID, RepoTags: []string{"<none>:<none>"}}, nil, []types.ImageDelete{{Deleted: testMiddleImageID}}},
+	}
+	for _, c := range cases {
+		records, err := imageRemoveTargets(c.imageRef, c.image, c.untagged)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(records, c.expected) {
+			t.Fatalf("%s: expected %v, got %v", c.imageRef, c.expected, records)
+		}
+	}
+}
+
+func TestImageOwnSizes(t *testing.T) {
+	images := []types.Image{
+		{ID: testBaseImageID, Size: 100},
+		{ID: testMiddleImageID, ParentID: testBaseImageID, Size: 150},
+		{ID: testAppImageID, ParentID: "sha256:unknown", Size: 80},
+	}
+	expected := map[string]int64{testBaseImageID: 100, testMiddleImageID: 50, testAppImageID: 80}
+	if sizes := imageOwnSizes(images); !reflect.DeepEqual(sizes, expected) {
+		t.Fatalf("Expected %v, got %v", expected, sizes)
+	}
+}
+
+func TestImageIDs(t *testing.T) {
+	images := []types.Image{{ID: testAppImageID}, {ID: testBaseImageID}, {ID: testAppImageID}}
+	expected := []string{testAppImageID, testBaseImageID}
+	if ids := imageIDs(images); !reflect.DeepEqual(ids, expected) {
+		t.Fatalf("Expected %v, got %v", expected, ids)
+	}
+}
+
+func TestDanglingImageIDs(t *testing.T) {
+	images := []types.Image{{ID: testAppImageID}, {ID: testMiddleImageID}, {ID: testBaseImageID}}
+	containers := []types.Container{{ID: "abcdef0123456789", ImageID: testMiddleImageID, Status: "Exited (0) 5 minutes ago"}}
+	expected := []string{testAppImageID, testBaseImageID}
+	if ids := danglingImageIDs(images, containers); !reflect.DeepEqual(ids, expected) {
+		t.Fatalf("Expected %v, got %v", expected, ids)
+	}
+}
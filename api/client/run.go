@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"runtime"
 	"strings"
 
@@ -70,11 +71,14 @@ func (cli *DockerCli) CmdRun(args ...string) error {
 
 	// These are flags not stored in Config/HostConfig
 	var (
-		flAutoRemove = cmd.Bool([]string{"-rm"}, false, "Automatically remove the container when it exits")
-		flDetach     = cmd.Bool([]string{"d", "-detach"}, false, "Run container in background and print container ID")
-		flSigProxy   = cmd.Bool([]string{"-sig-proxy"}, true, "Proxy received signals to the process")
-		flName       = cmd.String([]string{"-name"}, "", "Assign a name to the container")
-		flAttach     *opts.ListOpts
+		flAutoRemove   = cmd.Bool([]string{"-rm"}, false, "Automatically remove the container when it exits")
+		flDetach       = cmd.Bool([]string{"d", "-detach"}, false, "Run container in background and print container ID")
+		flSigProxy     = cmd.Bool([]string{"-sig-proxy"}, true, "Proxy received signals to the process")
+		flName         = cmd.String([]string{"-name"}, "", "Assign a name to the container")
+		flPull         = addPullFlag(cmd)
+		flNameTemplate = addNameTemplateFlag(cmd)
+		flQuiet        = cmd.Bool([]string{"-quiet"}, false, "Don't warn about a shell run without -i")
+		flAttach       *opts.ListOpts
 
 		ErrConflictAttachDetach               = fmt.Errorf("Conflicting options: -a and -d")
 		ErrConflictRestartPolicyAndAutoRemove = fmt.Errorf("Conflicting options: --restart and --rm")
@@ -108,6 +112,14 @@ func (cli *DockerCli) CmdRun(args ...string) error {
 		return nil
 	}
 
+	if err := validatePullPolicy(*flPull); err != nil {
+		return err
+	}
+	name, err := cli.containerName(*flName, *flNameTemplate)
+	if err != nil {
+		return err
+	}
+
 	config.ArgsEscaped = false
 
 	if !*flDetach {
@@ -144,11 +156,16 @@ func (cli *DockerCli) CmdRun(args ...string) error {
 		hostConfig.ConsoleSize[0], hostConfig.ConsoleSize[1] = cli.getTtySize()
 	}
 
-	createResponse, err := cli.createContainer(config, hostConfig, hostConfig.ContainerIDFile, *flName)
+	createResponse, err := cli.createContainer(config, hostConfig, hostConfig.ContainerIDFile, name, *flPull)
 	if err != nil {
 		cmd.ReportError(err.Error(), true)
 		return runStartContainerErr(err)
 	}
+	// The check costs an image inspection, it's skipped for detached
+	// containers, which are usually left running on purpose.
+	if !*flQuiet && !*flDetach && !config.OpenStdin && config.Cmd.Len() == 0 && config.Entrypoint.Len() == 0 {
+		cli.warnShellWithoutStdin(config.Image)
+	}
 	if sigProxy {
 		sigc := cli.forwardAllSignals(createResponse.ID)
 		defer signal.StopCatch(sigc)
@@ -275,3 +292,44 @@ func (cli *DockerCli) CmdRun(args ...string) error {
 	}
 	return nil
 }
+
+// interactiveShells are the shells which exit immediately, when they're run
+// as the command of a container, unless its stdin is open.
+var interactiveShells = map[string]bool{
+	"sh":   true,
+	"ash":  true,
+	"bash": true,
+	"dash": true,
+	"ksh":  true,
+	"zsh":  true,
+	"csh":  true,
+	"tcsh": true,
+	"fish": true,
+}
+
+// warnShellWithoutStdin warns if the default command of the image is an
+// interactive shell, as the container exits immediately without -i.
+func (cli *DockerCli) warnShellWithoutStdin(image string) {
+	img, _, err := cli.client.ImageInspectWithRaw(image, false)
+	if err != nil || img.Config == nil {
+		logrus.Debugf("Failed to inspect the image %s: %v", image, err)
+		return
+	}
+	if img.Config.Entrypoint.Len() == 0 && isInteractiveShell(img.Config.Cmd.Slice()) {
+		fmt.Fprintf(cli.err, "WARNING: The default command of %s is a shell (%s), which exits immediately without -i. Use -it to run it interactively.\n", image, img.Config.Cmd.ToString())
+	}
+}
+
+// isInteractiveShell returns true if the command runs a shell without a
+// script or command to execute, e.g. "/bin/bash" or "sh -l".
+func isInteractiveShell(command []string) bool {
+	if len(command) == 0 || !interactiveShells[path.Base(command[0])] {
+		return false
+	}
+	for _, arg := range command[1:] {
+		if !strings.HasPrefix(arg, "-") || strings.Contains(arg, "c") {
+			return false
+		}
+	}
+	return true
+}
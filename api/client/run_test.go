@@ -0,0 +1,25 @@
+package client
+
+import "testing"
+
+func TestIsInteractiveShell(t *testing.T) {
+	cases := []struct {
+		command  []string
+		expected bool
+	}{
+		{[]string{"/bin/sh"}, true},
+		{[]string{"bash"}, true},
+		{[]string{"/bin/bash", "-l"}, true},
+		{[]string{"/bin/sh", "-c", "echo hello"}, false},
+		{[]string{"/bin/sh", "-ec", "echo hello"}, false},
+		{[]string{"bash", "script.sh"}, false},
+		{[]string{"nginx", "-g", "daemon off;"}, false},
+		{[]string{"/usr/bin/shell"}, false},
+		{nil, false},
+	}
+	for _, c := range cases {
+		if actual := isInteractiveShell(c.command); actual != c.expected {
+			t.Fatalf("Expected %t for %v, got %t", c.expected, c.command, actual)
+		}
+	}
+}
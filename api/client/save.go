@@ -1,6 +1,7 @@
 package client
 
 import (
+	"compress/gzip"
 	"errors"
 	"io"
 	"os"
@@ -14,25 +15,32 @@ import (
 // The tar archive is written to STDOUT by default, or written to a file.
 //
 // Usage: docker save [OPTIONS] IMAGE [IMAGE...]
-func (cli *DockerCli) CmdSave(args ...string) error {
+func (cli *DockerCli) CmdSave(args ...string) (err error) {
 	cmd := Cli.Subcmd("save", []string{"IMAGE [IMAGE...]"}, Cli.DockerCommands["save"].Description+" (streamed to STDOUT by default)", true)
 	outfile := cmd.String([]string{"o", "-output"}, "", "Write to a file, instead of STDOUT")
+	compress := cmd.Bool([]string{"c", "-compress"}, false, "Compress the archive with gzip")
 	cmd.Require(flag.Min, 1)
 
 	cmd.ParseFlags(args, true)
 
-	var (
-		output = cli.out
-		err    error
-	)
+	var output = cli.out
 
 	if *outfile == "" && cli.isTerminalOut {
 		return errors.New("Cowardly refusing to save to a terminal. Use the -o flag or redirect.")
 	}
 	if *outfile != "" {
-		if output, err = os.Create(*outfile); err != nil {
+		var file *os.File
+		file, err = os.Create(*outfile)
+		if err != nil {
 			return err
 		}
+		// A failure to close the file may lose the end of the archive.
+		defer func() {
+			if cerr := file.Close(); err == nil {
+				err = cerr
+			}
+		}()
+		output = file
 	}
 
 	responseBody, err := cli.client.ImageSave(cmd.Args())
@@ -41,6 +49,21 @@ func (cli *DockerCli) CmdSave(args ...string) error {
 	}
 	defer responseBody.Close()
 
+	if *compress {
+		return copyCompressed(output, responseBody)
+	}
 	_, err = io.Copy(output, responseBody)
 	return err
 }
+
+// copyCompressed copies src to dst, compressed with gzip. The gzip writer is
+// always closed, so that the end of the stream is written once all of src is
+// copied.
+func copyCompressed(dst io.Writer, src io.Reader) error {
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		gz.Close()
+		return err
+	}
+	return gz.Close()
+}
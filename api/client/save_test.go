@@ -0,0 +1,46 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestCopyCompressed(t *testing.T) {
+	var out bytes.Buffer
+	content := strings.Repeat("layer data ", 1024)
+	if err := copyCompressed(&out, strings.NewReader(content)); err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() >= len(content) {
+		t.Fatalf("expected the output to be compressed, got %d bytes for %d", out.Len(), len(content))
+	}
+
+	gz, err := gzip.NewReader(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decompressed, err := ioutil.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(decompressed) != content {
+		t.Fatal("expected the decompressed output to be the input")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("no space left on device")
+}
+
+func TestCopyCompressedWriteError(t *testing.T) {
+	err := copyCompressed(failingWriter{}, strings.NewReader("data"))
+	if err == nil || err.Error() != "no space left on device" {
+		t.Fatalf("expected the write error, got %v", err)
+	}
+}
@@ -4,14 +4,15 @@ import (
 	"fmt"
 	"net/url"
 	"sort"
-	"strings"
-	"text/tabwriter"
+	"strconv"
 
+	"github.com/docker/docker/api/client/formatter"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
 	registrytypes "github.com/docker/docker/api/types/registry"
 	Cli "github.com/docker/docker/cli"
+	"github.com/docker/docker/opts"
 	flag "github.com/docker/docker/pkg/mflag"
-	"github.com/docker/docker/pkg/stringutils"
 	"github.com/docker/docker/registry"
 )
 
@@ -23,10 +24,37 @@ func (cli *DockerCli) CmdSearch(args ...string) error {
 	noTrunc := cmd.Bool([]string{"-no-trunc"}, false, "Don't truncate output")
 	automated := cmd.Bool([]string{"-automated"}, false, "Only show automated builds")
 	stars := cmd.Uint([]string{"s", "-stars"}, 0, "Only displays with at least x stars")
+	flFilter := opts.NewListOpts(nil)
+	cmd.Var(&flFilter, []string{"f", "-filter"}, "Filter output based on conditions provided")
+	limit := cmd.Int([]string{"-limit"}, 25, "Max number of search results")
+	format := cmd.String([]string{"-format"}, "", "Pretty-print search results using a Go template")
 	cmd.Require(flag.Exact, 1)
 
 	cmd.ParseFlags(args, true)
 
+	if *limit < 1 {
+		return fmt.Errorf("invalid --limit %d: must be at least 1", *limit)
+	}
+
+	searchFilterArgs := filters.NewArgs()
+	for _, f := range flFilter.GetAll() {
+		var err error
+		if searchFilterArgs, err = filters.ParseFlag(f, searchFilterArgs); err != nil {
+			return err
+		}
+	}
+	filter, err := parseSearchFilter(searchFilterArgs)
+	if err != nil {
+		return err
+	}
+	if *automated {
+		automatedOnly := true
+		filter.automated = &automatedOnly
+	}
+	if int(*stars) > filter.stars {
+		filter.stars = int(*stars)
+	}
+
 	name := cmd.Arg(0)
 	v := url.Values{}
 	v.Set("term", name)
@@ -57,29 +85,21 @@ func (cli *DockerCli) CmdSearch(args ...string) error {
 	results := searchResultsByStars(unorderedResults)
 	sort.Sort(results)
 
-	w := tabwriter.NewWriter(cli.out, 10, 1, 3, ' ', 0)
-	fmt.Fprintf(w, "NAME\tDESCRIPTION\tSTARS\tOFFICIAL\tAUTOMATED\n")
-	for _, res := range results {
-		if (*automated && !res.IsAutomated) || (int(*stars) > res.StarCount) {
-			continue
-		}
-		desc := strings.Replace(res.Description, "\n", " ", -1)
-		desc = strings.Replace(desc, "\r", " ", -1)
-		if !*noTrunc && len(desc) > 45 {
-			desc = stringutils.Truncate(desc, 42) + "..."
-		}
-		fmt.Fprintf(w, "%s\t%s\t%d\t", res.Name, desc, res.StarCount)
-		if res.IsOfficial {
-			fmt.Fprint(w, "[OK]")
+	f := *format
+	if len(f) == 0 {
+		f = "table"
+	}
 
-		}
-		fmt.Fprint(w, "\t")
-		if res.IsAutomated || res.IsTrusted {
-			fmt.Fprint(w, "[OK]")
-		}
-		fmt.Fprint(w, "\n")
+	searchCtx := formatter.SearchContext{
+		Context: formatter.Context{
+			Output: cli.out,
+			Format: f,
+			Trunc:  !*noTrunc,
+		},
+		Results: filter.apply(results, *limit),
 	}
-	w.Flush()
+
+	searchCtx.Write()
 	return nil
 }
 
@@ -89,3 +109,83 @@ type searchResultsByStars []registrytypes.SearchResult
 func (r searchResultsByStars) Len() int           { return len(r) }
 func (r searchResultsByStars) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
 func (r searchResultsByStars) Less(i, j int) bool { return r[j].StarCount < r[i].StarCount }
+
+// acceptedSearchFilters are the filters of docker search, applied to the
+// results returned by the registry.
+var acceptedSearchFilters = map[string]bool{
+	"stars":        true,
+	"is-official":  true,
+	"is-automated": true,
+}
+
+// searchFilter holds the conditions of the --filter flag of docker search.
+// A nil official or automated doesn't filter on it.
+type searchFilter struct {
+	stars     int
+	official  *bool
+	automated *bool
+}
+
+// parseSearchFilter checks the filters of docker search and their values.
+func parseSearchFilter(args filters.Args) (searchFilter, error) {
+	var filter searchFilter
+	if err := args.Validate(acceptedSearchFilters); err != nil {
+		return filter, fmt.Errorf("%v (valid filters are stars, is-official and is-automated)", err)
+	}
+
+	err := args.WalkValues("stars", func(value string) error {
+		stars, err := strconv.Atoi(value)
+		if err != nil || stars < 0 {
+			return fmt.Errorf("invalid filter 'stars=%s': expected a number of stars", value)
+		}
+		if stars > filter.stars {
+			filter.stars = stars
+		}
+		return nil
+	})
+	if err != nil {
+		return filter, err
+	}
+	if filter.official, err = parseSearchBoolFilter(args, "is-official"); err != nil {
+		return filter, err
+	}
+	if filter.automated, err = parseSearchBoolFilter(args, "is-automated"); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// parseSearchBoolFilter returns the value of a true or false filter, or nil
+// if it isn't given.
+func parseSearchBoolFilter(args filters.Args, field string) (*bool, error) {
+	var value *bool
+	err := args.WalkValues(field, func(v string) error {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid filter '%s=%s': expected true or false", field, v)
+		}
+		if value != nil && *value != b {
+			return fmt.Errorf("invalid filter '%s': conflicting values", field)
+		}
+		value = &b
+		return nil
+	})
+	return value, err
+}
+
+// apply returns the first limit results matching the filter.
+func (f searchFilter) apply(results []registrytypes.SearchResult, limit int) []registrytypes.SearchResult {
+	var matching []registrytypes.SearchResult
+	for _, res := range results {
+		if len(matching) == limit {
+			break
+		}
+		if res.StarCount < f.stars ||
+			(f.official != nil && res.IsOfficial != *f.official) ||
+			(f.automated != nil && res.IsAutomated != *f.automated) {
+			continue
+		}
+		matching = append(matching, res)
+	}
+	return matching
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/filters"
+	registrytypes "github.com/docker/docker/api/types/registry"
+)
+
+func TestSearchFilter(t *testing.T) {
+	results := []registrytypes.SearchResult{
+		{Name: "busybox", StarCount: 500, IsOfficial: true},
+		{Name: "progrium/busybox", StarCount: 60, IsAutomated: true},
+		{Name: "radial/busyboxplus", StarCount: 8, IsAutomated: true},
+		{Name: "someone/busybox", StarCount: 0},
+	}
+
+	cases := []struct {
+		filters  []string
+		limit    int
+		expected []string
+	}{
+		{nil, 25, []string{"busybox", "progrium/busybox", "radial/busyboxplus", "someone/busybox"}},
+		{nil, 2, []string{"busybox", "progrium/busybox"}},
+		{[]string{"stars=10"}, 25, []string{"busybox", "progrium/busybox"}},
+		{[]string{"stars=10", "stars=100"}, 25, []string{"busybox"}},
+		{[]string{"is-official=true"}, 25, []string{"busybox"}},
+		{[]string{"is-official=false", "is-automated=true"}, 25, []string{"progrium/busybox", "radial/busyboxplus"}},
+		{[]string{"is-automated=true"}, 1, []string{"progrium/busybox"}},
+		{[]string{"is-automated=false", "stars=1"}, 25, []string{"busybox"}},
+	}
+	for _, c := range cases {
+		args := filters.NewArgs()
+		for _, f := range c.filters {
+			var err error
+			if args, err = filters.ParseFlag(f, args); err != nil {
+				t.Fatal(err)
+			}
+		}
+		filter, err := parseSearchFilter(args)
+		if err != nil {
+			t.Fatalf("%v: %v", c.filters, err)
+		}
+		var names []string
+		for _, res := range filter.apply(results, c.limit) {
+			names = append(names, res.Name)
+		}
+		if strings.Join(names, " ") != strings.Join(c.expected, " ") {
+			t.Fatalf("%v limited to %d: expected %v, got %v", c.filters, c.limit, c.expected, names)
+		}
+	}
+}
+
+func TestParseSearchFilterInvalid(t *testing.T) {
+	cases := map[string]string{
+		"name=busybox":      "Invalid filter 'name'",
+		"stars=many":        "invalid filter 'stars=many'",
+		"stars=-1":          "invalid filter 'stars=-1'",
+		"is-official=maybe": "invalid filter 'is-official=maybe': expected true or false",
+	}
+	for f, expected := range cases {
+		args, err := filters.ParseFlag(f, filters.NewArgs())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := parseSearchFilter(args); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("%s: expected an error containing %q, got %v", f, expected, err)
+		}
+	}
+
+	args := filters.NewArgs()
+	args.Add("is-automated", "true")
+	args.Add("is-automated", "false")
+	if _, err := parseSearchFilter(args); err == nil || !strings.Contains(err.Error(), "conflicting values") {
+		t.Fatalf("expected conflicting values to be rejected, got %v", err)
+	}
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api/types"
 	Cli "github.com/docker/docker/cli"
-	flag "github.com/docker/docker/pkg/mflag"
+	"github.com/docker/docker/opts"
 	"github.com/docker/docker/pkg/promise"
 	"github.com/docker/docker/pkg/signal"
 )
@@ -49,14 +49,19 @@ func (cli *DockerCli) CmdStart(args ...string) error {
 	cmd := Cli.Subcmd("start", []string{"CONTAINER [CONTAINER...]"}, Cli.DockerCommands["start"].Description, true)
 	attach := cmd.Bool([]string{"a", "-attach"}, false, "Attach STDOUT/STDERR and forward signals")
 	openStdin := cmd.Bool([]string{"i", "-interactive"}, false, "Attach container's STDIN")
-	cmd.Require(flag.Min, 1)
+	flFilter := opts.NewListOpts(nil)
+	cmd.Var(&flFilter, []string{"-filter"}, "Start the containers matching the filter")
 
 	cmd.ParseFlags(args, true)
 
+	// At least one container has to be given, unless containers are selected
+	// with filters.
+	requireArgsOrFilter(cmd, flFilter)
+
 	if *attach || *openStdin {
 		// We're going to attach to a container.
 		// 1. Ensure we only have one container.
-		if cmd.NArg() > 1 {
+		if cmd.NArg() > 1 || flFilter.Len() > 0 {
 			return fmt.Errorf("You cannot start and attach multiple containers at once.")
 		}
 
@@ -119,13 +124,23 @@ func (cli *DockerCli) CmdStart(args ...string) error {
 	} else {
 		// We're not going to attach to anything.
 		// Start as many containers as we want.
-		return cli.startContainersWithoutAttachments(cmd.Args())
+		names := cmd.Args()
+		if flFilter.Len() > 0 {
+			var err error
+			if names, err = cli.appendFilteredContainers(names, flFilter); err != nil {
+				return err
+			}
+		}
+		return cli.startContainersWithoutAttachments(names, flFilter.Len() > 0)
 	}
 
 	return nil
 }
 
-func (cli *DockerCli) startContainersWithoutAttachments(containerIDs []string) error {
+// startContainersWithoutAttachments starts the containers one after the other,
+// going on past failures. If printTotal is set, the number of started
+// containers is printed last.
+func (cli *DockerCli) startContainersWithoutAttachments(containerIDs []string, printTotal bool) error {
 	var failedContainers []string
 	for _, containerID := range containerIDs {
 		if err := cli.client.ContainerStart(containerID); err != nil {
@@ -135,6 +150,9 @@ func (cli *DockerCli) startContainersWithoutAttachments(containerIDs []string) e
 			fmt.Fprintf(cli.out, "%s\n", containerID)
 		}
 	}
+	if printTotal {
+		fmt.Fprintf(cli.out, "Started %d containers\n", len(containerIDs)-len(failedContainers))
+	}
 
 	if len(failedContainers) > 0 {
 		return fmt.Errorf("Error: failed to start containers: %v", strings.Join(failedContainers, ", "))
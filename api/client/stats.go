@@ -7,27 +7,18 @@ import (
 	"sort"
 	"strings"
 	"sync"
-	"text/tabwriter"
 	"time"
 
+	"github.com/docker/docker/api/client/formatter"
 	"github.com/docker/docker/api/types"
 	Cli "github.com/docker/docker/cli"
 	"github.com/docker/docker/pkg/jsonmessage"
-	"github.com/docker/docker/pkg/units"
 )
 
 type containerStats struct {
-	Name             string
-	CPUPercentage    float64
-	Memory           float64
-	MemoryLimit      float64
-	MemoryPercentage float64
-	NetworkRx        float64
-	NetworkTx        float64
-	BlockRead        float64
-	BlockWrite       float64
-	mu               sync.RWMutex
-	err              error
+	formatter.ContainerStats
+	mu  sync.RWMutex
+	err error
 }
 
 type stats struct {
@@ -36,7 +27,15 @@ type stats struct {
 }
 
 func (s *containerStats) Collect(cli *DockerCli, streamStats bool) {
-	responseBody, err := cli.client.ContainerStats(s.Name, streamStats)
+	// The name is only used for display, the stats request reports the
+	// errors for missing containers.
+	if c, err := cli.client.ContainerInspect(s.Container); err == nil {
+		s.mu.Lock()
+		s.Name = strings.TrimPrefix(c.Name, "/")
+		s.mu.Unlock()
+	}
+
+	responseBody, err := cli.client.ContainerStats(s.Container, streamStats)
 	if err != nil {
 		s.mu.Lock()
 		s.err = err
@@ -80,6 +79,7 @@ func (s *containerStats) Collect(cli *DockerCli, streamStats bool) {
 			s.NetworkRx, s.NetworkTx = calculateNetwork(v.Networks)
 			s.BlockRead = float64(blkRead)
 			s.BlockWrite = float64(blkWrite)
+			s.PidsCurrent = v.PidsStats.Current
 			s.mu.Unlock()
 			u <- nil
 			if !streamStats {
@@ -101,6 +101,7 @@ func (s *containerStats) Collect(cli *DockerCli, streamStats bool) {
 			s.NetworkTx = 0
 			s.BlockRead = 0
 			s.BlockWrite = 0
+			s.PidsCurrent = 0
 			s.mu.Unlock()
 		case err := <-u:
 			if err != nil {
@@ -116,20 +117,12 @@ func (s *containerStats) Collect(cli *DockerCli, streamStats bool) {
 	}
 }
 
-func (s *containerStats) Display(w io.Writer) error {
+// Snapshot returns the latest statistics of the container, or the error
+// that stopped their collection.
+func (s *containerStats) Snapshot() (formatter.ContainerStats, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	if s.err != nil {
-		return s.err
-	}
-	fmt.Fprintf(w, "%s\t%.2f%%\t%s / %s\t%.2f%%\t%s / %s\t%s / %s\n",
-		s.Name,
-		s.CPUPercentage,
-		units.HumanSize(s.Memory), units.HumanSize(s.MemoryLimit),
-		s.MemoryPercentage,
-		units.HumanSize(s.NetworkRx), units.HumanSize(s.NetworkTx),
-		units.HumanSize(s.BlockRead), units.HumanSize(s.BlockWrite))
-	return nil
+	return s.ContainerStats, s.err
 }
 
 // CmdStats displays a live stream of resource usage statistics for one or more containers.
@@ -141,9 +134,28 @@ func (cli *DockerCli) CmdStats(args ...string) error {
 	cmd := Cli.Subcmd("stats", []string{"[CONTAINER...]"}, Cli.DockerCommands["stats"].Description, true)
 	all := cmd.Bool([]string{"a", "-all"}, false, "Show all containers (default shows just running)")
 	noStream := cmd.Bool([]string{"-no-stream"}, false, "Disable streaming stats and only pull the first result")
+	format := cmd.String([]string{"-format"}, "", "Pretty-print stats using a Go template")
+	jsonOutput := cmd.Bool([]string{"-json"}, false, "Print the stats of each container as a JSON object with raw values, requires --no-stream")
 
 	cmd.ParseFlags(args, true)
 
+	if *jsonOutput {
+		if !*noStream {
+			return fmt.Errorf("--json requires --no-stream")
+		}
+		if *format != "" {
+			return fmt.Errorf("--json and --format cannot be combined")
+		}
+	}
+
+	f := *format
+	if len(f) == 0 {
+		f = "table"
+	}
+	// Only tables refresh the screen, other formats are streamed so they can
+	// be consumed by other programs.
+	refreshScreen := !*noStream && strings.HasPrefix(f, "table")
+
 	names := cmd.Args()
 	showAll := len(names) == 0
 
@@ -164,19 +176,13 @@ func (cli *DockerCli) CmdStats(args ...string) error {
 	}
 	sort.Strings(names)
 
-	var (
-		cStats = stats{}
-		w      = tabwriter.NewWriter(cli.out, 20, 1, 3, ' ', 0)
-	)
-	printHeader := func() {
-		if !*noStream {
-			fmt.Fprint(cli.out, "\033[2J")
-			fmt.Fprint(cli.out, "\033[H")
-		}
-		io.WriteString(w, "CONTAINER\tCPU %\tMEM USAGE / LIMIT\tMEM %\tNET I/O\tBLOCK I/O\n")
+	if *jsonOutput {
+		return cli.printStatsJSON(names)
 	}
+
+	cStats := stats{}
 	for _, n := range names {
-		s := &containerStats{Name: n}
+		s := &containerStats{ContainerStats: formatter.ContainerStats{Container: n}}
 		// no need to lock here since only the main goroutine is running here
 		cStats.cs = append(cStats.cs, s)
 		go s.Collect(cli, !*noStream)
@@ -218,7 +224,7 @@ func (cli *DockerCli) CmdStats(args ...string) error {
 				}
 				switch c.event {
 				case "create":
-					s := &containerStats{Name: c.cid}
+					s := &containerStats{ContainerStats: formatter.ContainerStats{Container: c.cid}}
 					cStats.mu.Lock()
 					cStats.cs = append(cStats.cs, s)
 					cStats.mu.Unlock()
@@ -231,7 +237,7 @@ func (cli *DockerCli) CmdStats(args ...string) error {
 						// containers in stats to move up and down in the list...:(
 						cStats.mu.Lock()
 						for i, s := range cStats.cs {
-							if s.Name == c.cid {
+							if s.Container == c.cid {
 								remove = i
 								break
 							}
@@ -253,7 +259,7 @@ func (cli *DockerCli) CmdStats(args ...string) error {
 	for _, c := range cStats.cs {
 		c.mu.Lock()
 		if c.err != nil {
-			errs = append(errs, fmt.Sprintf("%s: %v", c.Name, c.err))
+			errs = append(errs, fmt.Sprintf("%s: %v", c.Container, c.err))
 		}
 		c.mu.Unlock()
 	}
@@ -262,13 +268,18 @@ func (cli *DockerCli) CmdStats(args ...string) error {
 		return fmt.Errorf("%s", strings.Join(errs, ", "))
 	}
 	for range time.Tick(500 * time.Millisecond) {
-		printHeader()
 		toRemove := []int{}
+		entries := []formatter.ContainerStats{}
 		cStats.mu.Lock()
 		for i, s := range cStats.cs {
-			if err := s.Display(w); err != nil && !*noStream {
-				toRemove = append(toRemove, i)
+			entry, err := s.Snapshot()
+			if err != nil {
+				if !*noStream {
+					toRemove = append(toRemove, i)
+				}
+				continue
 			}
+			entries = append(entries, entry)
 		}
 		for j := len(toRemove) - 1; j >= 0; j-- {
 			i := toRemove[j]
@@ -278,7 +289,21 @@ func (cli *DockerCli) CmdStats(args ...string) error {
 			return nil
 		}
 		cStats.mu.Unlock()
-		w.Flush()
+
+		if refreshScreen {
+			fmt.Fprint(cli.out, "\033[2J")
+			fmt.Fprint(cli.out, "\033[H")
+		}
+		statsCtx := formatter.StatsContext{
+			Context: formatter.Context{
+				Output: cli.out,
+				Format: f,
+			},
+			Stats: entries,
+		}
+		if err := statsCtx.Write(); err != nil {
+			return Cli.StatusError{StatusCode: 64}
+		}
 		if *noStream {
 			break
 		}
@@ -301,6 +326,92 @@ func (cli *DockerCli) CmdStats(args ...string) error {
 	return nil
 }
 
+// statsJSON is the machine readable representation of the stats of a
+// container, with raw values instead of humanized ones.
+type statsJSON struct {
+	Container       string  `json:"container"`
+	Name            string  `json:"name"`
+	CPUPercent      float64 `json:"cpu_percent"`
+	MemoryUsage     uint64  `json:"memory_usage_bytes"`
+	MemoryLimit     uint64  `json:"memory_limit_bytes"`
+	MemoryPercent   float64 `json:"memory_percent"`
+	NetworkRxBytes  uint64  `json:"network_rx_bytes"`
+	NetworkTxBytes  uint64  `json:"network_tx_bytes"`
+	BlockReadBytes  uint64  `json:"block_read_bytes"`
+	BlockWriteBytes uint64  `json:"block_write_bytes"`
+	Pids            uint64  `json:"pids"`
+}
+
+// printStatsJSON reads a single stats frame for each container and prints
+// them as JSON objects, one per line, in the order of the names.
+func (cli *DockerCli) printStatsJSON(names []string) error {
+	var (
+		results = make([]statsJSON, len(names))
+		errs    = make([]error, len(names))
+		wg      sync.WaitGroup
+	)
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			results[i], errs[i] = cli.containerStatsJSON(name)
+		}(i, name)
+	}
+	wg.Wait()
+
+	var failed []string
+	enc := json.NewEncoder(cli.out)
+	for i, name := range names {
+		if errs[i] != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", name, errs[i]))
+			continue
+		}
+		if err := enc.Encode(results[i]); err != nil {
+			return err
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func (cli *DockerCli) containerStatsJSON(name string) (statsJSON, error) {
+	c, err := cli.client.ContainerInspect(name)
+	if err != nil {
+		return statsJSON{}, err
+	}
+
+	responseBody, err := cli.client.ContainerStats(name, false)
+	if err != nil {
+		return statsJSON{}, err
+	}
+	defer responseBody.Close()
+
+	var v types.StatsJSON
+	if err := json.NewDecoder(responseBody).Decode(&v); err != nil {
+		return statsJSON{}, err
+	}
+	return newStatsJSON(name, strings.TrimPrefix(c.Name, "/"), &v), nil
+}
+
+func newStatsJSON(container, name string, v *types.StatsJSON) statsJSON {
+	s := statsJSON{
+		Container:   container,
+		Name:        name,
+		CPUPercent:  calculateCPUPercent(v.PreCPUStats.CPUUsage.TotalUsage, v.PreCPUStats.SystemUsage, v),
+		MemoryUsage: v.MemoryStats.Usage,
+		MemoryLimit: v.MemoryStats.Limit,
+		Pids:        v.PidsStats.Current,
+	}
+	if v.MemoryStats.Limit != 0 {
+		s.MemoryPercent = float64(v.MemoryStats.Usage) / float64(v.MemoryStats.Limit) * 100.0
+	}
+	s.NetworkRxBytes, s.NetworkTxBytes = calculateNetworkBytes(v.Networks)
+	s.BlockReadBytes, s.BlockWriteBytes = calculateBlockIO(v.BlkioStats)
+	return s
+}
+
 func calculateCPUPercent(previousCPU, previousSystem uint64, v *types.StatsJSON) float64 {
 	var (
 		cpuPercent = 0.0
@@ -329,11 +440,14 @@ func calculateBlockIO(blkio types.BlkioStats) (blkRead uint64, blkWrite uint64)
 }
 
 func calculateNetwork(network map[string]types.NetworkStats) (float64, float64) {
-	var rx, tx float64
+	rx, tx := calculateNetworkBytes(network)
+	return float64(rx), float64(tx)
+}
 
+func calculateNetworkBytes(network map[string]types.NetworkStats) (rx uint64, tx uint64) {
 	for _, v := range network {
-		rx += float64(v.RxBytes)
-		tx += float64(v.TxBytes)
+		rx += v.RxBytes
+		tx += v.TxBytes
 	}
-	return rx, tx
+	return
 }
@@ -1,34 +1,33 @@
 package client
 
 import (
-	"bytes"
+	"errors"
 	"sync"
 	"testing"
 
+	"github.com/docker/docker/api/client/formatter"
 	"github.com/docker/docker/api/types"
 )
 
-func TestDisplay(t *testing.T) {
+func TestSnapshot(t *testing.T) {
 	c := &containerStats{
-		Name:             "app",
-		CPUPercentage:    30.0,
-		Memory:           100 * 1024 * 1024.0,
-		MemoryLimit:      2048 * 1024 * 1024.0,
-		MemoryPercentage: 100.0 / 2048.0 * 100.0,
-		NetworkRx:        100 * 1024 * 1024,
-		NetworkTx:        800 * 1024 * 1024,
-		BlockRead:        100 * 1024 * 1024,
-		BlockWrite:       800 * 1024 * 1024,
-		mu:               sync.RWMutex{},
-	}
-	var b bytes.Buffer
-	if err := c.Display(&b); err != nil {
-		t.Fatalf("c.Display() gave error: %s", err)
-	}
-	got := b.String()
-	want := "app\t30.00%\t104.9 MB / 2.147 GB\t4.88%\t104.9 MB / 838.9 MB\t104.9 MB / 838.9 MB\n"
-	if got != want {
-		t.Fatalf("c.Display() = %q, want %q", got, want)
+		ContainerStats: formatter.ContainerStats{
+			Container:     "app",
+			CPUPercentage: 30.0,
+		},
+		mu: sync.RWMutex{},
+	}
+	stats, err := c.Snapshot()
+	if err != nil {
+		t.Fatalf("c.Snapshot() gave error: %s", err)
+	}
+	if stats.Container != "app" || stats.CPUPercentage != 30.0 {
+		t.Fatalf("c.Snapshot() = %+v, want the container's stats", stats)
+	}
+
+	c.err = errors.New("no such container")
+	if _, err := c.Snapshot(); err != c.err {
+		t.Fatalf("c.Snapshot() gave error %v, want %v", err, c.err)
 	}
 }
 
@@ -44,3 +43,36 @@ func TestCalculBlockIO(t *testing.T) {
 		t.Fatalf("blkWrite = %d, want 579", blkWrite)
 	}
 }
+
+func TestNewStatsJSON(t *testing.T) {
+	v := &types.StatsJSON{
+		Networks: map[string]types.NetworkStats{
+			"eth0": {RxBytes: 1000, TxBytes: 200},
+			"eth1": {RxBytes: 24, TxBytes: 56},
+		},
+	}
+	v.MemoryStats.Usage = 512
+	v.MemoryStats.Limit = 2048
+	v.PidsStats.Current = 3
+	v.BlkioStats.IoServiceBytesRecursive = []types.BlkioStatEntry{
+		{Op: "Read", Value: 4096},
+		{Op: "Write", Value: 8192},
+	}
+
+	s := newStatsJSON("app", "app_1", v)
+	if s.Container != "app" || s.Name != "app_1" {
+		t.Fatalf("container = %q, name = %q, want app, app_1", s.Container, s.Name)
+	}
+	if s.MemoryUsage != 512 || s.MemoryLimit != 2048 || s.MemoryPercent != 25.0 {
+		t.Fatalf("memory = %d / %d (%f%%), want 512 / 2048 (25%%)", s.MemoryUsage, s.MemoryLimit, s.MemoryPercent)
+	}
+	if s.NetworkRxBytes != 1024 || s.NetworkTxBytes != 256 {
+		t.Fatalf("network = %d / %d, want 1024 / 256", s.NetworkRxBytes, s.NetworkTxBytes)
+	}
+	if s.BlockReadBytes != 4096 || s.BlockWriteBytes != 8192 {
+		t.Fatalf("block io = %d / %d, want 4096 / 8192", s.BlockReadBytes, s.BlockWriteBytes)
+	}
+	if s.Pids != 3 {
+		t.Fatalf("pids = %d, want 3", s.Pids)
+	}
+}
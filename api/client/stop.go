@@ -2,34 +2,66 @@ package client
 
 import (
 	"fmt"
+	"syscall"
+	"time"
 
+	"github.com/docker/docker/api/types"
 	Cli "github.com/docker/docker/cli"
-	flag "github.com/docker/docker/pkg/mflag"
+	"github.com/docker/docker/opts"
 )
 
 // CmdStop stops one or more containers.
 //
 // A running container is stopped by first sending SIGTERM and then SIGKILL if the container fails to stop within a grace period (the default is 10 seconds).
+// The name of each stopped container is printed, and a warning tells about the ones which had to be killed.
 //
 // Usage: docker stop [OPTIONS] CONTAINER [CONTAINER...]
 func (cli *DockerCli) CmdStop(args ...string) error {
 	cmd := Cli.Subcmd("stop", []string{"CONTAINER [CONTAINER...]"}, Cli.DockerCommands["stop"].Description+".\nSending SIGTERM and then SIGKILL after a grace period", true)
 	nSeconds := cmd.Int([]string{"t", "-time"}, 10, "Seconds to wait for stop before killing it")
-	cmd.Require(flag.Min, 1)
+	flFilter := opts.NewListOpts(nil)
+	cmd.Var(&flFilter, []string{"-filter"}, "Stop the containers matching the filter")
 
 	cmd.ParseFlags(args, true)
 
+	// At least one container has to be given, unless containers are selected
+	// with filters.
+	requireArgsOrFilter(cmd, flFilter)
+
+	names := cmd.Args()
+	if flFilter.Len() > 0 {
+		var err error
+		if names, err = cli.appendFilteredContainers(names, flFilter); err != nil {
+			return err
+		}
+	}
+
 	var errNames []string
-	for _, name := range cmd.Args() {
+	for _, name := range names {
+		start := time.Now()
 		if err := cli.client.ContainerStop(name, *nSeconds); err != nil {
 			fmt.Fprintf(cli.err, "%s\n", err)
 			errNames = append(errNames, name)
-		} else {
-			fmt.Fprintf(cli.out, "%s\n", name)
+			continue
 		}
+		fmt.Fprintf(cli.out, "%s\n", name)
+		if c, err := cli.client.ContainerInspect(name); err == nil && killedOnStop(c.State, time.Since(start), *nSeconds) {
+			fmt.Fprintf(cli.err, "%s did not stop within %d seconds of SIGTERM and was killed with SIGKILL\n", name, *nSeconds)
+		}
+	}
+	if flFilter.Len() > 0 {
+		fmt.Fprintf(cli.out, "Stopped %d containers\n", len(names)-len(errNames))
 	}
 	if len(errNames) > 0 {
 		return fmt.Errorf("Error: failed to stop containers: %v", errNames)
 	}
 	return nil
 }
+
+// killedOnStop tells whether a container stopped with the given timeout had
+// to be killed, that is whether it exited from SIGKILL once the timeout
+// elapsed rather than from SIGTERM.
+func killedOnStop(state *types.ContainerState, elapsed time.Duration, timeout int) bool {
+	return state != nil && !state.Running && state.ExitCode == 128+int(syscall.SIGKILL) &&
+		elapsed >= time.Duration(timeout)*time.Second
+}
@@ -0,0 +1,30 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestKilledOnStop(t *testing.T) {
+	cases := []struct {
+		state    *types.ContainerState
+		elapsed  time.Duration
+		expected bool
+	}{
+		{&types.ContainerState{ExitCode: 137}, 10 * time.Second, true},
+		{&types.ContainerState{ExitCode: 137}, 12 * time.Second, true},
+		// Killed by something else before the timeout
+		{&types.ContainerState{ExitCode: 137}, 2 * time.Second, false},
+		{&types.ContainerState{ExitCode: 143}, 10 * time.Second, false},
+		{&types.ContainerState{ExitCode: 0}, 10 * time.Second, false},
+		{&types.ContainerState{Running: true, ExitCode: 137}, 10 * time.Second, false},
+		{nil, 10 * time.Second, false},
+	}
+	for _, c := range cases {
+		if actual := killedOnStop(c.state, c.elapsed, 10); actual != c.expected {
+			t.Fatalf("%+v after %s: expected %v, got %v", c.state, c.elapsed, c.expected, actual)
+		}
+	}
+}
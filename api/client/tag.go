@@ -1,7 +1,12 @@
 package client
 
 import (
+	"bufio"
 	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/types"
@@ -12,22 +17,126 @@ import (
 
 // CmdTag tags an image into a repository.
 //
-// Usage: docker tag [OPTIONS] IMAGE[:TAG] [REGISTRYHOST/][USERNAME/]NAME[:TAG]
+// If '-' is given instead of an image, the SOURCE DEST pairs to tag are read
+// from STDIN, one per line.
+//
+// Usage: docker tag [OPTIONS] IMAGE[:TAG] [REGISTRYHOST/][USERNAME/]NAME[:TAG] | -
 func (cli *DockerCli) CmdTag(args ...string) error {
-	cmd := Cli.Subcmd("tag", []string{"IMAGE[:TAG] [REGISTRYHOST/][USERNAME/]NAME[:TAG]"}, Cli.DockerCommands["tag"].Description, true)
+	cmd := Cli.Subcmd("tag", []string{"IMAGE[:TAG] [REGISTRYHOST/][USERNAME/]NAME[:TAG] | -"}, Cli.DockerCommands["tag"].Description, true)
 	force := cmd.Bool([]string{"#f", "#-force"}, false, "Force the tagging even if there's a conflict")
-	cmd.Require(flag.Exact, 2)
+	cmd.Require(flag.Min, 1)
+	cmd.Require(flag.Max, 2)
 
 	cmd.ParseFlags(args, true)
 
-	ref, err := reference.ParseNamed(cmd.Arg(1))
+	if cmd.NArg() == 1 {
+		if cmd.Arg(0) != "-" {
+			cmd.ReportError(fmt.Sprintf("%q requires 2 arguments", cmd.Name()), true)
+			cmd.ShortUsage()
+			os.Exit(1)
+		}
+		return cli.tagFromReader(cli.in, *force)
+	}
+
+	if _, err := parseNamedReference(cmd.Arg(0)); err != nil {
+		return fmt.Errorf("Error: invalid source %q: %v", cmd.Arg(0), err)
+	}
+	options, err := parseTagOptions(cmd.Arg(0), cmd.Arg(1))
 	if err != nil {
+		return fmt.Errorf("Error: invalid target %q: %v", cmd.Arg(1), err)
+	}
+	options.Force = *force
+
+	return cli.client.ImageTag(options)
+}
+
+// tagFromReader reads SOURCE DEST pairs from r, one per line, and tags each
+// SOURCE image as DEST. All the lines are validated before anything is
+// tagged. A failure to tag an image is reported and doesn't prevent the
+// following lines from being tagged.
+func (cli *DockerCli) tagFromReader(r io.Reader, force bool) error {
+	type tagLine struct {
+		num     int
+		text    string
+		options types.ImageTagOptions
+	}
+
+	var (
+		lines    []tagLine
+		invalid  int
+		scanner  = bufio.NewScanner(r)
+		fieldErr = errors.New("expected SOURCE DEST")
+	)
+	for num := 1; scanner.Scan(); num++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		var (
+			options types.ImageTagOptions
+			err     = fieldErr
+		)
+		if len(fields) == 2 {
+			if _, err = parseNamedReference(fields[0]); err == nil {
+				options, err = parseTagOptions(fields[0], fields[1])
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(cli.err, "line %d: invalid tag %q: %v\n", num, text, err)
+			invalid++
+			continue
+		}
+		options.Force = force
+		lines = append(lines, tagLine{num, text, options})
+	}
+	if err := scanner.Err(); err != nil {
 		return err
 	}
+	if invalid > 0 {
+		return fmt.Errorf("Error: %d invalid lines, nothing was tagged", invalid)
+	}
+
+	var failed int
+	for _, line := range lines {
+		if err := cli.client.ImageTag(line.options); err != nil {
+			fmt.Fprintf(cli.err, "line %d: failed to tag %q: %v\n", line.num, line.text, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(cli.out, "line %d: tagged %s\n", line.num, line.text)
+	}
+	if failed > 0 {
+		return fmt.Errorf("Error: failed to tag %d of %d images", failed, len(lines))
+	}
+	return nil
+}
+
+// parseNamedReference parses a reference with reference.ParseNamed. The
+// error for a repository name with uppercase characters says so, instead of
+// only reporting an invalid reference format.
+func parseNamedReference(s string) (reference.Named, error) {
+	ref, err := reference.ParseNamed(s)
+	if err == reference.ErrReferenceInvalidFormat {
+		// Tags may have uppercase characters, names can't.
+		if _, lowerErr := reference.ParseNamed(strings.ToLower(s)); lowerErr == nil {
+			return nil, fmt.Errorf("%v: repository name must be lowercase", err)
+		}
+	}
+	return ref, err
+}
+
+// parseTagOptions validates the new name to give to the image.
+func parseTagOptions(image, name string) (types.ImageTagOptions, error) {
+	ref, err := parseNamedReference(name)
+	if err != nil {
+		return types.ImageTagOptions{}, err
+	}
 
 	_, isDigested := ref.(reference.Digested)
 	if isDigested {
-		return errors.New("refusing to create a tag with a digest reference")
+		return types.ImageTagOptions{}, errors.New("refusing to create a tag with a digest reference")
 	}
 
 	tag := ""
@@ -38,15 +147,12 @@ func (cli *DockerCli) CmdTag(args ...string) error {
 
 	//Check if the given image name can be resolved
 	if err := registry.ValidateRepositoryName(ref); err != nil {
-		return err
+		return types.ImageTagOptions{}, err
 	}
 
-	options := types.ImageTagOptions{
-		ImageID:        cmd.Arg(0),
+	return types.ImageTagOptions{
+		ImageID:        image,
 		RepositoryName: ref.Name(),
 		Tag:            tag,
-		Force:          *force,
-	}
-
-	return cli.client.ImageTag(options)
+	}, nil
 }
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTagOptions(t *testing.T) {
+	options, err := parseTagOptions("busybox", "localhost:5000/myadmin/busybox:v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if options.ImageID != "busybox" || options.RepositoryName != "localhost:5000/myadmin/busybox" || options.Tag != "v1" {
+		t.Fatalf("Unexpected options %+v", options)
+	}
+
+	options, err = parseTagOptions("busybox", "myadmin/busybox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if options.Tag != "" {
+		t.Fatalf("Expected no tag, got %q", options.Tag)
+	}
+
+	invalid := []string{
+		"myadmin/busybox@sha256:cbbf2f9a99b47fc460d422812b6a5adff7dfee951d8fa2e4a98caa0382cfbdbf",
+		"Busybox",
+		"busybox:",
+	}
+	for _, name := range invalid {
+		if _, err := parseTagOptions("busybox", name); err == nil {
+			t.Fatalf("Expected %q to be an invalid name", name)
+		}
+	}
+}
+
+func TestParseNamedReference(t *testing.T) {
+	valid := []string{"busybox", "busybox:V1", "3e23a5875458", "localhost:5000/myadmin/busybox:latest"}
+	for _, s := range valid {
+		if _, err := parseNamedReference(s); err != nil {
+			t.Fatalf("Expected %q to be a valid reference, got %v", s, err)
+		}
+	}
+
+	invalid := map[string]string{
+		"Busybox":                "invalid reference format: repository name must be lowercase",
+		"localhost:5000/MyAdmin": "invalid reference format: repository name must be lowercase",
+		"busybox:":               "invalid reference format",
+		"busy box":               "invalid reference format",
+		"":                       "repository name must have at least one component",
+	}
+	for s, expected := range invalid {
+		_, err := parseNamedReference(s)
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected the error %q for %q, got %v", expected, s, err)
+		}
+	}
+
+	if _, err := parseTagOptions("busybox", "MyBusybox:v1"); err == nil || !strings.HasSuffix(err.Error(), "repository name must be lowercase") {
+		t.Fatalf("Expected an uppercase target to be reported as such, got %v", err)
+	}
+}
@@ -3,21 +3,39 @@ package client
 import (
 	"fmt"
 	"strings"
-	"text/tabwriter"
+	"text/template"
 
+	"github.com/docker/docker/api/client/formatter"
+	"github.com/docker/docker/api/types"
 	Cli "github.com/docker/docker/cli"
 	flag "github.com/docker/docker/pkg/mflag"
 )
 
 // CmdTop displays the running processes of a container.
 //
-// Usage: docker top CONTAINER
+// Usage: docker top [OPTIONS] CONTAINER [ps OPTIONS]
 func (cli *DockerCli) CmdTop(args ...string) error {
 	cmd := Cli.Subcmd("top", []string{"CONTAINER [ps OPTIONS]"}, Cli.DockerCommands["top"].Description, true)
+	format := cmd.String([]string{"-format"}, "", "Pretty-print processes using a Go template")
+	columns := cmd.String([]string{"-columns"}, "", "Comma-separated list of the ps columns to display")
 	cmd.Require(flag.Min, 1)
 
 	cmd.ParseFlags(args, true)
 
+	if *format != "" && *columns != "" {
+		return fmt.Errorf("--format and --columns cannot be combined")
+	}
+
+	var tmpl *template.Template
+	if *format != "" {
+		var err error
+		tmpl, err = template.New("").Funcs(funcMap).Parse(strings.NewReplacer(`\t`, "\t", `\n`, "\n").Replace(*format))
+		if err != nil {
+			return Cli.StatusError{StatusCode: 64,
+				Status: "Template parsing error: " + err.Error()}
+		}
+	}
+
 	var arguments []string
 	if cmd.NArg() > 1 {
 		arguments = cmd.Args()[1:]
@@ -28,7 +46,24 @@ func (cli *DockerCli) CmdTop(args ...string) error {
 		return err
 	}
 
-	w := tabwriter.NewWriter(cli.out, 20, 1, 3, ' ', 0)
+	if tmpl != nil {
+		for _, row := range topRows(procList) {
+			if err := tmpl.Execute(cli.out, row); err != nil {
+				return Cli.StatusError{StatusCode: 64,
+					Status: "Template parsing error: " + err.Error()}
+			}
+			fmt.Fprintln(cli.out)
+		}
+		return nil
+	}
+
+	if *columns != "" {
+		if procList, err = selectTopColumns(procList, strings.Split(*columns, ",")); err != nil {
+			return err
+		}
+	}
+
+	w := formatter.NewTabWriter(cli.out)
 	fmt.Fprintln(w, strings.Join(procList.Titles, "\t"))
 
 	for _, proc := range procList.Processes {
@@ -37,3 +72,62 @@ func (cli *DockerCli) CmdTop(args ...string) error {
 	w.Flush()
 	return nil
 }
+
+// topRows returns the processes as maps from the ps column titles to the
+// values of the process, as given to the --format template of docker top.
+func topRows(procList types.ContainerProcessList) []map[string]string {
+	rows := make([]map[string]string, 0, len(procList.Processes))
+	for _, proc := range procList.Processes {
+		row := make(map[string]string, len(procList.Titles))
+		for i, title := range procList.Titles {
+			if i < len(proc) {
+				row[title] = proc[i]
+			} else {
+				row[title] = ""
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// selectTopColumns returns the process list with only the given columns, in
+// the given order. Columns are matched against the ps titles regardless of
+// case.
+func selectTopColumns(procList types.ContainerProcessList, columns []string) (types.ContainerProcessList, error) {
+	indexes := make([]int, 0, len(columns))
+	for _, column := range columns {
+		column = strings.TrimSpace(column)
+		index := -1
+		for i, title := range procList.Titles {
+			if strings.EqualFold(title, column) {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return procList, fmt.Errorf("unknown column %q (columns are %s)", column, strings.Join(procList.Titles, ", "))
+		}
+		indexes = append(indexes, index)
+	}
+
+	selected := types.ContainerProcessList{
+		Titles:    make([]string, 0, len(indexes)),
+		Processes: make([][]string, 0, len(procList.Processes)),
+	}
+	for _, i := range indexes {
+		selected.Titles = append(selected.Titles, procList.Titles[i])
+	}
+	for _, proc := range procList.Processes {
+		row := make([]string, 0, len(indexes))
+		for _, i := range indexes {
+			value := ""
+			if i < len(proc) {
+				value = proc[i]
+			}
+			row = append(row, value)
+		}
+		selected.Processes = append(selected.Processes, row)
+	}
+	return selected, nil
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+var testProcList = types.ContainerProcessList{
+	Titles: []string{"UID", "PID", "PPID", "CMD"},
+	Processes: [][]string{
+		{"root", "2910", "2893", "top"},
+		{"root", "2951", "2910", "sh -c sleep 100"},
+	},
+}
+
+func TestTopRows(t *testing.T) {
+	expected := []map[string]string{
+		{"UID": "root", "PID": "2910", "PPID": "2893", "CMD": "top"},
+		{"UID": "root", "PID": "2951", "PPID": "2910", "CMD": "sh -c sleep 100"},
+	}
+	if rows := topRows(testProcList); !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %v, got %v", expected, rows)
+	}
+}
+
+func TestSelectTopColumns(t *testing.T) {
+	selected, err := selectTopColumns(testProcList, []string{"cmd", " PID"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := types.ContainerProcessList{
+		Titles:    []string{"CMD", "PID"},
+		Processes: [][]string{{"top", "2910"}, {"sh -c sleep 100", "2951"}},
+	}
+	if !reflect.DeepEqual(selected, expected) {
+		t.Fatalf("expected %v, got %v", expected, selected)
+	}
+
+	_, err = selectTopColumns(testProcList, []string{"PID", "USER"})
+	if expected := `unknown column "USER" (columns are UID, PID, PPID, CMD)`; err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %v", expected, err)
+	}
+}
@@ -1,23 +1,238 @@
 package client
 
 import (
+	"bufio"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	gosignal "os/signal"
 	"runtime"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api/client/lib"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
 	registrytypes "github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/opts"
+	flag "github.com/docker/docker/pkg/mflag"
 	"github.com/docker/docker/pkg/signal"
+	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/docker/pkg/term"
+	"github.com/docker/docker/pkg/version"
 	"github.com/docker/docker/registry"
+	"golang.org/x/net/context"
 )
 
+// nonRetryableRegistryErrors and retryableRegistryErrors hold fragments of
+// the messages of registry errors. They are used to tell transient failures
+// from the ones which would fail again.
+var (
+	nonRetryableRegistryErrors = []string{
+		"authentication required",
+		"unauthorized",
+		"denied",
+		"not found",
+		"manifest unknown",
+		"name unknown",
+	}
+	retryableRegistryErrors = []string{
+		"connection refused",
+		"connection reset",
+		"broken pipe",
+		"i/o timeout",
+		"tls handshake timeout",
+		"unexpected eof",
+		"temporary failure",
+		"500 internal server error",
+		"502 bad gateway",
+		"503 service unavailable",
+		"504 gateway timeout",
+	}
+)
+
+// isRetryableRegistryError returns whether the error returned by a pull or a
+// push is likely to be transient, like the network or the registry being
+// temporarily unavailable.
+func isRetryableRegistryError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, fragment := range nonRetryableRegistryErrors {
+		if strings.Contains(msg, fragment) {
+			return false
+		}
+	}
+	for _, fragment := range retryableRegistryErrors {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryRegistryOperation runs op and retries it up to retries times if it
+// fails with a retryable error, doubling the delay between two attempts.
+func (cli *DockerCli) retryRegistryOperation(retries int, delay time.Duration, op func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if err == nil {
+			return nil
+		}
+		if attempt > retries || !isRetryableRegistryError(err) {
+			if attempt > 1 {
+				return fmt.Errorf("%v (failed after %d attempts)", err, attempt)
+			}
+			return err
+		}
+		fmt.Fprintf(cli.err, "%v: retrying in %s (attempt %d of %d)\n", err, delay, attempt+1, retries+1)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// checkDaemonAPIVersion returns an error if the daemon's API version is older
+// than minVersion, the first version which supports the given flag. It's used
+// for flags older daemons would otherwise silently ignore.
+func (cli *DockerCli) checkDaemonAPIVersion(flagName string, minVersion version.Version) error {
+	v, err := cli.client.ServerVersion()
+	if err != nil {
+		return err
+	}
+	if v.APIVersion.LessThan(minVersion) {
+		return fmt.Errorf("%s is not supported by the daemon: it requires API version %s or later, the daemon has API version %s", flagName, minVersion, v.APIVersion)
+	}
+	return nil
+}
+
+// validateLabelFilter checks that every `label` filter is either a key or a
+// key=value pair, so that malformed filters fail before reaching the daemon.
+func validateLabelFilter(filterArgs filters.Args) error {
+	return filterArgs.WalkValues("label", func(value string) error {
+		if kv := strings.SplitN(value, "=", 2); strings.TrimSpace(kv[0]) == "" {
+			return fmt.Errorf("invalid filter 'label': expected key or key=value, got %q", value)
+		}
+		return nil
+	})
+}
+
+// requireArgsOrFilter exits with the usage of cmd if it isn't given any
+// argument nor any --filter to select what it acts upon.
+func requireArgsOrFilter(cmd *flag.FlagSet, flFilter opts.ListOpts) {
+	if cmd.NArg() == 0 && flFilter.Len() == 0 {
+		cmd.ReportError(fmt.Sprintf("%q requires a minimum of 1 argument or a --filter", cmd.Name()), true)
+		cmd.ShortUsage()
+		os.Exit(1)
+	}
+}
+
+// acceptedContainerSelectionFilters are the filters docker start, stop and
+// restart accept to select the containers they act upon.
+var acceptedContainerSelectionFilters = map[string]bool{
+	"label":  true,
+	"name":   true,
+	"status": true,
+}
+
+// appendFilteredContainers appends to names the IDs of the containers
+// matching the filters given with --filter, skipping the ones already named.
+func (cli *DockerCli) appendFilteredContainers(names []string, flFilter opts.ListOpts) ([]string, error) {
+	filterArgs := filters.NewArgs()
+	for _, f := range flFilter.GetAll() {
+		var err error
+		if filterArgs, err = filters.ParseFlag(f, filterArgs); err != nil {
+			return nil, err
+		}
+	}
+	if err := filterArgs.Validate(acceptedContainerSelectionFilters); err != nil {
+		return nil, err
+	}
+	if err := validateLabelFilter(filterArgs); err != nil {
+		return nil, err
+	}
+
+	containers, err := cli.client.ContainerList(types.ContainerListOptions{All: true, Filter: filterArgs})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(containers))
+	for _, c := range containers {
+		ids = append(ids, stringid.TruncateID(c.ID))
+	}
+	return appendNew(names, ids), nil
+}
+
+// appendNew appends to names the values which aren't in it already.
+func appendNew(names, values []string) []string {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		seen[name] = true
+	}
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			names = append(names, v)
+		}
+	}
+	return names
+}
+
+// confirm prints the warning message and asks the user whether to go on,
+// reading the answer from in. Anything but y or yes, including no answer at
+// all, is a no.
+func confirm(in io.Reader, out io.Writer, message string) bool {
+	fmt.Fprintf(out, "WARNING! %s\nAre you sure you want to continue? [y/N] ", message)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// confirmFilterRemoval asks the user to confirm the removal of the count
+// resources of the given kind matching the filters of docker volume rm,
+// unless force is set. It fails if the removal isn't confirmed, so
+// that scripts don't go on as if the resources were removed.
+func (cli *DockerCli) confirmFilterRemoval(force bool, count int, kind string) error {
+	if force || count == 0 {
+		return nil
+	}
+	if !confirm(cli.in, cli.out, fmt.Sprintf("This will remove %d %s.", count, kind)) {
+		return fmt.Errorf("Error: removal of the %d %s matching the filter was not confirmed", count, kind)
+	}
+	return nil
+}
+
+// addColorFlag adds the --color flag of the list commands to cmd.
+func addColorFlag(cmd *flag.FlagSet) *string {
+	return cmd.String([]string{"-color"}, "auto", "Color the output (auto, always or never)")
+}
+
+// colorOutput tells whether the output is colored for the value of the
+// --color flag. With auto, it's colored if it's a terminal, unless the
+// NO_COLOR environment variable is set.
+func colorOutput(mode string, isTerminal bool) (bool, error) {
+	switch mode {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		return isTerminal && os.Getenv("NO_COLOR") == "", nil
+	}
+	return false, fmt.Errorf("invalid --color value %q, must be auto, always or never", mode)
+}
+
+// envFormat returns the default --format of a list command, given by the
+// environment variable env, e.g. DOCKER_IMAGES_FORMAT for docker images. It
+// is ignored in quiet mode, so that scripts listing IDs aren't affected.
+func envFormat(env string, quiet bool) string {
+	if quiet {
+		return ""
+	}
+	return os.Getenv(env)
+}
+
 // encodeAuthToBase64 serializes the auth configuration as JSON base64 payload
 func encodeAuthToBase64(authConfig types.AuthConfig) (string, error) {
 	buf, err := json.Marshal(authConfig)
@@ -125,6 +340,27 @@ func (cli *DockerCli) monitorTtySize(id string, isExec bool) error {
 	return nil
 }
 
+// interruptContext returns a context which is canceled when the client is
+// interrupted by SIGINT or SIGTERM, to abort the requests in flight. The
+// returned function stops catching the signals and must be called once the
+// requests are done.
+func interruptContext() (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sigchan := make(chan os.Signal, 1)
+	gosignal.Notify(sigchan, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-sigchan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, func() {
+		gosignal.Stop(sigchan)
+		cancel()
+	}
+}
+
 func (cli *DockerCli) getTtySize() (int, int) {
 	if !cli.isTerminalOut {
 		return 0, 0
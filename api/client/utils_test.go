@@ -0,0 +1,217 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/client/lib"
+	"github.com/docker/docker/api/types/filters"
+)
+
+func TestValidateLabelFilter(t *testing.T) {
+	valid := []string{"label=com.example.version", "label=com.example.version=1.0", "label=com.example.empty="}
+	for _, f := range valid {
+		args, err := filters.ParseFlag(f, filters.NewArgs())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := validateLabelFilter(args); err != nil {
+			t.Fatalf("Expected %q to be a valid label filter, got %v", f, err)
+		}
+	}
+
+	invalid := []string{"label=", "label==1.0", "label= =1.0"}
+	for _, f := range invalid {
+		args, err := filters.ParseFlag(f, filters.NewArgs())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := validateLabelFilter(args); err == nil {
+			t.Fatalf("Expected %q to be an invalid label filter", f)
+		}
+	}
+}
+
+func TestIsRetryableRegistryError(t *testing.T) {
+	retryable := []string{
+		"Get https://registry-1.docker.io/v2/: dial tcp 52.0.0.1:443: connection refused",
+		"net/http: TLS handshake timeout",
+		"received unexpected HTTP status: 503 Service Unavailable",
+		"read tcp 10.0.0.1:443: i/o timeout",
+	}
+	for _, msg := range retryable {
+		if !isRetryableRegistryError(errors.New(msg)) {
+			t.Fatalf("Expected %q to be retryable", msg)
+		}
+	}
+
+	nonRetryable := []string{
+		"unauthorized: authentication required",
+		"Error: image library/asdfasdf not found",
+		"manifest unknown: manifest unknown",
+		"denied: requested access to the resource is denied",
+		"invalid reference format",
+	}
+	for _, msg := range nonRetryable {
+		if isRetryableRegistryError(errors.New(msg)) {
+			t.Fatalf("Expected %q not to be retryable", msg)
+		}
+	}
+}
+
+func TestRetryRegistryOperation(t *testing.T) {
+	cli := &DockerCli{err: ioutil.Discard}
+
+	attempts := 0
+	err := cli.retryRegistryOperation(3, 0, func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("connection reset by peer")
+		}
+		return nil
+	})
+	if err != nil || attempts != 3 {
+		t.Fatalf("Expected success after 3 attempts, got %v after %d attempts", err, attempts)
+	}
+
+	attempts = 0
+	err = cli.retryRegistryOperation(2, 0, func() error {
+		attempts++
+		return errors.New("connection reset by peer")
+	})
+	if err == nil || attempts != 3 || !strings.Contains(err.Error(), "failed after 3 attempts") {
+		t.Fatalf("Expected a failure after 3 attempts, got %v after %d attempts", err, attempts)
+	}
+
+	attempts = 0
+	err = cli.retryRegistryOperation(2, 0, func() error {
+		attempts++
+		return errors.New("unauthorized: authentication required")
+	})
+	if err == nil || attempts != 1 || err.Error() != "unauthorized: authentication required" {
+		t.Fatalf("Expected a failure without retries, got %v after %d attempts", err, attempts)
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	answers := map[string]bool{
+		"y\n":     true,
+		"Yes\r\n": true,
+		"yes":     true,
+		"n\n":     false,
+		"\n":      false,
+		"":        false,
+		"yess\n":  false,
+	}
+	for answer, expected := range answers {
+		out := bytes.NewBuffer(nil)
+		if actual := confirm(strings.NewReader(answer), out, "This will remove 2 volumes."); actual != expected {
+			t.Fatalf("%q: expected %v, got %v", answer, expected, actual)
+		}
+		if prompt := "WARNING! This will remove 2 volumes.\nAre you sure you want to continue? [y/N] "; out.String() != prompt {
+			t.Fatalf("expected prompt %q, got %q", prompt, out.String())
+		}
+	}
+}
+
+func TestConfirmFilterRemoval(t *testing.T) {
+	cases := []struct {
+		force  bool
+		count  int
+		answer string
+		err    string
+		prompt bool
+	}{
+		{false, 2, "y\n", "", true},
+		{false, 2, "n\n", "Error: removal of the 2 volumes matching the filter was not confirmed", true},
+		{false, 2, "", "Error: removal of the 2 volumes matching the filter was not confirmed", true},
+		{true, 2, "", "", false},
+		{false, 0, "", "", false},
+	}
+	for _, c := range cases {
+		out := bytes.NewBuffer(nil)
+		cli := &DockerCli{in: ioutil.NopCloser(strings.NewReader(c.answer)), out: out}
+		err := cli.confirmFilterRemoval(c.force, c.count, "volumes")
+		if c.err == "" && err != nil {
+			t.Fatalf("%+v: unexpected error %v", c, err)
+		}
+		if c.err != "" && (err == nil || err.Error() != c.err) {
+			t.Fatalf("%+v: expected error %q, got %v", c, c.err, err)
+		}
+		if prompted := out.Len() > 0; prompted != c.prompt {
+			t.Fatalf("%+v: expected prompt %v, got %q", c, c.prompt, out.String())
+		}
+	}
+}
+
+func TestColorOutput(t *testing.T) {
+	os.Unsetenv("NO_COLOR")
+	cases := []struct {
+		mode       string
+		isTerminal bool
+		expected   bool
+	}{
+		{"auto", true, true},
+		{"auto", false, false},
+		{"always", false, true},
+		{"never", true, false},
+	}
+	for _, c := range cases {
+		if color, err := colorOutput(c.mode, c.isTerminal); err != nil || color != c.expected {
+			t.Fatalf("Expected %v for %s with a terminal %v, got %v, %v", c.expected, c.mode, c.isTerminal, color, err)
+		}
+	}
+
+	os.Setenv("NO_COLOR", "1")
+	defer os.Unsetenv("NO_COLOR")
+	if color, _ := colorOutput("auto", true); color {
+		t.Fatal("Expected no color for auto with NO_COLOR set")
+	}
+	if color, _ := colorOutput("always", false); !color {
+		t.Fatal("Expected color for always with NO_COLOR set")
+	}
+	if _, err := colorOutput("sometimes", true); err == nil {
+		t.Fatal("Expected an error for sometimes")
+	}
+}
+
+func TestEnvFormat(t *testing.T) {
+	os.Setenv("DOCKER_TEST_FORMAT", "{{.ID}}\\t{{.Size}}")
+	defer os.Unsetenv("DOCKER_TEST_FORMAT")
+	if f := envFormat("DOCKER_TEST_FORMAT", false); f != "{{.ID}}\\t{{.Size}}" {
+		t.Fatalf("Expected the format of the environment variable, got %q", f)
+	}
+	if f := envFormat("DOCKER_TEST_FORMAT", true); f != "" {
+		t.Fatalf("Expected no format in quiet mode, got %q", f)
+	}
+	if f := envFormat("DOCKER_TEST_UNSET_FORMAT", false); f != "" {
+		t.Fatalf("Expected no format for an unset variable, got %q", f)
+	}
+}
+
+func TestCheckDaemonAPIVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ApiVersion":"1.21"}`)
+	}))
+	defer server.Close()
+	client, err := lib.NewClient(strings.Replace(server.URL, "http://", "tcp://", 1), "1.21", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cli := &DockerCli{client: client}
+
+	if err := cli.checkDaemonAPIVersion("--flag", "1.21"); err != nil {
+		t.Fatal(err)
+	}
+	expected := "--flag is not supported by the daemon: it requires API version 1.22 or later, the daemon has API version 1.21"
+	if err := cli.checkDaemonAPIVersion("--flag", "1.22"); err == nil || err.Error() != expected {
+		t.Fatalf("Expected %q, got %v", expected, err)
+	}
+}
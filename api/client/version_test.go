@@ -0,0 +1,48 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"text/template"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestVersionFormatJSON(t *testing.T) {
+	tmpl, err := template.New("").Funcs(funcMap).Parse("{{json .}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vd := types.VersionResponse{
+		Client: &types.Version{
+			Version:    "1.10.0-dev",
+			APIVersion: "1.22",
+			GoVersion:  "go1.5.2",
+			Os:         "linux",
+			Arch:       "amd64",
+		},
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, vd); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc map[string]map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("%s: %v", out.String(), err)
+	}
+	if server, ok := doc["Server"]; !ok || server != nil {
+		t.Fatalf("expected a null server, got %s", out.String())
+	}
+	client := doc["Client"]
+	for _, field := range []string{"Version", "ApiVersion", "GitCommit", "GoVersion", "Os", "Arch", "KernelVersion", "Experimental", "BuildTime"} {
+		if _, ok := client[field]; !ok {
+			t.Fatalf("expected the client's %s, got %s", field, out.String())
+		}
+	}
+	if client["ApiVersion"] != "1.22" || client["Experimental"] != false {
+		t.Fatalf("unexpected client version %s", out.String())
+	}
+}
@@ -2,8 +2,8 @@ package client
 
 import (
 	"fmt"
-	"text/tabwriter"
 
+	"github.com/docker/docker/api/client/formatter"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
 	Cli "github.com/docker/docker/cli"
@@ -45,6 +45,7 @@ func (cli *DockerCli) CmdVolumeLs(args ...string) error {
 	quiet := cmd.Bool([]string{"q", "-quiet"}, false, "Only display volume names")
 	flFilter := opts.NewListOpts(nil)
 	cmd.Var(&flFilter, []string{"f", "-filter"}, "Provide filter values (i.e. 'dangling=true')")
+	format := cmd.String([]string{"-format"}, "", "Pretty-print volumes using a Go template")
 
 	cmd.Require(flag.Exact, 0)
 	cmd.ParseFlags(args, true)
@@ -63,20 +64,24 @@ func (cli *DockerCli) CmdVolumeLs(args ...string) error {
 		return err
 	}
 
-	w := tabwriter.NewWriter(cli.out, 20, 1, 3, ' ', 0)
-	if !*quiet {
-		fmt.Fprintf(w, "DRIVER \tVOLUME NAME")
-		fmt.Fprintf(w, "\n")
+	f := *format
+	if len(f) == 0 {
+		f = envFormat("DOCKER_VOLUMES_FORMAT", *quiet)
+	}
+	if len(f) == 0 {
+		f = "table"
 	}
 
-	for _, vol := range volumes.Volumes {
-		if *quiet {
-			fmt.Fprintln(w, vol.Name)
-			continue
-		}
-		fmt.Fprintf(w, "%s\t%s\n", vol.Driver, vol.Name)
+	volumeCtx := formatter.VolumeContext{
+		Context: formatter.Context{
+			Output: cli.out,
+			Format: f,
+			Quiet:  *quiet,
+		},
+		Volumes: volumes.Volumes,
 	}
-	w.Flush()
+
+	volumeCtx.Write()
 	return nil
 }
 
@@ -131,26 +136,77 @@ func (cli *DockerCli) CmdVolumeCreate(args ...string) error {
 	return nil
 }
 
-// CmdVolumeRm removes one or more containers.
+// CmdVolumeRm removes one or more volumes.
 //
-// Usage: docker volume rm VOLUME [VOLUME...]
+// Usage: docker volume rm [OPTIONS] VOLUME [VOLUME...]
 func (cli *DockerCli) CmdVolumeRm(args ...string) error {
 	cmd := Cli.Subcmd("volume rm", []string{"VOLUME [VOLUME...]"}, "Remove a volume", true)
-	cmd.Require(flag.Min, 1)
+	flFilter := opts.NewListOpts(nil)
+	cmd.Var(&flFilter, []string{"-filter"}, "Remove the volumes matching the filter (i.e. 'dangling=true')")
+	force := cmd.Bool([]string{"f", "-force"}, false, "Do not prompt for confirmation before removing the volumes matching the filter")
 	cmd.ParseFlags(args, true)
 
-	var status = 0
-	for _, name := range cmd.Args() {
+	// At least one volume has to be given, unless volumes are selected with
+	// filters.
+	requireArgsOrFilter(cmd, flFilter)
+
+	names := cmd.Args()
+	if flFilter.Len() > 0 {
+		volFilterArgs := filters.NewArgs()
+		for _, f := range flFilter.GetAll() {
+			var err error
+			if volFilterArgs, err = filters.ParseFlag(f, volFilterArgs); err != nil {
+				return err
+			}
+		}
+		if err := volFilterArgs.Validate(acceptedVolumeRmFilters); err != nil {
+			return err
+		}
+		volumes, err := cli.client.VolumeList(volFilterArgs)
+		if err != nil {
+			return err
+		}
+		matching := volumeNames(volumes.Volumes)
+		if err := cli.confirmFilterRemoval(*force, len(matching), "volumes"); err != nil {
+			return err
+		}
+		names = appendNew(names, matching)
+	}
+
+	var (
+		errNames []string
+		removed  int
+	)
+	for _, name := range names {
 		if err := cli.client.VolumeRemove(name); err != nil {
 			fmt.Fprintf(cli.err, "%s\n", err)
-			status = 1
+			errNames = append(errNames, name)
 			continue
 		}
 		fmt.Fprintf(cli.out, "%s\n", name)
+		removed++
+	}
+	if flFilter.Len() > 0 {
+		fmt.Fprintf(cli.out, "Removed %d volumes\n", removed)
 	}
 
-	if status != 0 {
-		return Cli.StatusError{StatusCode: status}
+	if len(errNames) > 0 {
+		return fmt.Errorf("Error: failed to remove volumes: %v", errNames)
 	}
 	return nil
 }
+
+// acceptedVolumeRmFilters are the filters of docker volume rm. Volumes have
+// no labels, so they can only be selected by whether they're in use.
+var acceptedVolumeRmFilters = map[string]bool{
+	"dangling": true,
+}
+
+// volumeNames returns the names of the volumes.
+func volumeNames(volumes []*types.Volume) []string {
+	names := make([]string, 0, len(volumes))
+	for _, v := range volumes {
+		names = append(names, v.Name)
+	}
+	return names
+}
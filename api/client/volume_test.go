@@ -0,0 +1,19 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestVolumeRmNames(t *testing.T) {
+	volumes := []*types.Volume{{Name: "foo"}, {Name: "bar"}, {Name: "baz"}}
+	names := appendNew([]string{"bar", "qux"}, volumeNames(volumes))
+	if expected := []string{"bar", "qux", "foo", "baz"}; !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+	if names := appendNew(nil, volumeNames(nil)); len(names) != 0 {
+		t.Fatalf("expected no names, got %v", names)
+	}
+}
@@ -2,34 +2,121 @@ package client
 
 import (
 	"fmt"
+	"strings"
+	"text/template"
 
 	Cli "github.com/docker/docker/cli"
 	flag "github.com/docker/docker/pkg/mflag"
+	"golang.org/x/net/context"
 )
 
+// waitResult is the exit code of a container, as given to the --format
+// template of docker wait.
+type waitResult struct {
+	Container  string
+	StatusCode int
+	err        error
+}
+
 // CmdWait blocks until a container stops, then prints its exit code.
 //
-// If more than one container is specified, this will wait synchronously on each container.
+// If more than one container is specified, this will wait synchronously on each container,
+// or with --any, on all the containers at once until the first one stops.
 //
-// Usage: docker wait CONTAINER [CONTAINER...]
+// Usage: docker wait [OPTIONS] CONTAINER [CONTAINER...]
 func (cli *DockerCli) CmdWait(args ...string) error {
 	cmd := Cli.Subcmd("wait", []string{"CONTAINER [CONTAINER...]"}, Cli.DockerCommands["wait"].Description, true)
+	flAny := cmd.Bool([]string{"-any"}, false, "Return when the first container stops")
+	flAll := cmd.Bool([]string{"-all"}, false, "Return when all the containers stop (default)")
+	format := cmd.String([]string{"-format"}, "", "Pretty-print exit codes using a Go template")
+	propagate := cmd.Bool([]string{"-propagate-exit"}, false, "Exit with the exit code of the waited container")
 	cmd.Require(flag.Min, 1)
 
 	cmd.ParseFlags(args, true)
 
-	var errNames []string
+	if *flAny && *flAll {
+		return fmt.Errorf("--any and --all cannot be combined")
+	}
+
+	f := "{{.StatusCode}}"
+	if *format != "" {
+		f = strings.NewReplacer(`\t`, "\t", `\n`, "\n").Replace(*format)
+	}
+	tmpl, err := template.New("").Funcs(funcMap).Parse(f)
+	if err != nil {
+		return Cli.StatusError{StatusCode: 64,
+			Status: "Template parsing error: " + err.Error()}
+	}
+
+	if *flAny {
+		return cli.waitAny(cmd.Args(), tmpl, *propagate)
+	}
+
+	var (
+		errNames []string
+		exitCode int
+	)
 	for _, name := range cmd.Args() {
 		status, err := cli.client.ContainerWait(name)
 		if err != nil {
 			fmt.Fprintf(cli.err, "%s\n", err)
 			errNames = append(errNames, name)
-		} else {
-			fmt.Fprintf(cli.out, "%d\n", status)
+			continue
+		}
+		if err := cli.printWaitResult(tmpl, waitResult{Container: name, StatusCode: status}); err != nil {
+			return err
+		}
+		if exitCode == 0 {
+			exitCode = status
 		}
 	}
 	if len(errNames) > 0 {
 		return fmt.Errorf("Error: failed to wait containers: %v", errNames)
 	}
+	if *propagate && exitCode != 0 {
+		return Cli.StatusError{StatusCode: exitCode}
+	}
+	return nil
+}
+
+// waitAny waits for all the containers at once and prints the exit code of
+// the first one which stops. The remaining waits are canceled.
+func (cli *DockerCli) waitAny(names []string, tmpl *template.Template, propagate bool) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results := make(chan waitResult, len(names))
+	for _, name := range names {
+		go func(name string) {
+			status, err := cli.client.ContainerWaitWithContext(ctx, name)
+			results <- waitResult{Container: name, StatusCode: status, err: err}
+		}(name)
+	}
+
+	var errNames []string
+	for range names {
+		result := <-results
+		if result.err != nil {
+			fmt.Fprintf(cli.err, "%s\n", result.err)
+			errNames = append(errNames, result.Container)
+			continue
+		}
+		if err := cli.printWaitResult(tmpl, result); err != nil {
+			return err
+		}
+		if propagate && result.StatusCode != 0 {
+			return Cli.StatusError{StatusCode: result.StatusCode}
+		}
+		return nil
+	}
+	return fmt.Errorf("Error: failed to wait containers: %v", errNames)
+}
+
+func (cli *DockerCli) printWaitResult(tmpl *template.Template, result waitResult) error {
+	if err := tmpl.Execute(cli.out, result); err != nil {
+		return Cli.StatusError{StatusCode: 64,
+			Status: "Template parsing error: " + err.Error()}
+	}
+	fmt.Fprintln(cli.out)
 	return nil
 }
@@ -18,6 +18,7 @@ import (
 	derr "github.com/docker/docker/errors"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/signal"
+	"github.com/docker/docker/pkg/term"
 	"github.com/docker/docker/runconfig"
 	"github.com/docker/docker/utils"
 	"golang.org/x/net/context"
@@ -408,6 +409,14 @@ func (s *containerRouter) postContainersAttach(ctx context.Context, w http.Respo
 		return derr.ErrorCodePausedContainer.WithArgs(containerName)
 	}
 
+	var keys []byte
+	if detachKeys := r.FormValue("detachKeys"); detachKeys != "" {
+		var err error
+		if keys, err = term.ToBytes(detachKeys); err != nil {
+			return err
+		}
+	}
+
 	inStream, outStream, err := httputils.HijackConnection(w)
 	if err != nil {
 		return err
@@ -421,13 +430,14 @@ func (s *containerRouter) postContainersAttach(ctx context.Context, w http.Respo
 	}
 
 	attachWithLogsConfig := &daemon.ContainerAttachWithLogsConfig{
-		InStream:  inStream,
-		OutStream: outStream,
-		UseStdin:  httputils.BoolValue(r, "stdin"),
-		UseStdout: httputils.BoolValue(r, "stdout"),
-		UseStderr: httputils.BoolValue(r, "stderr"),
-		Logs:      httputils.BoolValue(r, "logs"),
-		Stream:    httputils.BoolValue(r, "stream"),
+		InStream:   inStream,
+		OutStream:  outStream,
+		UseStdin:   httputils.BoolValue(r, "stdin"),
+		UseStdout:  httputils.BoolValue(r, "stdout"),
+		UseStderr:  httputils.BoolValue(r, "stderr"),
+		Logs:       httputils.BoolValue(r, "logs"),
+		Stream:     httputils.BoolValue(r, "stream"),
+		DetachKeys: keys,
 	}
 
 	if err := s.backend.ContainerAttachWithLogs(containerName, attachWithLogsConfig); err != nil {
@@ -0,0 +1,69 @@
+package network
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/runconfig"
+)
+
+// acceptedNetworkFilters are the filters of GET /networks.
+var acceptedNetworkFilters = map[string]bool{
+	"driver": true,
+	"id":     true,
+	"name":   true,
+	"scope":  true,
+	"type":   true,
+}
+
+// filterNetworks returns the networks matching all the filters. A filter
+// given several values matches the networks matching any of them.
+func filterNetworks(nws []*types.NetworkResource, netFilters filters.Args) ([]*types.NetworkResource, error) {
+	if err := netFilters.Validate(acceptedNetworkFilters); err != nil {
+		return nil, err
+	}
+	err := netFilters.WalkValues("type", func(value string) error {
+		if value != "builtin" && value != "custom" {
+			return fmt.Errorf("Invalid filter: 'type'='%s'", value)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*types.NetworkResource{}
+	for _, nw := range nws {
+		if !netFilters.ExactMatch("driver", nw.Driver) ||
+			!netFilters.ExactMatch("name", nw.Name) ||
+			!netFilters.ExactMatch("scope", nw.Scope) {
+			continue
+		}
+		if netFilters.Include("id") && !matchIDPrefix(netFilters, nw.ID) {
+			continue
+		}
+		if netFilters.Include("type") {
+			networkType := "custom"
+			if runconfig.IsPreDefinedNetwork(nw.Name) {
+				networkType = "builtin"
+			}
+			if !netFilters.ExactMatch("type", networkType) {
+				continue
+			}
+		}
+		list = append(list, nw)
+	}
+	return list, nil
+}
+
+// matchIDPrefix returns true if id starts with one of the id filters.
+func matchIDPrefix(netFilters filters.Args, id string) bool {
+	for _, prefix := range netFilters.Get("id") {
+		if strings.HasPrefix(id, prefix) {
+			return true
+		}
+	}
+	return false
+}
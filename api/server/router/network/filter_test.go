@@ -0,0 +1,64 @@
+package network
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+)
+
+func TestFilterNetworks(t *testing.T) {
+	networks := []*types.NetworkResource{
+		{Name: "bridge", ID: "7fca4eb8c647", Driver: "bridge", Scope: "local"},
+		{Name: "host", ID: "cf03ee007fb4", Driver: "host", Scope: "local"},
+		{Name: "none", ID: "9f904ee27bf5", Driver: "null", Scope: "local"},
+		{Name: "mynet", ID: "95e74588f40d", Driver: "bridge", Scope: "local"},
+		{Name: "multi-host", ID: "78b03ee04fc4", Driver: "overlay", Scope: "global"},
+	}
+
+	cases := []struct {
+		filters  map[string][]string
+		expected []string
+	}{
+		{nil, []string{"bridge", "host", "none", "mynet", "multi-host"}},
+		{map[string][]string{"driver": {"bridge"}}, []string{"bridge", "mynet"}},
+		{map[string][]string{"driver": {"bridge", "overlay"}}, []string{"bridge", "mynet", "multi-host"}},
+		{map[string][]string{"scope": {"global"}}, []string{"multi-host"}},
+		{map[string][]string{"type": {"builtin"}}, []string{"bridge", "host", "none"}},
+		{map[string][]string{"type": {"custom"}}, []string{"mynet", "multi-host"}},
+		{map[string][]string{"type": {"custom"}, "driver": {"bridge"}}, []string{"mynet"}},
+		{map[string][]string{"name": {"host", "mynet"}}, []string{"host", "mynet"}},
+		{map[string][]string{"id": {"9f9", "78b"}}, []string{"none", "multi-host"}},
+		{map[string][]string{"name": {"unknown"}}, nil},
+	}
+	for _, c := range cases {
+		args := filters.NewArgs()
+		for field, values := range c.filters {
+			for _, value := range values {
+				args.Add(field, value)
+			}
+		}
+		list, err := filterNetworks(networks, args)
+		if err != nil {
+			t.Fatalf("%v: %v", c.filters, err)
+		}
+		var names []string
+		for _, nw := range list {
+			names = append(names, nw.Name)
+		}
+		if strings.Join(names, " ") != strings.Join(c.expected, " ") {
+			t.Fatalf("%v: expected %v, got %v", c.filters, c.expected, names)
+		}
+	}
+}
+
+func TestFilterNetworksInvalid(t *testing.T) {
+	for field, value := range map[string]string{"label": "a=b", "type": "foo"} {
+		args := filters.NewArgs()
+		args.Add(field, value)
+		if _, err := filterNetworks(nil, args); err == nil {
+			t.Fatalf("%s=%s: expected an error", field, value)
+		}
+	}
+}
@@ -2,8 +2,8 @@ package system
 
 import (
 	"github.com/docker/docker/api/types"
+	eventtypes "github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
-	"github.com/docker/docker/pkg/jsonmessage"
 )
 
 // Backend is the methods that need to be implemented to provide
@@ -11,7 +11,7 @@ import (
 type Backend interface {
 	SystemInfo() (*types.Info, error)
 	SystemVersion() types.Version
-	SubscribeToEvents(since, sinceNano int64, ef filters.Args) ([]*jsonmessage.JSONMessage, chan interface{})
+	SubscribeToEvents(since, sinceNano int64, ef filters.Args) ([]*eventtypes.Message, chan interface{})
 	UnsubscribeFromEvents(chan interface{})
 	AuthenticateToRegistry(authConfig *types.AuthConfig) (string, error)
 }
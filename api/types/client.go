@@ -2,11 +2,13 @@ package types
 
 import (
 	"bufio"
+	"encoding/json"
 	"io"
 	"net"
 
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/pkg/ulimit"
+	"github.com/docker/docker/pkg/version"
 	"github.com/docker/docker/runconfig"
 )
 
@@ -17,6 +19,7 @@ type ContainerAttachOptions struct {
 	Stdin       bool
 	Stdout      bool
 	Stderr      bool
+	DetachKeys  string
 }
 
 // ContainerCommitOptions holds parameters to commit changes into a container.
@@ -146,6 +149,13 @@ type ImageBuildResponse struct {
 	OSType string
 }
 
+// ImageLoadResponse returns information to the client about a load process.
+type ImageLoadResponse struct {
+	Body io.ReadCloser
+	// JSON is set if Body is a JSON stream rather than plain text.
+	JSON bool
+}
+
 // ImageCreateOptions holds information to create images.
 type ImageCreateOptions struct {
 	// Parent is the image to create this image from
@@ -231,3 +241,47 @@ type VersionResponse struct {
 func (v VersionResponse) ServerOK() bool {
 	return v.Server != nil
 }
+
+// versionJSON is the JSON representation of a Version in a VersionResponse.
+// Unlike the one of Version, every field is always present, so that tools
+// can rely on the document's schema.
+type versionJSON struct {
+	Version       string
+	APIVersion    version.Version `json:"ApiVersion"`
+	GitCommit     string
+	GoVersion     string
+	Os            string
+	Arch          string
+	KernelVersion string
+	Experimental  bool
+	BuildTime     string
+}
+
+func newVersionJSON(v *Version) *versionJSON {
+	if v == nil {
+		return nil
+	}
+	return &versionJSON{
+		Version:       v.Version,
+		APIVersion:    v.APIVersion,
+		GitCommit:     v.GitCommit,
+		GoVersion:     v.GoVersion,
+		Os:            v.Os,
+		Arch:          v.Arch,
+		KernelVersion: v.KernelVersion,
+		Experimental:  v.Experimental,
+		BuildTime:     v.BuildTime,
+	}
+}
+
+// MarshalJSON encodes the client and the server versions with all their
+// fields. Server is null if the client couldn't get the server's version.
+func (v VersionResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Client *versionJSON
+		Server *versionJSON
+	}{
+		Client: newVersionJSON(v.Client),
+		Server: newVersionJSON(v.Server),
+	})
+}
@@ -116,10 +116,14 @@ func (filters Args) Add(name, value string) {
 	}
 }
 
-// Del removes a value from a filter field.
+// Del removes a value from a filter field. The field is removed with its
+// last value.
 func (filters Args) Del(name, value string) {
 	if _, ok := filters.fields[name]; ok {
 		delete(filters.fields[name], value)
+		if len(filters.fields[name]) == 0 {
+			delete(filters.fields, name)
+		}
 	}
 }
 
@@ -261,6 +261,9 @@ func TestDel(t *testing.T) {
 	if v["running"] {
 		t.Fatalf("Expected to not include a running status filter, got true")
 	}
+	if f.Include("status") {
+		t.Fatalf("Expected to not include the status field without values")
+	}
 }
 
 func TestLen(t *testing.T) {
@@ -11,24 +11,13 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/builder"
+	"github.com/docker/docker/builder/dockerfile/command"
 	"github.com/docker/docker/builder/dockerfile/parser"
 	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/docker/pkg/ulimit"
 	"github.com/docker/docker/runconfig"
 )
 
-var validCommitCommands = map[string]bool{
-	"cmd":        true,
-	"entrypoint": true,
-	"env":        true,
-	"expose":     true,
-	"label":      true,
-	"onbuild":    true,
-	"user":       true,
-	"volume":     true,
-	"workdir":    true,
-}
-
 // BuiltinAllowedBuildArgs is list of built-in allowed build args
 var BuiltinAllowedBuildArgs = map[string]bool{
 	"HTTP_PROXY":  true,
@@ -214,7 +203,7 @@ func BuildFromConfig(config *runconfig.Config, changes []string) (*runconfig.Con
 
 	// ensure that the commands are valid
 	for _, n := range ast.Children {
-		if !validCommitCommands[n.Value] {
+		if _, ok := command.CommitCommands[n.Value]; !ok {
 			return nil, fmt.Errorf("%s is not a valid change command", n.Value)
 		}
 	}
@@ -40,3 +40,17 @@ var Commands = map[string]struct{}{
 	StopSignal: {},
 	Arg:        {},
 }
+
+// CommitCommands is the list of Dockerfile commands which can be applied to
+// an image with the changes of docker commit and docker import.
+var CommitCommands = map[string]struct{}{
+	Cmd:        {},
+	Entrypoint: {},
+	Env:        {},
+	Expose:     {},
+	Label:      {},
+	Onbuild:    {},
+	User:       {},
+	Volume:     {},
+	Workdir:    {},
+}
@@ -1,6 +1,10 @@
 package cli
 
-import flag "github.com/docker/docker/pkg/mflag"
+import (
+	"time"
+
+	flag "github.com/docker/docker/pkg/mflag"
+)
 
 // ClientFlags represents flags for the docker client.
 type ClientFlags struct {
@@ -8,5 +12,7 @@ type ClientFlags struct {
 	Common    *CommonFlags
 	PostParse func()
 
-	ConfigDir string
+	ConfigDir      string
+	RequestTimeout time.Duration
+	RequestRetries int
 }
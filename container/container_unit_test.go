@@ -1,6 +1,8 @@
 package container
 
 import (
+	"bytes"
+	"io"
 	"testing"
 
 	"github.com/docker/docker/pkg/signal"
@@ -34,3 +36,50 @@ func TestContainerStopSignal(t *testing.T) {
 		t.Fatalf("Expected 9, got %v", s)
 	}
 }
+
+// chunkReader returns one chunk per Read call, like keys typed on a TTY.
+type chunkReader struct {
+	chunks []string
+	closed bool
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if len(r.chunks) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.chunks[0])
+	r.chunks = r.chunks[1:]
+	return n, nil
+}
+
+func (r *chunkReader) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestCopyEscapable(t *testing.T) {
+	cases := []struct {
+		keys     []byte
+		chunks   []string
+		expected string
+		detached bool
+	}{
+		{nil, []string{"ls\n", "\x10", "\x11", "ignored"}, "ls\n", true},
+		{nil, []string{"\x10", "x", "\x11"}, "\x10x\x11", false},
+		{[]byte{1, 'd'}, []string{"\x10", "\x11", "\x01", "d"}, "\x10\x11", true},
+		{[]byte{1, 2, 3}, []string{"\x01", "\x02", "y"}, "\x01\x02y", false},
+	}
+	for _, c := range cases {
+		src := &chunkReader{chunks: c.chunks}
+		dst := new(bytes.Buffer)
+		if _, err := copyEscapable(dst, src, c.keys); err != nil {
+			t.Fatal(err)
+		}
+		if dst.String() != c.expected {
+			t.Fatalf("keys %v, input %q: expected %q, got %q", c.keys, c.chunks, c.expected, dst.String())
+		}
+		if src.closed != c.detached {
+			t.Fatalf("keys %v, input %q: expected detached to be %v", c.keys, c.chunks, c.detached)
+		}
+	}
+}
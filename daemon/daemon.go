@@ -22,6 +22,7 @@ import (
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api"
 	"github.com/docker/docker/api/types"
+	eventtypes "github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 	registrytypes "github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/container"
@@ -46,7 +47,6 @@ import (
 	"github.com/docker/docker/pkg/fileutils"
 	"github.com/docker/docker/pkg/graphdb"
 	"github.com/docker/docker/pkg/idtools"
-	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/docker/docker/pkg/mount"
 	"github.com/docker/docker/pkg/namesgenerator"
 	"github.com/docker/docker/pkg/nat"
@@ -550,11 +550,13 @@ func (daemon *Daemon) GetByName(name string) (*container.Container, error) {
 // getEventFilter returns a filters.Filter for a set of filters
 func (daemon *Daemon) getEventFilter(filter filters.Args) *events.Filter {
 	// incoming container filter can be name, id or partial id, convert to
-	// a full container id
+	// a full container id. Containers which don't exist anymore are kept
+	// as given, so that they only match the events of that id instead of
+	// leaving the filter empty.
 	for _, cn := range filter.Get("container") {
 		c, err := daemon.GetContainer(cn)
-		filter.Del("container", cn)
 		if err == nil {
+			filter.Del("container", cn)
 			filter.Add("container", c.ID)
 		}
 	}
@@ -562,7 +564,7 @@ func (daemon *Daemon) getEventFilter(filter filters.Args) *events.Filter {
 }
 
 // SubscribeToEvents returns the currently record of events, a channel to stream new events from, and a function to cancel the stream of events.
-func (daemon *Daemon) SubscribeToEvents(since, sinceNano int64, filter filters.Args) ([]*jsonmessage.JSONMessage, chan interface{}) {
+func (daemon *Daemon) SubscribeToEvents(since, sinceNano int64, filter filters.Args) ([]*eventtypes.Message, chan interface{}) {
 	ef := daemon.getEventFilter(filter)
 	return daemon.EventsService.SubscribeTopic(since, sinceNano, ef)
 }
@@ -1048,7 +1050,7 @@ func (daemon *Daemon) TagImage(newTag reference.Named, imageName string) error {
 	if err := daemon.tagStore.AddTag(newTag, imageID, true); err != nil {
 		return err
 	}
-	daemon.EventsService.Log("tag", newTag.String(), "")
+	daemon.LogImageEvent(newTag.String(), newTag.String(), "tag")
 	return nil
 }
 
@@ -1208,15 +1210,21 @@ func (daemon *Daemon) LookupImage(name string) (*types.ImageInspect, error) {
 
 	imageInspect.GraphDriver.Data = layerMetadata
 
+	imageInspect.RootFS.Type = img.RootFS.Type
+	for _, l := range img.RootFS.DiffIDs {
+		imageInspect.RootFS.Layers = append(imageInspect.RootFS.Layers, l.String())
+	}
+
 	return imageInspect, nil
 }
 
 // LoadImage uploads a set of images into the repository. This is the
 // complement of ImageExport.  The input stream is an uncompressed tar
-// ball containing images and metadata.
-func (daemon *Daemon) LoadImage(inTar io.ReadCloser, outStream io.Writer) error {
+// ball containing images and metadata. Unless quiet is set, the progress of
+// the load is written to outStream as a JSON stream.
+func (daemon *Daemon) LoadImage(inTar io.ReadCloser, outStream io.Writer, quiet bool) error {
 	imageExporter := tarexport.NewTarExporter(daemon.imageStore, daemon.layerStore, daemon.tagStore)
-	return imageExporter.Load(inTar, outStream)
+	return imageExporter.Load(inTar, outStream, quiet)
 }
 
 // ImageHistory returns a slice of ImageHistory structures for the specified image
@@ -1,14 +1,35 @@
 package daemon
 
 import (
+	"strings"
+
+	eventtypes "github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/container"
 )
 
 // LogContainerEvent generates an event related to a container.
 func (daemon *Daemon) LogContainerEvent(container *container.Container, action string) {
-	daemon.EventsService.Log(
-		action,
-		container.ID,
-		container.Config.Image,
-	)
+	attributes := map[string]string{
+		"image": container.Config.Image,
+		"name":  strings.TrimLeft(container.Name, "/"),
+	}
+	actor := eventtypes.Actor{
+		ID:         container.ID,
+		Attributes: attributes,
+	}
+	daemon.EventsService.LogEvent(action, eventtypes.ContainerEventType, actor)
+}
+
+// LogImageEvent generates an event related to an image, refName being the
+// reference the image was acted on with, if any.
+func (daemon *Daemon) LogImageEvent(imageID, refName, action string) {
+	var attributes map[string]string
+	if refName != "" {
+		attributes = map[string]string{"name": refName}
+	}
+	actor := eventtypes.Actor{
+		ID:         imageID,
+		Attributes: attributes,
+	}
+	daemon.EventsService.LogEvent(action, eventtypes.ImageEventType, actor)
 }
@@ -4,7 +4,7 @@ import (
 	"sync"
 	"time"
 
-	"github.com/docker/docker/pkg/jsonmessage"
+	eventtypes "github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/pkg/pubsub"
 )
 
@@ -13,17 +13,17 @@ const (
 	bufferSize  = 1024
 )
 
-// Events is pubsub channel for *jsonmessage.JSONMessage
+// Events is pubsub channel for events generated by the engine.
 type Events struct {
 	mu     sync.Mutex
-	events []*jsonmessage.JSONMessage
+	events []*eventtypes.Message
 	pub    *pubsub.Publisher
 }
 
 // New returns new *Events instance
 func New() *Events {
 	return &Events{
-		events: make([]*jsonmessage.JSONMessage, 0, eventsLimit),
+		events: make([]*eventtypes.Message, 0, eventsLimit),
 		pub:    pubsub.NewPublisher(100*time.Millisecond, bufferSize),
 	}
 }
@@ -32,9 +32,9 @@ func New() *Events {
 // last events, a channel in which you can expect new events (in form
 // of interface{}, so you need type assertion), and a function to call
 // to stop the stream of events.
-func (e *Events) Subscribe() ([]*jsonmessage.JSONMessage, chan interface{}, func()) {
+func (e *Events) Subscribe() ([]*eventtypes.Message, chan interface{}, func()) {
 	e.mu.Lock()
-	current := make([]*jsonmessage.JSONMessage, len(e.events))
+	current := make([]*eventtypes.Message, len(e.events))
 	copy(current, e.events)
 	l := e.pub.Subscribe()
 	e.mu.Unlock()
@@ -48,13 +48,13 @@ func (e *Events) Subscribe() ([]*jsonmessage.JSONMessage, chan interface{}, func
 // SubscribeTopic adds new listener to events, returns slice of 64 stored
 // last events, a channel in which you can expect new events (in form
 // of interface{}, so you need type assertion).
-func (e *Events) SubscribeTopic(since, sinceNano int64, ef *Filter) ([]*jsonmessage.JSONMessage, chan interface{}) {
+func (e *Events) SubscribeTopic(since, sinceNano int64, ef *Filter) ([]*eventtypes.Message, chan interface{}) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	var buffered []*jsonmessage.JSONMessage
+	var buffered []*eventtypes.Message
 	topic := func(m interface{}) bool {
-		return ef.Include(m.(*jsonmessage.JSONMessage))
+		return ef.Include(m.(*eventtypes.Message))
 	}
 
 	if since != -1 {
@@ -64,7 +64,7 @@ func (e *Events) SubscribeTopic(since, sinceNano int64, ef *Filter) ([]*jsonmess
 				break
 			}
 			if ef.filter.Len() == 0 || topic(ev) {
-				buffered = append([]*jsonmessage.JSONMessage{ev}, buffered...)
+				buffered = append([]*eventtypes.Message{ev}, buffered...)
 			}
 		}
 	}
@@ -88,8 +88,28 @@ func (e *Events) Evict(l chan interface{}) {
 // Log broadcasts event to listeners. Each listener has 100 millisecond for
 // receiving event or it will be skipped.
 func (e *Events) Log(action, id, from string) {
+	var attributes map[string]string
+	if from != "" {
+		attributes = map[string]string{"image": from}
+	}
+	e.LogEvent(action, "", eventtypes.Actor{ID: id, Attributes: attributes})
+}
+
+// LogEvent broadcasts an event of the given type to listeners. The
+// deprecated status, id and from fields are filled from the action and the
+// actor, so that older clients keep working.
+func (e *Events) LogEvent(action, eventType string, actor eventtypes.Actor) {
 	now := time.Now().UTC()
-	jm := &jsonmessage.JSONMessage{Status: action, ID: id, From: from, Time: now.Unix(), TimeNano: now.UnixNano()}
+	jm := &eventtypes.Message{
+		Status:   action,
+		ID:       actor.ID,
+		From:     actor.Attributes["image"],
+		Type:     eventType,
+		Action:   action,
+		Actor:    actor,
+		Time:     now.Unix(),
+		TimeNano: now.UnixNano(),
+	}
 	e.mu.Lock()
 	if len(e.events) == cap(e.events) {
 		// discard oldest event
@@ -5,7 +5,8 @@ import (
 	"testing"
 	"time"
 
-	"github.com/docker/docker/pkg/jsonmessage"
+	eventtypes "github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
 )
 
 func TestEventsLog(t *testing.T) {
@@ -21,7 +22,7 @@ func TestEventsLog(t *testing.T) {
 	e.Log("test", "cont", "image")
 	select {
 	case msg := <-l1:
-		jmsg, ok := msg.(*jsonmessage.JSONMessage)
+		jmsg, ok := msg.(*eventtypes.Message)
 		if !ok {
 			t.Fatalf("Unexpected type %T", msg)
 		}
@@ -42,7 +43,7 @@ func TestEventsLog(t *testing.T) {
 	}
 	select {
 	case msg := <-l2:
-		jmsg, ok := msg.(*jsonmessage.JSONMessage)
+		jmsg, ok := msg.(*eventtypes.Message)
 		if !ok {
 			t.Fatalf("Unexpected type %T", msg)
 		}
@@ -103,10 +104,10 @@ func TestLogEvents(t *testing.T) {
 		t.Fatalf("Must be %d events, got %d", eventsLimit, len(e.events))
 	}
 
-	var msgs []*jsonmessage.JSONMessage
+	var msgs []*eventtypes.Message
 	for len(msgs) < 10 {
 		m := <-l
-		jm, ok := (m).(*jsonmessage.JSONMessage)
+		jm, ok := (m).(*eventtypes.Message)
 		if !ok {
 			t.Fatalf("Unexpected type %T", m)
 		}
@@ -133,3 +134,46 @@ func TestLogEvents(t *testing.T) {
 		t.Fatalf("Last action is %s, must be action_89", lastC.Status)
 	}
 }
+
+func TestLogEvent(t *testing.T) {
+	e := New()
+	_, l, _ := e.Subscribe()
+	defer e.Evict(l)
+
+	actor := eventtypes.Actor{
+		ID:         "cont",
+		Attributes: map[string]string{"image": "busybox", "name": "web"},
+	}
+	e.LogEvent("start", eventtypes.ContainerEventType, actor)
+	select {
+	case msg := <-l:
+		ev, ok := msg.(*eventtypes.Message)
+		if !ok {
+			t.Fatalf("Unexpected type %T", msg)
+		}
+		if ev.Type != eventtypes.ContainerEventType || ev.Action != "start" {
+			t.Fatalf("Type and Action should be container and start, got %s and %s", ev.Type, ev.Action)
+		}
+		if ev.Actor.ID != "cont" || ev.Actor.Attributes["name"] != "web" {
+			t.Fatalf("Unexpected actor %+v", ev.Actor)
+		}
+		if ev.Status != "start" || ev.ID != "cont" || ev.From != "busybox" {
+			t.Fatalf("Deprecated fields should be start, cont and busybox, got %s, %s and %s", ev.Status, ev.ID, ev.From)
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatal("Timeout waiting for broadcasted message")
+	}
+}
+
+func TestFilterByType(t *testing.T) {
+	args := filters.NewArgs()
+	args.Add("type", eventtypes.ImageEventType)
+	ef := NewFilter(args, func(string) map[string]string { return nil })
+
+	if ef.Include(&eventtypes.Message{Type: eventtypes.ContainerEventType, Status: "start", ID: "cont"}) {
+		t.Fatal("Container events should be filtered out by type=image")
+	}
+	if !ef.Include(&eventtypes.Message{Type: eventtypes.ImageEventType, Status: "tag", ID: "busybox:latest"}) {
+		t.Fatal("Image events should be included by type=image")
+	}
+}
@@ -2,8 +2,8 @@ package events
 
 import (
 	"github.com/docker/distribution/reference"
+	eventtypes "github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
-	"github.com/docker/docker/pkg/jsonmessage"
 )
 
 // Filter can filter out docker events from a stream
@@ -18,8 +18,9 @@ func NewFilter(filter filters.Args, getLabels func(id string) map[string]string)
 }
 
 // Include returns true when the event ev is included by the filters
-func (ef *Filter) Include(ev *jsonmessage.JSONMessage) bool {
+func (ef *Filter) Include(ev *eventtypes.Message) bool {
 	return ef.filter.ExactMatch("event", ev.Status) &&
+		ef.filter.ExactMatch("type", ev.Type) &&
 		ef.filter.ExactMatch("container", ev.ID) &&
 		ef.isImageIncluded(ev.ID, ev.From) &&
 		ef.isLabelFieldIncluded(ev.ID)
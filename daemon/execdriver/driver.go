@@ -110,6 +110,7 @@ type ResourceStats struct {
 	Read        time.Time `json:"read"`
 	MemoryLimit int64     `json:"memory_limit"`
 	SystemUsage uint64    `json:"system_usage"`
+	Pids        uint64    `json:"pids"`
 }
 
 // CommonProcessConfig is the common platform agnostic part of the ProcessConfig
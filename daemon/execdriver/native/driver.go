@@ -3,6 +3,7 @@
 package native
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -391,13 +392,38 @@ func (d *Driver) Stats(id string) (*execdriver.ResourceStats, error) {
 	if memoryLimit == 0 {
 		memoryLimit = d.machineMemory
 	}
+	// A failure to count the tasks doesn't fail the whole stats stream.
+	pids, err := countTasks(c)
+	if err != nil {
+		logrus.Errorf("Error counting the tasks of container %s: %v", id, err)
+	}
 	return &execdriver.ResourceStats{
 		Stats:       stats,
 		Read:        now,
 		MemoryLimit: memoryLimit,
+		Pids:        pids,
 	}, nil
 }
 
+// countTasks returns the number of tasks of the container, read from the
+// tasks file of its devices cgroup, which every container joins. It's cheaper
+// than listing the processes of all the sub-cgroups for every stats frame.
+func countTasks(c libcontainer.Container) (uint64, error) {
+	state, err := c.State()
+	if err != nil {
+		return 0, err
+	}
+	dir, ok := state.CgroupPaths["devices"]
+	if !ok {
+		return 0, fmt.Errorf("no devices cgroup")
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, "tasks"))
+	if err != nil {
+		return 0, err
+	}
+	return uint64(bytes.Count(data, []byte("\n"))), nil
+}
+
 // TtyConsole implements the exec driver Terminal interface.
 type TtyConsole struct {
 	console libcontainer.Console
@@ -10,6 +10,7 @@ import (
 	"syscall"
 
 	"github.com/docker/docker/daemon/execdriver"
+	dockerutils "github.com/docker/docker/utils"
 	"github.com/opencontainers/runc/libcontainer"
 	// Blank import 'nsenter' so that init in that package will call c
 	// function 'nsexec()' to do 'setns' before Go runtime take over,
@@ -33,9 +34,15 @@ func (d *Driver) Exec(c *execdriver.Command, processConfig *execdriver.ProcessCo
 		user = "0"
 	}
 
+	// The exec's environment variables override the container's.
+	env := append([]string{}, c.ProcessConfig.Env...)
+	if len(processConfig.Env) > 0 {
+		env = dockerutils.ReplaceOrAppendEnvValues(env, processConfig.Env)
+	}
+
 	p := &libcontainer.Process{
 		Args: append([]string{processConfig.Entrypoint}, processConfig.Arguments...),
-		Env:  c.ProcessConfig.Env,
+		Env:  env,
 		Cwd:  c.WorkingDir,
 		User: user,
 	}
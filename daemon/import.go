@@ -98,6 +98,6 @@ func (daemon *Daemon) ImportImage(src string, newRef reference.Named, msg string
 	}
 
 	outStream.Write(sf.FormatStatus("", id.String()))
-	daemon.EventsService.Log("import", id.String(), "")
+	daemon.LogImageEvent(id.String(), "", "import")
 	return nil
 }
@@ -47,6 +47,7 @@ func (daemon *Daemon) ContainerStats(prefixOrName string, config *ContainerStats
 		ss.MemoryStats.Limit = uint64(update.MemoryLimit)
 		ss.Read = update.Read
 		ss.CPUStats.SystemUsage = update.SystemUsage
+		ss.PidsStats.Current = update.Pids
 		preCPUStats = ss.CPUStats
 		return ss
 	}
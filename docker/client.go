@@ -1,7 +1,10 @@
 package main
 
 import (
+	"fmt"
+	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cliconfig"
@@ -13,10 +16,21 @@ var clientFlags = &cli.ClientFlags{FlagSet: new(flag.FlagSet), Common: commonFla
 func init() {
 	client := clientFlags.FlagSet
 	client.StringVar(&clientFlags.ConfigDir, []string{"-config"}, cliconfig.ConfigDir(), "Location of client config files")
+	client.DurationVar(&clientFlags.RequestTimeout, []string{"-request-timeout"}, 0, "Time to wait for the daemon to respond to GET requests, 0 to wait forever")
+	client.IntVar(&clientFlags.RequestRetries, []string{"-request-retries"}, 0, "Number of times GET requests are retried when the connection fails")
 
 	clientFlags.PostParse = func() {
 		clientFlags.Common.PostParse()
 
+		if timeout := os.Getenv("DOCKER_CLIENT_TIMEOUT"); timeout != "" && !client.IsSet("-request-timeout") {
+			d, err := time.ParseDuration(timeout)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Unable to parse DOCKER_CLIENT_TIMEOUT: %s\n", timeout)
+				os.Exit(1)
+			}
+			clientFlags.RequestTimeout = d
+		}
+
 		if clientFlags.ConfigDir != "" {
 			cliconfig.SetConfigDir(clientFlags.ConfigDir)
 		}
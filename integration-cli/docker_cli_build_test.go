@@ -3501,6 +3501,42 @@ dir`,
 	}
 }
 
+func (s *DockerSuite) TestBuildContextExclude(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	name := "testbuildcontextexclude"
+	dockerfile := `
+        FROM busybox
+        ADD . /bla
+		RUN [[ -f /bla/src/x.go ]]
+		RUN [[ ! -e /bla/.git ]]
+		RUN [[ ! -e /bla/build ]]
+		RUN [[ ! -e /bla/src/x.log ]]`
+	ctx, err := fakeContext(dockerfile, map[string]string{
+		".git/HEAD":     "ref: foo",
+		"build/out":     "",
+		"src/x.go":      "package main",
+		"src/x.log":     "",
+		".dockerignore": ".git",
+	})
+	if err != nil {
+		c.Fatal(err)
+	}
+	defer ctx.Close()
+	if _, err := buildImageFromContext(name, ctx, true, "--build-context-exclude", "build", "--build-context-exclude", "**/*.log"); err != nil {
+		c.Fatal(err)
+	}
+}
+
+func (s *DockerSuite) TestBuildContextExcludeAbsolutePath(c *check.C) {
+	ctx, err := fakeContext("FROM busybox", nil)
+	c.Assert(err, check.IsNil)
+	defer ctx.Close()
+
+	out, _, err := dockerCmdInDir(c, ctx.Dir, "build", "--build-context-exclude", "/etc", ".")
+	c.Assert(err, check.NotNil, check.Commentf("%s", out))
+	c.Assert(out, checker.Contains, "pattern must be relative to the context directory")
+}
+
 func (s *DockerSuite) TestBuildDockerignoreCleanPaths(c *check.C) {
 	testRequires(c, DaemonIsLinux)
 	name := "testbuilddockerignorecleanpaths"
@@ -6623,3 +6659,23 @@ func (s *DockerSuite) TestBuildCacheRootSource(c *check.C) {
 
 	c.Assert(out, checker.Not(checker.Contains), "Using cache")
 }
+
+func (s *DockerSuite) TestBuildProgressJSON(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	name := "testbuildprogressjson"
+	_, stdout, _, err := buildImageWithStdoutStderr(name, "FROM busybox\nRUN echo hello", false, "--progress=json")
+	c.Assert(err, check.IsNil)
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	c.Assert(len(lines), checker.GreaterThan, 1, check.Commentf("%s", stdout))
+	for _, line := range lines {
+		var msg map[string]interface{}
+		c.Assert(json.Unmarshal([]byte(line), &msg), check.IsNil, check.Commentf("line %q is not a JSON message", line))
+	}
+}
+
+func (s *DockerSuite) TestBuildProgressInvalid(c *check.C) {
+	out, _, err := dockerCmdWithError("build", "--progress=fancy", ".")
+	c.Assert(err, check.NotNil)
+	c.Assert(out, checker.Contains, `"fancy" is not a valid value for --progress`)
+}
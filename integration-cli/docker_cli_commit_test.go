@@ -1,6 +1,8 @@
 package main
 
 import (
+	"io/ioutil"
+	"os"
 	"strings"
 
 	"github.com/docker/docker/pkg/integration/checker"
@@ -147,6 +149,46 @@ func (s *DockerSuite) TestCommitChange(c *check.C) {
 	}
 }
 
+func (s *DockerSuite) TestCommitChangeFile(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	dockerCmd(c, "run", "--name", "test-change-file", "busybox", "true")
+
+	changeFile, err := ioutil.TempFile("", "test-commit-change-file")
+	c.Assert(err, checker.IsNil)
+	defer os.Remove(changeFile.Name())
+	_, err = changeFile.WriteString("# set up the environment\nENV B 2\n\nENV C 3\nWORKDIR /opt\n")
+	c.Assert(err, checker.IsNil)
+	c.Assert(changeFile.Close(), checker.IsNil)
+
+	// The changes are applied in the order of the flags.
+	imageID, _ := dockerCmd(c, "commit",
+		"--change", "ENV A 1",
+		"--change-file", changeFile.Name(),
+		"--change", "ENV D 4",
+		"test-change-file", "test-commit-change-file")
+	imageID = strings.TrimSpace(imageID)
+
+	res, err := inspectField(imageID, "Config.Env")
+	c.Assert(err, checker.IsNil)
+	c.Assert(res, checker.Contains, "A=1 B=2 C=3 D=4")
+	res, err = inspectField(imageID, "Config.WorkingDir")
+	c.Assert(err, checker.IsNil)
+	c.Assert(res, checker.Equals, "/opt")
+}
+
+func (s *DockerSuite) TestCommitChangeFileInvalidCommand(c *check.C) {
+	changeFile, err := ioutil.TempFile("", "test-commit-change-file")
+	c.Assert(err, checker.IsNil)
+	defer os.Remove(changeFile.Name())
+	_, err = changeFile.WriteString("ENV A 1\nRUN make\n")
+	c.Assert(err, checker.IsNil)
+	c.Assert(changeFile.Close(), checker.IsNil)
+
+	out, _, err := dockerCmdWithError("commit", "--change-file", changeFile.Name(), "foo")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "line 2: RUN is not a valid change command")
+}
+
 // TODO: commit --run is deprecated, remove this once --run is removed
 func (s *DockerSuite) TestCommitMergeConfigRun(c *check.C) {
 	testRequires(c, DaemonIsLinux)
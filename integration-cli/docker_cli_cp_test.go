@@ -663,3 +663,61 @@ func (s *DockerSuite) TestCpSymlinkFromConToHostFollowSymlink(c *check.C) {
 	}
 	defer os.Remove(expectedPath)
 }
+
+func (s *DockerSuite) TestCpProgress(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, _ := dockerCmd(c, "run", "-d", "busybox", "/bin/sh", "-c", "mkdir -p '"+cpTestPath+"' && echo -n '"+cpContainerContents+"' > "+cpFullPath)
+	containerID := strings.TrimSpace(out)
+	dockerCmd(c, "wait", containerID)
+
+	// The progress goes to STDERR, STDOUT only has the archive.
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(dockerBinary, "cp", "--progress", containerID+":"+cpFullPath, "-")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	c.Assert(cmd.Run(), checker.IsNil, check.Commentf("stderr: %s", stderr.String()))
+	c.Assert(stderr.String(), checker.Contains, "Copied ")
+	c.Assert(stdout.String(), checker.Contains, cpContainerContents)
+	c.Assert(stdout.String(), checker.Not(checker.Contains), "Copied ")
+
+	tmpDir := getTestDir(c, "test-cp-progress")
+	defer os.RemoveAll(tmpDir)
+	hostFile := filepath.Join(tmpDir, "file")
+	c.Assert(ioutil.WriteFile(hostFile, []byte(cpHostContents), 0644), checker.IsNil)
+
+	out, errOut, _, err := runCommandWithStdoutStderr(exec.Command(dockerBinary, "cp", "--progress", hostFile, containerID+":/tmp"))
+	c.Assert(err, checker.IsNil)
+	c.Assert(out, checker.Equals, "")
+	c.Assert(errOut, checker.Contains, "Copied ")
+}
+
+// Check that -L follows a chain of symbolic links, and that copying a link
+// to a directory onto a file is a clear error in both directions.
+func (s *DockerSuite) TestCpFollowSymlinkChain(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, _ := dockerCmd(c, "run", "-d", "busybox", "/bin/sh", "-c", "mkdir -p '"+cpTestPath+"' && echo -n '"+cpContainerContents+"' > "+cpFullPath+" && ln -s "+cpFullPath+" /link1 && ln -s /link1 /link2 && ln -s "+cpTestPath+" /dir_link && touch /file")
+	containerID := strings.TrimSpace(out)
+	dockerCmd(c, "wait", containerID)
+
+	testDir := getTestDir(c, "test-cp-follow-symlink-chain")
+	defer os.RemoveAll(testDir)
+
+	dockerCmd(c, "cp", "-L", containerID+":/link2", testDir)
+	c.Assert(fileContentEquals(c, filepath.Join(testDir, "link2"), cpContainerContents), checker.IsNil)
+
+	// Without -L the link itself is copied.
+	dockerCmd(c, "cp", containerID+":/link2", filepath.Join(testDir, "nofollow"))
+	c.Assert(symlinkTargetEquals(c, filepath.Join(testDir, "nofollow"), "/link1"), checker.IsNil)
+
+	hostFile := filepath.Join(testDir, "file")
+	c.Assert(ioutil.WriteFile(hostFile, []byte(cpHostContents), 0644), checker.IsNil)
+	out, _, err := dockerCmdWithError("cp", "-L", containerID+":/dir_link", hostFile)
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, `the destination is a file ("/dir_link" is a symbolic link to the directory)`)
+
+	hostDirLink := filepath.Join(testDir, "host_dir_link")
+	c.Assert(os.Symlink(testDir, hostDirLink), checker.IsNil)
+	out, _, err = dockerCmdWithError("cp", "-L", hostDirLink, containerID+":/file")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "the destination is a file")
+}
@@ -85,3 +85,26 @@ func (s *DockerSuite) TestDiffEmptyArgClientError(c *check.C) {
 	c.Assert(err, checker.NotNil)
 	c.Assert(strings.TrimSpace(out), checker.Equals, "Container name cannot be empty")
 }
+
+func (s *DockerSuite) TestDiffFilterAndFormat(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, _ := dockerCmd(c, "run", "-d", "busybox", "sh", "-c", "touch /added && echo changed >> /etc/passwd && rm /etc/shadow")
+	cleanCID := strings.TrimSpace(out)
+	dockerCmd(c, "wait", cleanCID)
+
+	out, _ = dockerCmd(c, "diff", "--filter", "added", "--format", "{{.Path}}", cleanCID)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	c.Assert(lines, checker.HasLen, 1, check.Commentf("out: %s", out))
+	c.Assert(lines[0], checker.Equals, "/added")
+
+	out, _ = dockerCmd(c, "diff", "-f", "changed", "-f", "deleted", cleanCID)
+	c.Assert(out, checker.Contains, "C /etc/passwd\n")
+	c.Assert(out, checker.Contains, "D /etc/shadow\n")
+	c.Assert(out, checker.Not(checker.Contains), "A ")
+}
+
+func (s *DockerSuite) TestDiffInvalidFilter(c *check.C) {
+	out, _, err := dockerCmdWithError("diff", "--filter", "removed", "foo")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, `invalid filter "removed": expected added, changed or deleted`)
+}
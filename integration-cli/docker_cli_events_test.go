@@ -46,6 +46,53 @@ func (s *DockerSuite) TestEventsTimestampFormats(c *check.C) {
 
 }
 
+func (s *DockerSuite) TestEventsInvalidTimes(c *check.C) {
+	out, _, err := dockerCmdWithError("events", "--since", "30x")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, `invalid value "30x" for --since`)
+
+	out, _, err = dockerCmdWithError("events", "--since", "1m", "--until", "2m")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "must be after --since")
+}
+
+func (s *DockerSuite) TestEventsFormat(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	since := daemonTime(c).Unix()
+	dockerCmd(c, "run", "--name", "events-format", "busybox", "true")
+
+	out, _ := dockerCmd(c, "events", fmt.Sprintf("--since=%d", since), fmt.Sprintf("--until=%d", daemonTime(c).Unix()+1),
+		"--filter", "event=start", "--format", "{{.Type}} {{.Action}} {{.Actor.Attributes.name}} {{.Actor.Attributes.image}}")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "container start events-format busybox")
+}
+
+func (s *DockerSuite) TestEventsFilterType(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	since := daemonTime(c).Unix()
+	dockerCmd(c, "run", "--name", "events-type", "busybox", "true")
+	dockerCmd(c, "tag", "busybox", "eventstype:tag1")
+	dockerCmd(c, "rmi", "eventstype:tag1")
+	until := daemonTime(c).Unix() + 1
+
+	out, _ := dockerCmd(c, "events", fmt.Sprintf("--since=%d", since), fmt.Sprintf("--until=%d", until),
+		"--filter", "type=image", "--format", "{{.Type}} {{.Action}}")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "image tag\nimage untag")
+
+	out, _ = dockerCmd(c, "events", fmt.Sprintf("--since=%d", since), fmt.Sprintf("--until=%d", until),
+		"--filter", "type=container", "--filter", "container=events-type", "--filter", "event=die", "--format", "{{.Type}} {{.Action}} {{.Actor.Attributes.name}}")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "container die events-type")
+}
+
+func (s *DockerSuite) TestEventsFilterInvalid(c *check.C) {
+	out, _, err := dockerCmdWithError("events", "--filter", "foo=bar")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "Invalid filter 'foo'")
+
+	out, _, err = dockerCmdWithError("events", "--filter", "type=foo")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, `invalid filter 'type': "foo"`)
+}
+
 func (s *DockerSuite) TestEventsUntag(c *check.C) {
 	testRequires(c, DaemonIsLinux)
 	image := "busybox"
@@ -5,6 +5,7 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"os/exec"
@@ -524,3 +525,51 @@ func (s *DockerSuite) TestExecStartFails(c *check.C) {
 	c.Assert(err, checker.NotNil, check.Commentf(out))
 	c.Assert(out, checker.Contains, "executable file not found")
 }
+
+func (s *DockerSuite) TestExecEnvFlags(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	dockerCmd(c, "run", "-d", "--name", "testing", "-e", "FOO=container", "-e", "BAR=container", "busybox", "top")
+
+	envFile, err := ioutil.TempFile("", "exec-env-file")
+	c.Assert(err, checker.IsNil)
+	defer os.Remove(envFile.Name())
+	_, err = envFile.WriteString("# comment\nFOO=file\nBAZ=file\n")
+	c.Assert(err, checker.IsNil)
+	envFile.Close()
+
+	out, _ := dockerCmd(c, "exec", "--env-file", envFile.Name(), "-e", "BAZ=flag", "testing", "env")
+	c.Assert(out, checker.Contains, "FOO=file\n")
+	c.Assert(out, checker.Contains, "BAR=container\n")
+	c.Assert(out, checker.Contains, "BAZ=flag\n")
+	c.Assert(out, checker.Not(checker.Contains), "FOO=container")
+
+	// the exec environment doesn't leak into the container's
+	out, _ = dockerCmd(c, "exec", "testing", "env")
+	c.Assert(out, checker.Contains, "FOO=container\n")
+	c.Assert(out, checker.Not(checker.Contains), "BAZ=")
+}
+
+func (s *DockerSuite) TestExecEnvFileInvalidLine(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	dockerCmd(c, "run", "-d", "--name", "testing", "busybox", "top")
+
+	envFile, err := ioutil.TempFile("", "exec-env-file")
+	c.Assert(err, checker.IsNil)
+	defer os.Remove(envFile.Name())
+	_, err = envFile.WriteString("FOO=bar\nnot valid\n")
+	c.Assert(err, checker.IsNil)
+	envFile.Close()
+
+	out, _, err := dockerCmdWithError("exec", "--env-file", envFile.Name(), "testing", "env")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "has white spaces on line 2")
+}
+
+func (s *DockerSuite) TestExecInvalidDetachKeys(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	dockerCmd(c, "run", "-d", "--name", "testing", "busybox", "top")
+
+	out, _, err := dockerCmdWithError("exec", "-it", "--detach-keys", "ctrl-1,a", "testing", "sh")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "invalid key \"ctrl-1\"")
+}
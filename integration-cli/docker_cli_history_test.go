@@ -124,3 +124,23 @@ func (s *DockerSuite) TestHistoryHumanOptionTrue(c *check.C) {
 		c.Assert(strings.TrimSpace(sizeString), checker.Matches, humanSizeRegexRaw, check.Commentf("The size '%s' was not in human format", sizeString))
 	}
 }
+
+func (s *DockerSuite) TestHistoryFormat(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	name := "testhistoryformat"
+	longCmd := "echo " + strings.Repeat("a", 60)
+	_, err := buildImage(name, "FROM busybox\nRUN "+longCmd, true)
+	c.Assert(err, checker.IsNil)
+
+	out, _ := dockerCmd(c, "history", "--format", "{{.CreatedBy}}", name)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	c.Assert(len(lines[0]), checker.Equals, 45, check.Commentf("expected a truncated command, got %q", lines[0]))
+
+	out, _ = dockerCmd(c, "history", "--no-trunc", "--format", "{{.CreatedBy}}|{{.Size}}", name)
+	lines = strings.Split(strings.TrimSpace(out), "\n")
+	c.Assert(lines[0], checker.Contains, longCmd+"|")
+
+	out, _ = dockerCmd(c, "history", "--format", "table {{.ID}}\t{{.Comment}}", name)
+	lines = strings.Split(strings.TrimSpace(out), "\n")
+	c.Assert(strings.Fields(lines[0]), checker.DeepEquals, []string{"IMAGE", "COMMENT"})
+}
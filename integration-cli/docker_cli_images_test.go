@@ -105,6 +105,12 @@ func (s *DockerSuite) TestImagesFilterLabelMatch(c *check.C) {
 	c.Assert(out, check.Equals, image2ID)
 }
 
+func (s *DockerSuite) TestImagesFilterLabelInvalidFormat(c *check.C) {
+	out, _, err := dockerCmdWithError("images", "-f", "label==me")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "invalid filter 'label': expected key or key=value")
+}
+
 // Regression : #15659
 func (s *DockerSuite) TestImagesFilterLabelWithCommit(c *check.C) {
 	// Create a container
@@ -233,3 +239,36 @@ func (s *DockerSuite) TestImagesFilterNameWithPort(c *check.C) {
 	out, _ = dockerCmd(c, "images", tag+":no-such-tag")
 	c.Assert(out, checker.Not(checker.Contains), tag)
 }
+
+func (s *DockerSuite) TestImagesFormat(c *check.C) {
+	tag := "myimage"
+	dockerCmd(c, "tag", "busybox", tag+":v1")
+	dockerCmd(c, "tag", "busybox", tag+":v2")
+
+	out, _ := dockerCmd(c, "images", "--format", "{{.Repository}}", tag)
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+
+	expected := []string{"myimage", "myimage"}
+	c.Assert(lines, checker.DeepEquals, expected, check.Commentf("Expected repositories: %v, got: %v", expected, lines))
+
+	out, _ = dockerCmd(c, "images", "--format", "table {{.Repository}}\t{{.Tag}}", tag)
+	lines = strings.Split(strings.TrimSpace(string(out)), "\n")
+	c.Assert(lines[0], checker.Matches, `REPOSITORY\s+TAG`)
+}
+
+func (s *DockerSuite) TestImagesFilterReference(c *check.C) {
+	dockerCmd(c, "tag", "busybox", "myrepo/app:v1.0")
+	dockerCmd(c, "tag", "busybox", "myrepo/app:v2.0")
+	dockerCmd(c, "tag", "busybox", "mybusybox:latest")
+
+	out, _ := dockerCmd(c, "images", "--format", "{{.Repository}}:{{.Tag}}", "--filter", "reference=myrepo/*:v1.*")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "myrepo/app:v1.0")
+
+	out, _ = dockerCmd(c, "images", "--format", "{{.Repository}}:{{.Tag}}", "--filter", "reference=busybox")
+	c.Assert(out, checker.Contains, "busybox:latest")
+	c.Assert(out, checker.Not(checker.Contains), "mybusybox")
+
+	out, _, err := dockerCmdWithError("images", "--filter", "reference=[")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "invalid filter 'reference'")
+}
@@ -91,6 +91,52 @@ func (s *DockerSuite) TestImportFileWithMessage(c *check.C) {
 	c.Assert(out, checker.Equals, "", check.Commentf("command output should've been nothing"))
 }
 
+func (s *DockerSuite) TestImportFileWithChangeFileAndMessageFile(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	dockerCmd(c, "run", "--name", "test-import-change-file", "busybox", "true")
+
+	temporaryFile, err := ioutil.TempFile("", "exportImportTest")
+	c.Assert(err, checker.IsNil, check.Commentf("failed to create temporary file"))
+	defer os.Remove(temporaryFile.Name())
+
+	runCmd := exec.Command(dockerBinary, "export", "test-import-change-file")
+	runCmd.Stdout = bufio.NewWriter(temporaryFile)
+	_, err = runCommand(runCmd)
+	c.Assert(err, checker.IsNil, check.Commentf("failed to export a container"))
+
+	changeFile, err := ioutil.TempFile("", "test-import-change-file")
+	c.Assert(err, checker.IsNil)
+	defer os.Remove(changeFile.Name())
+	_, err = changeFile.WriteString("# set up the environment\nENV B 2\nWORKDIR /opt\n")
+	c.Assert(err, checker.IsNil)
+	c.Assert(changeFile.Close(), checker.IsNil)
+
+	message := "Imported from a file"
+	messageFile, err := ioutil.TempFile("", "test-import-message")
+	c.Assert(err, checker.IsNil)
+	defer os.Remove(messageFile.Name())
+	_, err = messageFile.WriteString(message + "\n")
+	c.Assert(err, checker.IsNil)
+	c.Assert(messageFile.Close(), checker.IsNil)
+
+	out, _ := dockerCmd(c, "import",
+		"--change", "ENV A 1",
+		"--change-file", changeFile.Name(),
+		"-m", "@"+messageFile.Name(),
+		temporaryFile.Name())
+	image := strings.TrimSpace(out)
+
+	res, err := inspectField(image, "Config.Env")
+	c.Assert(err, checker.IsNil)
+	c.Assert(res, checker.Contains, "A=1 B=2")
+	res, err = inspectField(image, "Config.WorkingDir")
+	c.Assert(err, checker.IsNil)
+	c.Assert(res, checker.Equals, "/opt")
+	res, err = inspectField(image, "Comment")
+	c.Assert(err, checker.IsNil)
+	c.Assert(res, checker.Equals, message)
+}
+
 func (s *DockerSuite) TestImportFileNonExistentFile(c *check.C) {
 	_, _, err := dockerCmdWithError("import", "example.com/myImage.tar")
 	c.Assert(err, checker.NotNil, check.Commentf("import non-existing file must failed"))
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
+	"strings"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/pkg/integration/checker"
 	"github.com/docker/docker/utils"
 	"github.com/go-check/check"
@@ -101,3 +104,20 @@ func (s *DockerSuite) TestInfoDiscoveryAdvertiseInterfaceName(c *check.C) {
 	c.Assert(out, checker.Contains, fmt.Sprintf("Cluster store: %s\n", discoveryBackend))
 	c.Assert(out, checker.Contains, fmt.Sprintf("Cluster advertise: %s:2375\n", ip.String()))
 }
+
+func (s *DockerSuite) TestInfoFormat(c *check.C) {
+	out, _ := dockerCmd(c, "info", "--format", "{{.Driver}}")
+	c.Assert(strings.TrimSpace(out), checker.Not(checker.Equals), "")
+
+	out, _ = dockerCmd(c, "info", "--format", "{{json .}}")
+	var info types.Info
+	c.Assert(json.Unmarshal([]byte(out), &info), checker.IsNil, check.Commentf("%s", out))
+	c.Assert(info.ID, checker.Not(checker.Equals), "")
+	c.Assert(info.MemTotal > 0, checker.True)
+	c.Assert(out, checker.Contains, `"Labels":[`)
+	c.Assert(out, checker.Contains, `"DriverStatus":[`)
+
+	out, _, err := dockerCmdWithError("info", "--format", "{{.Driver")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "Template parsing error")
+}
@@ -367,3 +367,42 @@ func (s *DockerSuite) TestInspectStopWhenNotFound(c *check.C) {
 	c.Assert(out, checker.Not(checker.Contains), "not-shown")
 	c.Assert(out, checker.Contains, "Error: No such container: missing")
 }
+
+func (s *DockerSuite) TestInspectContinueOnError(c *check.C) {
+	dockerCmd(c, "run", "--name=busybox", "-d", "busybox", "top")
+	dockerCmd(c, "run", "--name=shown", "-d", "busybox", "top")
+	out, exitCode, err := dockerCmdWithError("inspect", "--continue-on-error", "--format='{{.Name}}'", "busybox", "missing", "shown")
+
+	c.Assert(err, checker.Not(check.IsNil))
+	c.Assert(exitCode, checker.Equals, 1)
+	c.Assert(out, checker.Contains, "busybox")
+	c.Assert(out, checker.Contains, "shown")
+	c.Assert(out, checker.Contains, "Error: No such image or container: missing")
+}
+
+func (s *DockerSuite) TestInspectFormatArray(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	dockerCmd(c, "run", "--name=inspect-array", "-d", "busybox", "top")
+	out, _ := dockerCmd(c, "inspect", "--format-array", "--format={{range .}}{{.Config.Image}},{{end}}{{len .}}", "inspect-array", "busybox")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "busybox,,2")
+}
+
+func (s *DockerSuite) TestInspectFormatArrayWithoutFormat(c *check.C) {
+	out, _, err := dockerCmdWithError("inspect", "--format-array", "busybox")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "--format-array requires --format")
+}
+
+func (s *DockerSuite) TestInspectImageLayerCount(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, _ := dockerCmd(c, "inspect", "--format={{.LayerCount}} {{len .RootFS.Layers}}", "busybox")
+	fields := strings.Fields(out)
+	c.Assert(fields, checker.HasLen, 2)
+	c.Assert(fields[0], checker.Equals, fields[1])
+	c.Assert(fields[0], checker.Not(checker.Equals), "0")
+
+	size, err := inspectField("busybox", "VirtualSize")
+	c.Assert(err, checker.IsNil)
+	out, _ = dockerCmd(c, "inspect", "--format={{.TotalSize}}", "busybox")
+	c.Assert(strings.TrimSpace(out), checker.Equals, size)
+}
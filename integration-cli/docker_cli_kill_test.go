@@ -84,6 +84,11 @@ func (s *DockerSuite) TestKillWithInvalidSignal(c *check.C) {
 	running, _ = inspectField(cid, "State.Running")
 	c.Assert(running, checker.Equals, "true", check.Commentf("Container should be in running state after an invalid signal"))
 
+	// The signal is checked before contacting the daemon
+	out, _, err = dockerCmdWithError("kill", "-s", "SIGFOO", "doesnotexist")
+	c.Assert(err, check.NotNil)
+	c.Assert(out, checker.Contains, "Invalid signal: SIGFOO")
+	c.Assert(out, checker.Not(checker.Contains), "No such container")
 }
 
 func (s *DockerSuite) TestKillStoppedContainerAPIPre120(c *check.C) {
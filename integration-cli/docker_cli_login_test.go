@@ -19,3 +19,19 @@ func (s *DockerSuite) TestLoginWithoutTTY(c *check.C) {
 	c.Assert(err, checker.NotNil) //"Expected non nil err when loginning in & TTY not available"
 
 }
+
+func (s *DockerSuite) TestLoginPasswordStdinInvalid(c *check.C) {
+	out, _, err := dockerCmdWithError("login", "-u", "user", "-p", "secret", "--password-stdin")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "--password and --password-stdin are mutually exclusive")
+
+	out, _, err = dockerCmdWithError("login", "--password-stdin")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "Must provide --username with --password-stdin")
+
+	cmd := exec.Command(dockerBinary, "login", "-u", "user", "--password-stdin")
+	cmd.Stdin = bytes.NewBufferString("")
+	out, _, err = runCommandWithOutput(cmd)
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "Password Required")
+}
@@ -352,3 +352,60 @@ func (s *DockerSuite) TestLogsCLIContainerNotFound(c *check.C) {
 	message := fmt.Sprintf("Error: No such container: %s\n", name)
 	c.Assert(out, checker.Equals, message)
 }
+
+func (s *DockerSuite) TestLogsGrep(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, _ := dockerCmd(c, "run", "-d", "busybox", "sh", "-c", "echo keep 1; echo drop 1; echo keep 2 1>&2; echo drop 2 1>&2")
+
+	id := strings.TrimSpace(out)
+	dockerCmd(c, "wait", id)
+
+	stdout, stderr, _ := dockerCmdWithStdoutStderr(c, "logs", "--grep", "^keep", id)
+	c.Assert(stdout, checker.Equals, "keep 1\n")
+	c.Assert(stderr, checker.Equals, "keep 2\n")
+
+	stdout, stderr, _ = dockerCmdWithStdoutStderr(c, "logs", "--grep-v", "^keep", id)
+	c.Assert(stdout, checker.Equals, "drop 1\n")
+	c.Assert(stderr, checker.Equals, "drop 2\n")
+}
+
+func (s *DockerSuite) TestLogsGrepInvalidPattern(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, _ := dockerCmd(c, "run", "-d", "busybox", "true")
+
+	id := strings.TrimSpace(out)
+	out, _, err := dockerCmdWithError("logs", "--grep", "(", id)
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "invalid --grep pattern")
+}
+
+func (s *DockerSuite) TestLogsTimestampsFormat(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, _ := dockerCmd(c, "run", "-d", "busybox", "sh", "-c", "echo out; echo err 1>&2")
+
+	id := strings.TrimSpace(out)
+	dockerCmd(c, "wait", id)
+
+	stdout, stderr, _ := dockerCmdWithStdoutStderr(c, "logs", "--timestamps=relative", id)
+	c.Assert(stdout, checker.Matches, `\+00:00:\d\d out\n`)
+	c.Assert(stderr, checker.Matches, `\+00:00:\d\d err\n`)
+
+	out, _ = dockerCmd(c, "logs", "--timestamps=unix", id)
+	c.Assert(out, checker.Matches, `\d+\.\d{9} (out|err)\n\d+\.\d{9} (out|err)\n`)
+
+	out, _ = dockerCmd(c, "logs", "--timestamps=rfc3339", id)
+	for _, l := range strings.Split(strings.TrimSpace(out), "\n") {
+		_, err := time.Parse(time.RFC3339, strings.Fields(l)[0])
+		c.Assert(err, checker.IsNil, check.Commentf("Failed to parse timestamp from %v", l))
+	}
+}
+
+func (s *DockerSuite) TestLogsTimestampsInvalidFormat(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, _ := dockerCmd(c, "run", "-d", "busybox", "true")
+
+	id := strings.TrimSpace(out)
+	out, _, err := dockerCmdWithError("logs", "--timestamps=compact", id)
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "invalid timestamps format")
+}
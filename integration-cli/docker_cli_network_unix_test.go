@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/docker/docker/api/types"
@@ -257,6 +258,48 @@ func (s *DockerNetworkSuite) TestDockerNetworkLsDefault(c *check.C) {
 	}
 }
 
+func (s *DockerNetworkSuite) TestDockerNetworkLsFormat(c *check.C) {
+	out, _ := dockerCmd(c, "network", "ls", "--format", "{{.Name}}|{{.Driver}}|{{.Scope}}")
+	c.Assert(out, checker.Contains, "bridge|bridge|local\n")
+	c.Assert(out, checker.Contains, "host|host|local\n")
+	c.Assert(out, checker.Not(checker.Contains), "NETWORK ID")
+
+	out, _ = dockerCmd(c, "network", "ls", "--no-trunc", "--format", "table {{.ID}}\t{{.Name}}")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	c.Assert(lines[0], checker.Matches, "NETWORK ID +NAME")
+	for _, line := range lines[1:] {
+		c.Assert(strings.Fields(line)[0], checker.HasLen, 64, check.Commentf("%s", out))
+	}
+}
+
+func (s *DockerNetworkSuite) TestDockerNetworkLsFilter(c *check.C) {
+	dockerCmd(c, "network", "create", "testfilter")
+	assertNwIsAvailable(c, "testfilter")
+
+	out, _ := dockerCmd(c, "network", "ls", "-f", "type=custom", "--format", "{{.Name}}")
+	names := strings.Fields(out)
+	c.Assert(names, checker.Contains, "testfilter")
+	c.Assert(names, checker.Not(checker.Contains), "bridge")
+
+	out, _ = dockerCmd(c, "network", "ls", "-f", "type=builtin", "-f", "driver=bridge", "--format", "{{.Name}}")
+	c.Assert(strings.Fields(out), checker.DeepEquals, []string{"bridge"})
+
+	out, _ = dockerCmd(c, "network", "ls", "-f", "name=host", "-f", "name=none", "--format", "{{.Name}}")
+	names = strings.Fields(out)
+	sort.Strings(names)
+	c.Assert(names, checker.DeepEquals, []string{"host", "none"})
+
+	out, _, err := dockerCmdWithError("network", "ls", "-f", "drvier=bridge")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "Invalid filter 'drvier'")
+
+	out, _, err = dockerCmdWithError("network", "ls", "-f", "scope=host")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "Invalid filter 'scope=host'")
+
+	dockerCmd(c, "network", "rm", "testfilter")
+}
+
 func (s *DockerNetworkSuite) TestDockerNetworkCreateDelete(c *check.C) {
 	dockerCmd(c, "network", "create", "test")
 	assertNwIsAvailable(c, "test")
@@ -487,13 +530,23 @@ func (s *DockerNetworkSuite) TestDockerNetworkIpamInvalidCombinations(c *check.C
 	_, _, err := dockerCmdWithError("network", "create", "--subnet=192.168.0.0/16", "--ip-range=192.170.0.0/16", "test")
 	c.Assert(err, check.NotNil)
 
+	// network with ip-range larger than the subnet
+	_, _, err = dockerCmdWithError("network", "create", "--subnet=192.168.0.0/24", "--ip-range=192.168.0.0/16", "test")
+	c.Assert(err, check.NotNil)
+
+	// network with gateway out of subnet range
+	out, _, err := dockerCmdWithError("network", "create", "--subnet=192.168.0.0/16", "--gateway=192.170.0.1", "test")
+	c.Assert(err, check.NotNil)
+	c.Assert(out, checker.Contains, "gateway 192.170.0.1 is not in subnet 192.168.0.0/16")
+
 	// network with multiple gateways for a single subnet
 	_, _, err = dockerCmdWithError("network", "create", "--subnet=192.168.0.0/16", "--gateway=192.168.0.1", "--gateway=192.168.0.2", "test")
 	c.Assert(err, check.NotNil)
 
 	// Multiple overlapping subnets in the same network must fail
-	_, _, err = dockerCmdWithError("network", "create", "--subnet=192.168.0.0/16", "--subnet=192.168.1.0/16", "test")
+	out, _, err = dockerCmdWithError("network", "create", "--subnet=192.168.0.0/16", "--subnet=192.168.1.0/16", "test")
 	c.Assert(err, check.NotNil)
+	c.Assert(out, checker.Contains, "subnet 192.168.1.0/16 overlaps with subnet 192.168.0.0/16")
 
 	// overlapping subnets across networks must fail
 	// create a valid test0 network
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"regexp"
@@ -293,3 +294,29 @@ func (s *DockerSuite) TestPortExposeHostBinding(c *check.C) {
 	// Port is still bound after the Container is removed
 	c.Assert(err, checker.NotNil, check.Commentf("out: %s", out))
 }
+
+func (s *DockerSuite) TestPortFormatAndJSON(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, _ := dockerCmd(c, "run", "-d", "-p", "9877:80", "--expose", "8080", "busybox", "top")
+	id := strings.TrimSpace(out)
+
+	out, _ = dockerCmd(c, "port", "--format", "{{.ContainerPort}}/{{.Protocol}}={{.HostIP}}:{{.HostPort}}", id)
+	c.Assert(strings.Split(strings.TrimSpace(out), "\n"), checker.DeepEquals, []string{"80/tcp=0.0.0.0:9877", "8080/tcp=:"})
+
+	out, _ = dockerCmd(c, "port", "--json", id, "8080")
+	var unpublished []map[string]string
+	c.Assert(json.Unmarshal([]byte(out), &unpublished), checker.IsNil, check.Commentf(out))
+	c.Assert(unpublished, checker.DeepEquals, []map[string]string{
+		{"containerPort": "8080", "protocol": "tcp", "hostIp": "", "hostPort": ""},
+	})
+
+	// The unpublished port is still an error without --json or --format
+	_, _, err := dockerCmdWithError("port", id, "8080")
+	c.Assert(err, checker.NotNil)
+
+	out, _, err = dockerCmdWithError("port", "--json", "--format", "{{.HostPort}}", id)
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "--json and --format cannot be combined")
+
+	dockerCmd(c, "rm", "-f", id)
+}
@@ -203,6 +203,13 @@ func (s *DockerSuite) TestPsListContainersFilterStatus(c *check.C) {
 	out, _ = dockerCmd(c, "ps", "--no-trunc", "-q", "--filter=status=paused")
 	containerOut = strings.TrimSpace(out)
 	c.Assert(containerOut, checker.Equals, pausedID)
+
+	// filter containers by several statuses at once
+	out, _ = dockerCmd(c, "ps", "--no-trunc", "-q", "--filter=status=running", "--filter=status=paused")
+	containerOut = strings.TrimSpace(out)
+	c.Assert(containerOut, checker.Contains, secondID)
+	c.Assert(containerOut, checker.Contains, pausedID)
+	c.Assert(containerOut, checker.Not(checker.Contains), firstID)
 }
 
 func (s *DockerSuite) TestPsListContainersFilterID(c *check.C) {
@@ -491,7 +498,11 @@ func (s *DockerSuite) TestPsWithSize(c *check.C) {
 	dockerCmd(c, "run", "-d", "--name", "sizetest", "busybox", "top")
 
 	out, _ := dockerCmd(c, "ps", "--size")
-	c.Assert(out, checker.Contains, "virtual", check.Commentf("docker ps with --size should show virtual size of container"))
+	c.Assert(out, checker.Contains, "VIRTUAL SIZE", check.Commentf("docker ps with --size should show virtual size of container"))
+
+	out, _ = dockerCmd(c, "ps", "--size", "--size-format", "combined")
+	c.Assert(out, checker.Contains, "virtual", check.Commentf("docker ps with --size-format combined should show virtual size of container in the size column"))
+	c.Assert(out, checker.Not(checker.Contains), "VIRTUAL SIZE")
 }
 
 func (s *DockerSuite) TestPsListContainersFilterCreated(c *check.C) {
@@ -638,3 +649,27 @@ func (s *DockerSuite) TestPsImageIDAfterUpdate(c *check.C) {
 	}
 
 }
+
+func (s *DockerSuite) TestPsFormatMounts(c *check.C) {
+	testRequires(c, DaemonIsLinux, SameHostDaemon)
+	dockerCmd(c, "run", "-d", "--name", "mounttest", "-v", "mountedvolume:/data", "busybox", "top")
+
+	out, _ := dockerCmd(c, "ps", "--format", "{{.Names}} {{.Mounts}}", "--filter", "name=mounttest")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "mounttest mountedvolume")
+}
+
+func (s *DockerSuite) TestPsConflictLastAndLatest(c *check.C) {
+	out, _, err := dockerCmdWithError("ps", "-n=2", "-l")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "Conflicting options: -n and -l")
+}
+
+func (s *DockerSuite) TestPsLatestPerImage(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	dockerCmd(c, "run", "-d", "busybox", "true")
+	out, _ := dockerCmd(c, "run", "-d", "busybox", "true")
+	latestID := strings.TrimSpace(out)
+
+	out, _ = dockerCmd(c, "ps", "-a", "--no-trunc", "-q", "--latest-per-image", "--filter", "ancestor=busybox")
+	c.Assert(strings.TrimSpace(out), checker.Equals, latestID)
+}
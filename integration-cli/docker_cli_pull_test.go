@@ -58,6 +58,32 @@ func (s *DockerHubPullSuite) TestPullNonExistingImage(c *check.C) {
 	}
 }
 
+// TestPullMultipleImages pulls several images in one invocation and verifies that a failing image
+// doesn't prevent the others from being pulled, while still making the command fail.
+func (s *DockerHubPullSuite) TestPullMultipleImages(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, err := s.CmdWithError("pull", "hello-world", "asdfasdf:foobar", "busybox")
+	defer deleteImages("hello-world", "busybox")
+	c.Assert(err, checker.NotNil, check.Commentf("expected non-zero exit status when one of the images doesn't exist: %s", out))
+	c.Assert(out, checker.Contains, "Pulling hello-world")
+	c.Assert(out, checker.Contains, "Pulling asdfasdf:foobar")
+	c.Assert(out, checker.Contains, "Pulling busybox")
+	c.Assert(out, checker.Contains, "failed to pull images: [asdfasdf:foobar]")
+
+	img := s.Cmd(c, "images")
+	c.Assert(img, checker.Contains, "hello-world")
+	c.Assert(img, checker.Contains, "busybox")
+}
+
+// TestPullMultipleImagesInvalidReference verifies that nothing is pulled when one of the
+// references is invalid.
+func (s *DockerHubPullSuite) TestPullMultipleImagesInvalidReference(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, err := s.CmdWithError("pull", "hello-world", "INVALID_NAME")
+	c.Assert(err, checker.NotNil, check.Commentf("expected non-zero exit status for an invalid reference: %s", out))
+	c.Assert(out, checker.Not(checker.Contains), "Pulling hello-world")
+}
+
 // TestPullFromCentralRegistryImplicitRefParts pulls an image from the central registry and verifies
 // that pulling the same image with different combinations of implicit elements of the the image
 // reference (tag, repository, central registry url, ...) doesn't trigger a new pull nor leads to
@@ -83,6 +83,28 @@ func (s *DockerRegistrySuite) TestPushMultipleTags(c *check.C) {
 	}
 }
 
+func (s *DockerRegistrySuite) TestPushAllTags(c *check.C) {
+	repoName := fmt.Sprintf("%v/dockercli/busybox", privateRegistryURL)
+	dockerCmd(c, "tag", "busybox", repoName+":t2")
+	dockerCmd(c, "tag", "busybox", repoName+":t1")
+
+	out, _ := dockerCmd(c, "push", "--all-tags", repoName)
+	c.Assert(out, checker.Contains, fmt.Sprintf("Pushing 2 tags of %s: t1, t2", repoName))
+
+	// Both tags must be pullable from the registry.
+	dockerCmd(c, "rmi", repoName+":t1", repoName+":t2")
+	dockerCmd(c, "pull", repoName+":t1")
+	dockerCmd(c, "pull", repoName+":t2")
+}
+
+func (s *DockerRegistrySuite) TestPushAllTagsWithTag(c *check.C) {
+	repoName := fmt.Sprintf("%v/dockercli/busybox:latest", privateRegistryURL)
+
+	out, _, err := dockerCmdWithError("push", "--all-tags", repoName)
+	c.Assert(err, check.NotNil, check.Commentf("pushing a tag with --all-tags should have failed: output %q", out))
+	c.Assert(out, checker.Contains, "tag can't be used with --all-tags/-a")
+}
+
 func (s *DockerRegistrySuite) TestPushEmptyLayer(c *check.C) {
 	repoName := fmt.Sprintf("%v/dockercli/emptylayer", privateRegistryURL)
 	emptyTarball, err := ioutil.TempFile("", "empty_tarball")
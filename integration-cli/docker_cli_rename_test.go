@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/docker/docker/pkg/integration/checker"
@@ -94,3 +95,26 @@ func (s *DockerSuite) TestRenameInvalidName(c *check.C) {
 	out, _ = dockerCmd(c, "ps", "-a")
 	c.Assert(out, checker.Contains, "myname", check.Commentf("Output of docker ps should have included 'myname': %s", out))
 }
+
+func (s *DockerSuite) TestRenameNameInUse(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, _ := dockerCmd(c, "run", "--name", "first_name", "-d", "busybox", "top")
+	firstID := strings.TrimSpace(out)
+	out, _ = dockerCmd(c, "run", "--name", "second_name", "-d", "busybox", "top")
+	secondID := strings.TrimSpace(out)
+
+	out, _, err := dockerCmdWithError("rename", "first_name", "second_name")
+	c.Assert(err, checker.NotNil, check.Commentf("Renaming container to a name in use should have failed: %s", out))
+	c.Assert(out, checker.Contains, fmt.Sprintf("name \"second_name\" is already in use by container %s", stringid.TruncateID(secondID)))
+
+	// A name which only contains the other name isn't in use
+	dockerCmd(c, "rename", "first_name", "second")
+	dockerCmd(c, "rename", firstID, "first_name")
+
+	// Renaming a container to its own name succeeds
+	dockerCmd(c, "rename", "first_name", "first_name")
+	dockerCmd(c, "rename", firstID, "first_name")
+	name, err := inspectField(firstID, "Name")
+	c.Assert(err, checker.IsNil)
+	c.Assert(name, checker.Equals, "/first_name")
+}
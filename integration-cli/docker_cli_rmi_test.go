@@ -337,3 +337,45 @@ func (s *DockerSuite) TestRmiWithParentInUse(c *check.C) {
 
 	dockerCmd(c, "rmi", imageID)
 }
+
+func (s *DockerSuite) TestRmiDryRun(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	name := "rmi-dry-run"
+	id, err := buildImage(name, "FROM busybox\nLABEL rmi=dry-run", true)
+	c.Assert(err, checker.IsNil)
+	dockerCmd(c, "tag", name, name+":other")
+
+	out, _ := dockerCmd(c, "rmi", "--dry-run", name+":other")
+	c.Assert(out, checker.Equals, fmt.Sprintf("Would untag: %s:other\n", name))
+
+	out, _ = dockerCmd(c, "rmi", "--dry-run", name+":other", name)
+	c.Assert(out, checker.Equals, fmt.Sprintf("Would untag: %s:other\nWould untag: %s:latest\nWould delete: %s\n", name, name, id))
+
+	// Nothing was removed.
+	images, _ := dockerCmd(c, "images", name)
+	c.Assert(images, checker.Contains, "other")
+	c.Assert(images, checker.Contains, "latest")
+}
+
+func (s *DockerSuite) TestRmiFilter(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	id1, err := buildImage("rmi-filter-1", "FROM busybox\nLABEL rmi.filter=yes", true)
+	c.Assert(err, checker.IsNil)
+	id2, err := buildImage("rmi-filter-2", "FROM busybox\nLABEL rmi.filter=no", true)
+	c.Assert(err, checker.IsNil)
+
+	out, _ := dockerCmd(c, "rmi", "--force", "--filter", "label=rmi.filter=yes")
+	c.Assert(out, checker.Contains, "Untagged: rmi-filter-1:latest")
+	c.Assert(out, checker.Contains, "Deleted: "+id1)
+	c.Assert(out, checker.Contains, "Removed 1 images, reclaimed")
+
+	images, _ := dockerCmd(c, "images", "-q", "--no-trunc")
+	c.Assert(images, checker.Not(checker.Contains), id1)
+	c.Assert(images, checker.Contains, id2)
+}
+
+func (s *DockerSuite) TestRmiNoArgsNoFilter(c *check.C) {
+	out, _, err := dockerCmdWithError("rmi")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, `"rmi" requires a minimum of 1 argument or a --filter`)
+}
@@ -1021,6 +1021,14 @@ func (s *DockerSuite) TestRunAddingOptionalDevicesInvalidMode(c *check.C) {
 	}
 }
 
+func (s *DockerSuite) TestRunAddingOptionalDevicesRelativePath(c *check.C) {
+	// Not applicable on Windows as Windows does not support --device
+	testRequires(c, DaemonIsLinux)
+	out, _, err := dockerCmdWithError("run", "--device", "zero:/dev/zero", "busybox", "true")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "invalid device zero:/dev/zero: host path zero is not an absolute path")
+}
+
 func (s *DockerSuite) TestRunModeHostname(c *check.C) {
 	// Not applicable on Windows as Windows does not support -h
 	testRequires(c, SameHostDaemon, DaemonIsLinux, NotUserNamespace)
@@ -3742,6 +3750,19 @@ func (s *DockerSuite) TestRunNonExistingImage(c *check.C) {
 	}
 }
 
+// TestRunPullNever checks that 'docker run --pull=never foo' fails without pulling the image
+func (s *DockerSuite) TestRunPullNever(c *check.C) {
+	out, exit, err := dockerCmdWithError("run", "--pull=never", "foo")
+	c.Assert(err, checker.NotNil)
+	c.Assert(exit, checker.Equals, 125)
+	c.Assert(out, checker.Contains, "Error: image foo:latest not found locally")
+	c.Assert(out, checker.Not(checker.Contains), "Unable to find image")
+
+	out, _, err = dockerCmdWithError("run", "--pull=sometimes", "busybox", "true")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, `invalid --pull value "sometimes"`)
+}
+
 // TestDockerFails checks that 'docker run -foo busybox' exits with 125 to signal docker run failed
 func (s *DockerSuite) TestDockerFails(c *check.C) {
 	runCmd := exec.Command(dockerBinary, "run", "-foo", "busybox")
@@ -3924,3 +3945,33 @@ func (s *DockerSuite) TestRunNamedVolumesMountedAsShared(c *check.C) {
 	}
 
 }
+
+func (s *DockerSuite) TestRunMount(c *check.C) {
+	testRequires(c, DaemonIsLinux, SameHostDaemon)
+	dir, err := ioutil.TempDir("", "test-run-mount")
+	c.Assert(err, checker.IsNil)
+	defer os.RemoveAll(dir)
+
+	out, _, err := dockerCmdWithError("run", "--mount", "type=bind,source="+dir+",target=/foo,readonly", "busybox", "touch", "/foo/bar")
+	c.Assert(err, checker.NotNil, check.Commentf("writing to a readonly bind mount should fail: %s", out))
+	c.Assert(out, checker.Contains, "Read-only file system")
+
+	out, _, err = dockerCmdWithError("run", "--mount", "type=bind,source="+dir+",taget=/foo", "busybox", "true")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "unknown key taget")
+}
+
+func (s *DockerSuite) TestRunNameTemplate(c *check.C) {
+	dockerCmd(c, "create", "--name", "job-1", "busybox")
+	dockerCmd(c, "create", "--name", "job-3", "busybox")
+
+	// job-3 is in use, the next sequence number is used
+	out, _ := dockerCmd(c, "run", "-d", "--name-template", "job-{{.Seq}}", "busybox", "true")
+	name, err := inspectField(strings.TrimSpace(out), "Name")
+	c.Assert(err, checker.IsNil)
+	c.Assert(name, checker.Equals, "/job-4")
+
+	out, _, err = dockerCmdWithError("run", "--name", "job", "--name-template", "job-{{.Seq}}", "busybox", "true")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "--name and --name-template cannot be combined")
+}
@@ -166,6 +166,48 @@ func (s *DockerSuite) TestSaveAndLoadRepoFlags(c *check.C) {
 	c.Assert(before, checker.Equals, after, check.Commentf("inspect is not the same after a save / load"))
 }
 
+func (s *DockerSuite) TestSaveCompressAndLoad(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	repoName := "foobar-save-compress-load-test"
+	dockerCmd(c, "tag", "busybox:latest", repoName)
+	before, _ := dockerCmd(c, "inspect", repoName)
+
+	tmpDir, err := ioutil.TempDir("", "save-compress")
+	c.Assert(err, checker.IsNil)
+	defer os.RemoveAll(tmpDir)
+	archivePath := filepath.Join(tmpDir, "busybox.tar.gz")
+
+	dockerCmd(c, "save", "--compress", "-o", archivePath, repoName)
+
+	content, err := ioutil.ReadFile(archivePath)
+	c.Assert(err, checker.IsNil)
+	c.Assert(len(content) > 2, checker.True)
+	c.Assert(content[:2], checker.DeepEquals, []byte{0x1f, 0x8b}, check.Commentf("archive is not gzip compressed"))
+
+	deleteImages(repoName)
+	dockerCmd(c, "load", "-i", archivePath)
+
+	after, _ := dockerCmd(c, "inspect", repoName)
+	c.Assert(before, checker.Equals, after, check.Commentf("inspect is not the same after a save / load"))
+}
+
+func (s *DockerSuite) TestLoadQuiet(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	repoName := "foobar-load-quiet-test"
+	dockerCmd(c, "tag", "busybox:latest", repoName)
+
+	tmpDir, err := ioutil.TempDir("", "load-quiet")
+	c.Assert(err, checker.IsNil)
+	defer os.RemoveAll(tmpDir)
+	archivePath := filepath.Join(tmpDir, "busybox.tar")
+	dockerCmd(c, "save", "-o", archivePath, repoName)
+
+	deleteImages(repoName)
+	out, _ := dockerCmd(c, "load", "-q", "-i", archivePath)
+	c.Assert(out, checker.Contains, "Loaded image: "+repoName+":latest")
+	c.Assert(out, checker.Not(checker.Contains), "Loading layer")
+}
+
 func (s *DockerSuite) TestSaveMultipleNames(c *check.C) {
 	testRequires(c, DaemonIsLinux)
 	repoName := "foobar-save-multi-name-test"
@@ -47,6 +47,52 @@ func (s *DockerSuite) TestSearchCmdOptions(c *check.C) {
 	dockerCmd(c, "search", "--stars=2", "--automated=true", "--no-trunc=true", "busybox")
 }
 
+func (s *DockerSuite) TestSearchWithFilters(c *check.C) {
+	testRequires(c, Network)
+
+	out, _ := dockerCmd(c, "search", "--filter", "is-official=true", "--filter", "stars=1", "busybox")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	c.Assert(len(lines) > 1, checker.True, check.Commentf("expected the official busybox image: %s", out))
+	for _, line := range lines[1:] {
+		c.Assert(strings.Contains(line, "[OK]"), checker.True, check.Commentf("expected only official images: %s", out))
+	}
+
+	out, _ = dockerCmd(c, "search", "--limit", "2", "busybox")
+	lines = strings.Split(strings.TrimSpace(out), "\n")
+	c.Assert(len(lines) <= 3, checker.True, check.Commentf("expected at most 2 results: %s", out))
+}
+
+func (s *DockerSuite) TestSearchWithInvalidFilters(c *check.C) {
+	out, _, err := dockerCmdWithError("search", "--filter", "stars=many", "busybox")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "invalid filter 'stars=many'")
+
+	out, _, err = dockerCmdWithError("search", "--filter", "is-official=maybe", "busybox")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "expected true or false")
+
+	out, _, err = dockerCmdWithError("search", "--filter", "name=busybox", "busybox")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "valid filters are stars, is-official and is-automated")
+
+	out, _, err = dockerCmdWithError("search", "--limit", "0", "busybox")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "invalid --limit 0")
+}
+
+func (s *DockerSuite) TestSearchFormat(c *check.C) {
+	testRequires(c, Network)
+
+	out, _ := dockerCmd(c, "search", "--filter", "is-official=true", "--format", "{{.Name}}|{{.IsOfficial}}", "busybox")
+	c.Assert(out, checker.Contains, "busybox|[OK]\n")
+	c.Assert(out, checker.Not(checker.Contains), "NAME")
+
+	out, _ = dockerCmd(c, "search", "--limit", "1", "--format", "table {{.Name}}\t{{.StarCount}}", "busybox")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	c.Assert(lines, checker.HasLen, 2, check.Commentf("%s", out))
+	c.Assert(lines[0], checker.Matches, "NAME +STARS")
+}
+
 // search for repos which start with "ubuntu-" on the central registry
 func (s *DockerSuite) TestSearchOnCentralRegistryWithDash(c *check.C) {
 	testRequires(c, Network, DaemonIsLinux)
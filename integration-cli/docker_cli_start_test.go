@@ -178,3 +178,39 @@ func (s *DockerSuite) TestStartAttachMultipleContainers(c *check.C) {
 		c.Assert(out, checker.Equals, expected)
 	}
 }
+
+func (s *DockerSuite) TestStartStopRestartFilter(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	field := func(name, path string) string {
+		value, err := inspectField(name, path)
+		c.Assert(err, checker.IsNil)
+		return value
+	}
+	dockerCmd(c, "create", "--name", "web1", "--label", "tier=web", "busybox", "top")
+	dockerCmd(c, "create", "--name", "web2", "--label", "tier=web", "busybox", "top")
+	dockerCmd(c, "create", "--name", "db1", "--label", "tier=db", "busybox", "top")
+
+	out, _ := dockerCmd(c, "start", "--filter", "label=tier=web")
+	c.Assert(out, checker.Contains, "Started 2 containers")
+	c.Assert(field("web1", "State.Running"), checker.Equals, "true")
+	c.Assert(field("web2", "State.Running"), checker.Equals, "true")
+	c.Assert(field("db1", "State.Running"), checker.Equals, "false")
+
+	startedAt := field("web2", "State.StartedAt")
+	out, _ = dockerCmd(c, "restart", "-t", "1", "--filter", "name=web2")
+	c.Assert(out, checker.Contains, "Restarted 1 containers")
+	c.Assert(field("web2", "State.StartedAt"), checker.Not(checker.Equals), startedAt)
+
+	out, _ = dockerCmd(c, "stop", "-t", "1", "--filter", "status=running", "--filter", "label=tier=web")
+	c.Assert(out, checker.Contains, "Stopped 2 containers")
+	c.Assert(field("web1", "State.Running"), checker.Equals, "false")
+	c.Assert(field("web2", "State.Running"), checker.Equals, "false")
+
+	out, _, err := dockerCmdWithError("stop", "--filter", "ancestor=busybox")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "Invalid filter 'ancestor'")
+
+	out, _, err = dockerCmdWithError("start", "-a", "--filter", "label=tier=db")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "You cannot start and attach multiple containers at once.")
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"encoding/json"
 	"os/exec"
 	"regexp"
 	"strings"
@@ -127,3 +128,54 @@ func (s *DockerSuite) TestStatsAllNewContainersAdded(c *check.C) {
 		// ignore, done
 	}
 }
+
+func (s *DockerSuite) TestStatsFormat(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	dockerCmd(c, "run", "-d", "--name", "stats-format", "busybox", "top")
+	c.Assert(waitRun("stats-format"), checker.IsNil)
+
+	out, _ := dockerCmd(c, "stats", "--no-stream", "--format", "{{.Container}} {{.Name}} {{.PIDs}}", "stats-format")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "stats-format stats-format 1")
+
+	out, _ = dockerCmd(c, "stats", "--no-stream", "--format", "table {{.Name}}\t{{.CPUPerc}}", "stats-format")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	c.Assert(lines, checker.HasLen, 2)
+	c.Assert(lines[0], checker.Matches, `NAME\s+CPU %`)
+	c.Assert(lines[1], checker.Matches, `stats-format\s+\d+\.\d\d%`)
+}
+
+func (s *DockerSuite) TestStatsFormatInvalid(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	dockerCmd(c, "run", "-d", "--name", "stats-format", "busybox", "top")
+	c.Assert(waitRun("stats-format"), checker.IsNil)
+
+	out, _, err := dockerCmdWithError("stats", "--no-stream", "--format", "{{.Invalid", "stats-format")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "Template parsing error")
+}
+
+func (s *DockerSuite) TestStatsJSON(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	dockerCmd(c, "run", "-d", "--name", "stats-json", "busybox", "top")
+	c.Assert(waitRun("stats-json"), checker.IsNil)
+
+	out, _ := dockerCmd(c, "stats", "--no-stream", "--json", "stats-json")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	c.Assert(lines, checker.HasLen, 1)
+
+	var stats map[string]interface{}
+	c.Assert(json.Unmarshal([]byte(lines[0]), &stats), checker.IsNil)
+	c.Assert(stats["container"], checker.Equals, "stats-json")
+	c.Assert(stats["name"], checker.Equals, "stats-json")
+	for _, key := range []string{"cpu_percent", "memory_usage_bytes", "memory_limit_bytes", "memory_percent", "network_rx_bytes", "network_tx_bytes", "block_read_bytes", "block_write_bytes", "pids"} {
+		_, ok := stats[key].(float64)
+		c.Assert(ok, checker.True, check.Commentf("%s is not a number in %s", key, lines[0]))
+	}
+}
+
+func (s *DockerSuite) TestStatsJSONRequiresNoStream(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, _, err := dockerCmdWithError("stats", "--json")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "--json requires --no-stream")
+}
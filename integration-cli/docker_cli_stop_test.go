@@ -0,0 +1,31 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/docker/docker/pkg/integration/checker"
+	"github.com/go-check/check"
+)
+
+func (s *DockerSuite) TestStopReportsKill(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	// top runs as PID 1 and ignores SIGTERM, so it has to be killed
+	out, _ := dockerCmd(c, "run", "-d", "busybox", "top")
+	id := strings.TrimSpace(out)
+	c.Assert(waitRun(id), checker.IsNil)
+
+	stdout, stderr, _, err := runCommandWithStdoutStderr(exec.Command(dockerBinary, "stop", "-t", "1", id))
+	c.Assert(err, checker.IsNil, check.Commentf(stderr))
+	c.Assert(stdout, checker.Equals, id+"\n")
+	c.Assert(stderr, checker.Contains, "did not stop within 1 seconds of SIGTERM and was killed with SIGKILL")
+
+	out, _ = dockerCmd(c, "run", "-d", "busybox", "sh", "-c", "trap 'exit 0' TERM; while true; do sleep 1; done")
+	id = strings.TrimSpace(out)
+	c.Assert(waitRun(id), checker.IsNil)
+
+	stdout, stderr, _, err = runCommandWithStdoutStderr(exec.Command(dockerBinary, "stop", "-t", "10", id))
+	c.Assert(err, checker.IsNil, check.Commentf(stderr))
+	c.Assert(stdout, checker.Equals, id+"\n")
+	c.Assert(stderr, checker.Equals, "")
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os/exec"
 	"strings"
 
 	"github.com/docker/docker/pkg/integration/checker"
@@ -223,3 +224,33 @@ func (s *DockerSuite) TestTagTruncationAmbiguity(c *check.C) {
 	// Ensure id is imageID and not busybox:latest
 	c.Assert(id, checker.Not(checker.Equals), imageID)
 }
+
+// tagging from stdin tags every SOURCE DEST pair
+func (s *DockerSuite) TestTagFromStdin(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	tagCmd := exec.Command(dockerBinary, "tag", "-")
+	tagCmd.Stdin = strings.NewReader("busybox:latest stdin/foo:1\n\nbusybox stdin/bar\nnotanimage stdin/baz\n")
+	out, _, err := runCommandWithOutput(tagCmd)
+	c.Assert(err, checker.NotNil, check.Commentf("tagging a missing image should have failed: %s", out))
+	c.Assert(out, checker.Contains, "line 1: tagged busybox:latest stdin/foo:1")
+	c.Assert(out, checker.Contains, "line 3: tagged busybox stdin/bar")
+	c.Assert(out, checker.Contains, `line 4: failed to tag "notanimage stdin/baz"`)
+
+	images, _ := dockerCmd(c, "images", "stdin/*")
+	c.Assert(images, checker.Contains, "stdin/foo")
+	c.Assert(images, checker.Contains, "stdin/bar")
+}
+
+// tagging from stdin doesn't tag anything if a line is invalid
+func (s *DockerSuite) TestTagFromStdinInvalidLine(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	tagCmd := exec.Command(dockerBinary, "tag", "-")
+	tagCmd.Stdin = strings.NewReader("busybox stdinvalid/foo\nbusybox Stdinvalid/Bar\n")
+	out, _, err := runCommandWithOutput(tagCmd)
+	c.Assert(err, checker.NotNil, check.Commentf("an invalid line should have failed: %s", out))
+	c.Assert(out, checker.Contains, `line 2: invalid tag "busybox Stdinvalid/Bar"`)
+	c.Assert(out, checker.Contains, "nothing was tagged")
+
+	images, _ := dockerCmd(c, "images", "stdinvalid/foo")
+	c.Assert(images, checker.Not(checker.Contains), "stdinvalid/foo")
+}
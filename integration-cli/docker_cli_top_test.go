@@ -16,6 +16,29 @@ func (s *DockerSuite) TestTopMultipleArgs(c *check.C) {
 	c.Assert(out, checker.Contains, "PID", check.Commentf("did not see PID after top -o pid: %s", out))
 }
 
+func (s *DockerSuite) TestTopFormatAndColumns(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, _ := dockerCmd(c, "run", "-d", "busybox", "top")
+	id := strings.TrimSpace(out)
+
+	out, _ = dockerCmd(c, "top", "--format", "{{.PID}}:{{.CMD}}", id)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	c.Assert(lines, checker.HasLen, 1)
+	c.Assert(lines[0], checker.Matches, "[0-9]+:top")
+
+	out, _ = dockerCmd(c, "top", "--columns", "cmd,pid", id)
+	lines = strings.Split(strings.TrimSpace(out), "\n")
+	c.Assert(lines, checker.HasLen, 2)
+	c.Assert(strings.Fields(lines[0]), checker.DeepEquals, []string{"CMD", "PID"})
+	c.Assert(strings.Fields(lines[1])[0], checker.Equals, "top")
+
+	out, _, err := dockerCmdWithError("top", "--columns", "pid,user", id)
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, `unknown column "user"`)
+
+	dockerCmd(c, "kill", id)
+}
+
 func (s *DockerSuite) TestTopNonPrivileged(c *check.C) {
 	testRequires(c, DaemonIsLinux)
 	out, _ := dockerCmd(c, "run", "-i", "-d", "busybox", "top")
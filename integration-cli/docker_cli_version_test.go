@@ -1,8 +1,12 @@
 package main
 
 import (
+	"encoding/json"
+	"os/exec"
 	"strings"
 
+	"github.com/docker/docker/api/types"
+
 	"github.com/docker/docker/pkg/integration/checker"
 	"github.com/go-check/check"
 )
@@ -56,3 +60,28 @@ func testVersionPlatform(c *check.C, platform string) {
 	}
 	c.Assert(bFound, checker.Equals, true, check.Commentf("Could not find server '%s' in '%s'", expected, out))
 }
+
+func (s *DockerSuite) TestVersionFormatJSON(c *check.C) {
+	out, _ := dockerCmd(c, "version", "--format", "{{json .}}")
+
+	var vd types.VersionResponse
+	c.Assert(json.Unmarshal([]byte(out), &vd), checker.IsNil, check.Commentf("%s", out))
+	c.Assert(vd.Client, checker.NotNil)
+	c.Assert(vd.Server, checker.NotNil)
+	c.Assert(strings.Contains(out, `"Experimental":`), checker.True, check.Commentf("%s", out))
+	c.Assert(vd.Client.APIVersion, checker.Equals, vd.Server.APIVersion)
+}
+
+func (s *DockerSuite) TestVersionFormatJSONWithoutServer(c *check.C) {
+	// Nothing listens on port 1, the client can't get the server's version.
+	cmd := exec.Command(dockerBinary, "-H", "tcp://127.0.0.1:1", "version", "--format", "{{json .}}")
+	out, err := cmd.Output()
+	c.Assert(err, checker.NotNil)
+
+	var doc map[string]interface{}
+	c.Assert(json.Unmarshal(out, &doc), checker.IsNil, check.Commentf("%s", out))
+	c.Assert(doc["Client"], checker.NotNil)
+	server, ok := doc["Server"]
+	c.Assert(ok, checker.True)
+	c.Assert(server, checker.IsNil)
+}
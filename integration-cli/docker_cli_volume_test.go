@@ -86,6 +86,28 @@ func (s *DockerSuite) TestVolumeCliLs(c *check.C) {
 	c.Assert(strings.Contains(out, "test\n"), check.Equals, true)
 }
 
+func (s *DockerSuite) TestVolumeCliLsFormat(c *check.C) {
+	dockerCmd(c, "volume", "create", "--name", "testformat")
+
+	out, _ := dockerCmd(c, "volume", "ls", "--format", "{{.Name}}:{{.Driver}}")
+	c.Assert(strings.Split(strings.TrimSpace(out), "\n"), checker.Contains, "testformat:local")
+
+	out, _ = dockerCmd(c, "volume", "inspect", "--format", "{{.Mountpoint}}", "testformat")
+	mountpoint := strings.TrimSpace(out)
+
+	out, _ = dockerCmd(c, "volume", "ls", "--format", "table {{.Name}}\t{{.Mountpoint}}")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	c.Assert(strings.Fields(lines[0]), checker.DeepEquals, []string{"VOLUME", "NAME", "MOUNTPOINT"})
+	var found bool
+	for _, line := range lines[1:] {
+		if fields := strings.Fields(line); len(fields) == 2 && fields[0] == "testformat" {
+			c.Assert(fields[1], checker.Equals, mountpoint)
+			found = true
+		}
+	}
+	c.Assert(found, checker.True, check.Commentf("testformat not listed in\n%s", out))
+}
+
 func (s *DockerSuite) TestVolumeCliLsFilterDangling(c *check.C) {
 	prefix := ""
 	if daemonPlatform == "windows" {
@@ -164,6 +186,46 @@ func (s *DockerSuite) TestVolumeCliRm(c *check.C) {
 	)
 }
 
+func (s *DockerSuite) TestVolumeCliRmFilter(c *check.C) {
+	prefix := ""
+	if daemonPlatform == "windows" {
+		prefix = "c:"
+	}
+	dockerCmd(c, "volume", "create", "--name", "testrmunused")
+	dockerCmd(c, "create", "--name", "volume-rm-test", "-v", "testrminuse:"+prefix+"/foo", "busybox", "true")
+
+	// Without a confirmation, nothing is removed and the command fails
+	cmd := exec.Command(dockerBinary, "volume", "rm", "--filter", "dangling=true")
+	cmd.Stdin = strings.NewReader("n\n")
+	out, _, err := runCommandWithOutput(cmd)
+	c.Assert(err, checker.NotNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "Are you sure you want to continue? [y/N]")
+	c.Assert(out, checker.Contains, "matching the filter was not confirmed")
+	dockerCmd(c, "volume", "inspect", "testrmunused")
+
+	out, _ = dockerCmd(c, "volume", "rm", "--force", "--filter", "dangling=true")
+	c.Assert(out, checker.Contains, "testrmunused\n")
+	c.Assert(out, checker.Not(checker.Contains), "testrminuse\n")
+	c.Assert(out, checker.Contains, "Removed ")
+	_, _, err = dockerCmdWithError("volume", "inspect", "testrmunused")
+	c.Assert(err, checker.NotNil)
+	dockerCmd(c, "volume", "inspect", "testrminuse")
+
+	// Volumes in use can't be removed, the others still are
+	dockerCmd(c, "volume", "create", "--name", "testrmunused")
+	out, _, err = dockerCmdWithError("volume", "rm", "testrminuse", "testrmunused")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "testrmunused\n")
+	c.Assert(out, checker.Contains, "failed to remove volumes: [testrminuse]")
+
+	out, _, err = dockerCmdWithError("volume", "rm", "--filter", "label=foo")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "Invalid filter 'label'")
+
+	dockerCmd(c, "rm", "volume-rm-test")
+	dockerCmd(c, "volume", "rm", "testrminuse")
+}
+
 func (s *DockerSuite) TestVolumeCliNoArgs(c *check.C) {
 	out, _ := dockerCmd(c, "volume")
 	// no args should produce the cmd usage output
@@ -93,3 +93,29 @@ func (s *DockerSuite) TestWaitBlockedExitRandom(c *check.C) {
 		c.Fatal("timeout waiting for `docker wait` to exit")
 	}
 }
+
+func (s *DockerSuite) TestWaitAnyFormatPropagateExit(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	dockerCmd(c, "run", "-d", "--name", "wait-fast", "busybox", "sh", "-c", "exit 3")
+	dockerCmd(c, "run", "-d", "--name", "wait-slow", "busybox", "top")
+
+	// --any returns as soon as wait-fast has stopped, without waiting for top
+	out, code, err := dockerCmdWithError("wait", "--any", "--propagate-exit", "--format", "{{.Container}}={{.StatusCode}}", "wait-slow", "wait-fast")
+	c.Assert(err, checker.NotNil)
+	c.Assert(code, checker.Equals, 3)
+	c.Assert(strings.TrimSpace(out), checker.Equals, "wait-fast=3")
+
+	running, err := inspectField("wait-slow", "State.Running")
+	c.Assert(err, checker.IsNil)
+	c.Assert(running, checker.Equals, "true")
+
+	dockerCmd(c, "kill", "wait-slow")
+	out, code, err = dockerCmdWithError("wait", "--propagate-exit", "wait-fast", "wait-slow")
+	c.Assert(err, checker.NotNil)
+	c.Assert(code, checker.Equals, 3)
+	c.Assert(strings.Fields(out), checker.DeepEquals, []string{"3", "137"})
+
+	out, _, err = dockerCmdWithError("wait", "--any", "--all", "wait-fast")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "--any and --all cannot be combined")
+}
@@ -19,7 +19,8 @@ import (
 //
 // As of #16585, it's up to application inside docker to validate or not
 // environment variables, that's why we just strip leading whitespace and
-// nothing more.
+// nothing more. Lines may be prefixed with `export`, as in a shell script,
+// and files with Windows line endings are accepted.
 func ParseEnvFile(filename string) ([]string, error) {
 	fh, err := os.Open(filename)
 	if err != nil {
@@ -29,17 +30,25 @@ func ParseEnvFile(filename string) ([]string, error) {
 
 	lines := []string{}
 	scanner := bufio.NewScanner(fh)
-	for scanner.Scan() {
+	for lineNum := 1; scanner.Scan(); lineNum++ {
 		// trim the line from all leading whitespace first
-		line := strings.TrimLeft(scanner.Text(), whiteSpaces)
+		line := strings.TrimLeft(strings.TrimSuffix(scanner.Text(), "\r"), whiteSpaces)
+		// drop the `export` of a shell assignment
+		if fields := strings.Fields(line); len(fields) > 1 && fields[0] == "export" {
+			line = strings.TrimLeft(line[len("export"):], whiteSpaces)
+		}
 		// line is not empty, and not starting with '#'
 		if len(line) > 0 && !strings.HasPrefix(line, "#") {
 			data := strings.SplitN(line, "=", 2)
 
 			// trim the front of a variable, but nothing else
 			variable := strings.TrimLeft(data[0], whiteSpaces)
+			if len(data) == 1 {
+				// a pass-through variable may be followed by whitespace
+				variable = strings.TrimRight(variable, whiteSpaces)
+			}
 			if strings.ContainsAny(variable, whiteSpaces) {
-				return []string{}, ErrBadEnvVariable{fmt.Sprintf("variable '%s' has white spaces", variable)}
+				return []string{}, ErrBadEnvVariable{fmt.Sprintf("variable '%s' has white spaces on line %d", variable, lineNum)}
 			}
 
 			if len(data) > 1 {
@@ -48,7 +57,7 @@ func ParseEnvFile(filename string) ([]string, error) {
 				lines = append(lines, fmt.Sprintf("%s=%s", variable, data[1]))
 			} else {
 				// if only a pass-through variable is given, clean it up.
-				lines = append(lines, fmt.Sprintf("%s=%s", strings.TrimSpace(line), os.Getenv(line)))
+				lines = append(lines, fmt.Sprintf("%s=%s", variable, os.Getenv(variable)))
 			}
 		}
 	}
@@ -58,6 +58,39 @@ and_underscore=working too
 	}
 }
 
+// Test ParseEnvFile for a file with shell exports and Windows line endings
+func TestParseEnvFileExportsAndLineEndings(t *testing.T) {
+	os.Setenv("ENVFILE_TEST_PASSTHROUGH", "passed")
+	defer os.Unsetenv("ENVFILE_TEST_PASSTHROUGH")
+
+	content := "export foo=bar\r\n" +
+		"\texport  baz=quux\r\n" +
+		"export=value\n" +
+		"export ENVFILE_TEST_PASSTHROUGH  \n" +
+		"ENVFILE_TEST_PASSTHROUGH\r\n" +
+		"\r\n" +
+		"# export commented=out\r\n"
+	tmpFile := tmpFileWithContent(content, t)
+	defer os.Remove(tmpFile)
+
+	lines, err := ParseEnvFile(tmpFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedLines := []string{
+		"foo=bar",
+		"baz=quux",
+		"export=value",
+		"ENVFILE_TEST_PASSTHROUGH=passed",
+		"ENVFILE_TEST_PASSTHROUGH=passed",
+	}
+
+	if !reflect.DeepEqual(lines, expectedLines) {
+		t.Fatalf("Expected %v, got %v", expectedLines, lines)
+	}
+}
+
 // Test ParseEnvFile for an empty file
 func TestParseEnvFileEmptyFile(t *testing.T) {
 	tmpFile := tmpFileWithContent("", t)
@@ -100,7 +133,7 @@ func TestParseEnvFileBadlyFormattedFile(t *testing.T) {
 	if _, ok := err.(ErrBadEnvVariable); !ok {
 		t.Fatalf("Expected a ErrBadEnvVariable, got [%v]", err)
 	}
-	expectedMessage := "poorly formatted environment: variable 'f   ' has white spaces"
+	expectedMessage := "poorly formatted environment: variable 'f   ' has white spaces on line 2"
 	if err.Error() != expectedMessage {
 		t.Fatalf("Expected [%v], got [%v]", expectedMessage, err.Error())
 	}
@@ -135,7 +168,7 @@ another invalid line`
 	if _, ok := err.(ErrBadEnvVariable); !ok {
 		t.Fatalf("Expected a ErrBadEnvvariable, got [%v]", err)
 	}
-	expectedMessage := "poorly formatted environment: variable 'first line' has white spaces"
+	expectedMessage := "poorly formatted environment: variable 'first line' has white spaces on line 1"
 	if err.Error() != expectedMessage {
 		t.Fatalf("Expected [%v], got [%v]", expectedMessage, err.Error())
 	}
@@ -0,0 +1,154 @@
+package opts
+
+import (
+	"encoding/csv"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MountTypeBind is the type of a mount of a host path.
+	MountTypeBind = "bind"
+	// MountTypeVolume is the type of a mount of a volume.
+	MountTypeVolume = "volume"
+)
+
+// MountSpec is a mount given to the --mount flag.
+type MountSpec struct {
+	Type     string
+	Source   string
+	Target   string
+	ReadOnly bool
+}
+
+// Bind returns the mount in the format of a bind, as given to the -v flag.
+// Anonymous volumes don't have a bind, Bind returns an empty string for them.
+func (m MountSpec) Bind() string {
+	if m.Source == "" {
+		return ""
+	}
+	bind := m.Source + ":" + m.Target
+	if m.ReadOnly {
+		bind += ":ro"
+	}
+	return bind
+}
+
+// MountOpt defines a list of mounts.
+type MountOpt struct {
+	values []MountSpec
+}
+
+// NewMountOpt creates a new MountOpt
+func NewMountOpt() *MountOpt {
+	return &MountOpt{}
+}
+
+// Set validates a mount and adds it to the list.
+func (o *MountOpt) Set(val string) error {
+	m, err := ParseMount(val)
+	if err != nil {
+		return err
+	}
+	o.values = append(o.values, m)
+	return nil
+}
+
+// String returns the mounts as a string.
+func (o *MountOpt) String() string {
+	var out []string
+	for _, m := range o.values {
+		out = append(out, fmt.Sprintf("type=%s,source=%s,target=%s,readonly=%t", m.Type, m.Source, m.Target, m.ReadOnly))
+	}
+	return fmt.Sprintf("%v", out)
+}
+
+// GetList returns the mounts.
+func (o *MountOpt) GetList() []MountSpec {
+	return o.values
+}
+
+// ParseMount parses a mount in the form
+//    type=bind|volume,source=SOURCE,target=TARGET[,readonly]
+// The fields are comma separated key=value pairs, quoted as CSV if a value
+// contains a comma. The type defaults to volume. A bind requires a source
+// and a target, a volume requires a target, and is anonymous without a
+// source. The target, and the source of a bind, must be absolute paths, and
+// neither can contain a colon, which separates them in the bind given to the
+// daemon. readonly may be given without a value.
+func ParseMount(val string) (MountSpec, error) {
+	fields, err := csv.NewReader(strings.NewReader(val)).Read()
+	if err != nil {
+		return MountSpec{}, fmt.Errorf("invalid mount %s: %v", val, err)
+	}
+
+	m := MountSpec{Type: MountTypeVolume}
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		key := strings.ToLower(strings.TrimSpace(parts[0]))
+
+		if key == "readonly" || key == "ro" {
+			if len(parts) == 1 {
+				m.ReadOnly = true
+				continue
+			}
+			if m.ReadOnly, err = strconv.ParseBool(parts[1]); err != nil {
+				return MountSpec{}, fmt.Errorf("invalid mount %s: invalid value %q for key %s", val, parts[1], key)
+			}
+			continue
+		}
+
+		if len(parts) == 1 {
+			if key == "" {
+				return MountSpec{}, fmt.Errorf("invalid mount %s: empty field", val)
+			}
+			return MountSpec{}, fmt.Errorf("invalid mount %s: key %s requires a value", val, key)
+		}
+		value := parts[1]
+		switch key {
+		case "type":
+			m.Type = value
+		case "source", "src":
+			m.Source = value
+		case "target", "destination", "dst":
+			m.Target = value
+		default:
+			return MountSpec{}, fmt.Errorf("invalid mount %s: unknown key %s", val, key)
+		}
+	}
+
+	if m.Target == "" {
+		return MountSpec{}, fmt.Errorf("invalid mount %s: target is required", val)
+	}
+	if strings.Contains(m.Source, ":") {
+		return MountSpec{}, fmt.Errorf("invalid mount %s: source %s can't contain a colon", val, m.Source)
+	}
+	if strings.Contains(m.Target, ":") {
+		return MountSpec{}, fmt.Errorf("invalid mount %s: target %s can't contain a colon", val, m.Target)
+	}
+	if !path.IsAbs(m.Target) {
+		return MountSpec{}, fmt.Errorf("invalid mount %s: target %s must be an absolute path", val, m.Target)
+	}
+	switch m.Type {
+	case MountTypeBind:
+		if m.Source == "" {
+			return MountSpec{}, fmt.Errorf("invalid mount %s: source is required for a bind mount", val)
+		}
+		// A relative source would be taken for the name of a volume.
+		if !path.IsAbs(m.Source) {
+			return MountSpec{}, fmt.Errorf("invalid mount %s: source %s of a bind mount must be an absolute path", val, m.Source)
+		}
+	case MountTypeVolume:
+		if strings.ContainsAny(m.Source, `/\`) {
+			return MountSpec{}, fmt.Errorf("invalid mount %s: source %s of a volume mount must be a volume name", val, m.Source)
+		}
+		if m.Source == "" && m.ReadOnly {
+			return MountSpec{}, fmt.Errorf("invalid mount %s: an anonymous volume can't be readonly", val)
+		}
+	default:
+		return MountSpec{}, fmt.Errorf("invalid mount %s: unknown type %s (types are %s and %s)", val, m.Type, MountTypeBind, MountTypeVolume)
+	}
+	return m, nil
+}
@@ -0,0 +1,72 @@
+package opts
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMount(t *testing.T) {
+	valid := map[string]MountSpec{
+		"type=bind,source=/src,target=/dst":            {Type: "bind", Source: "/src", Target: "/dst"},
+		"type=bind,src=/src,dst=/dst,readonly":         {Type: "bind", Source: "/src", Target: "/dst", ReadOnly: true},
+		"type=bind,src=/src,destination=/dst,ro=true":  {Type: "bind", Source: "/src", Target: "/dst", ReadOnly: true},
+		"type=bind,src=/src,dst=/dst,readonly=false":   {Type: "bind", Source: "/src", Target: "/dst"},
+		"type=volume,source=data,target=/data":         {Type: "volume", Source: "data", Target: "/data"},
+		"target=/data":                                 {Type: "volume", Target: "/data"},
+		"Type=volume,Target=/data":                     {Type: "volume", Target: "/data"},
+		`type=bind,"source=/with,comma",target=/dst`:   {Type: "bind", Source: "/with,comma", Target: "/dst"},
+		"type=volume,source=data,target=/data,ro=1":    {Type: "volume", Source: "data", Target: "/data", ReadOnly: true},
+		"type=bind,source=/src,target=/dst,readonly=0": {Type: "bind", Source: "/src", Target: "/dst"},
+	}
+	for val, expected := range valid {
+		m, err := ParseMount(val)
+		if err != nil {
+			t.Fatalf("ParseMount(%q) should succeed: error %q", val, err)
+		}
+		if !reflect.DeepEqual(m, expected) {
+			t.Fatalf("ParseMount(%q) should return %+v, got %+v", val, expected, m)
+		}
+	}
+
+	invalid := map[string]string{
+		"type=bind,target=/dst":                        "invalid mount type=bind,target=/dst: source is required for a bind mount",
+		"type=bind,source=/src":                        "invalid mount type=bind,source=/src: target is required",
+		"type=volume":                                  "invalid mount type=volume: target is required",
+		"type=bind,source=/src,taget=/dst":             "invalid mount type=bind,source=/src,taget=/dst: unknown key taget",
+		"type=bind,source,target=/dst":                 "invalid mount type=bind,source,target=/dst: key source requires a value",
+		"type=bind,,target=/dst":                       "invalid mount type=bind,,target=/dst: empty field",
+		"type=bind,source=/src,target=/dst,readonly=y": "invalid mount type=bind,source=/src,target=/dst,readonly=y: invalid value \"y\" for key readonly",
+		"type=tmpfs,target=/dst":                       "invalid mount type=tmpfs,target=/dst: unknown type tmpfs (types are bind and volume)",
+		"type=volume,source=/src,target=/dst":          "invalid mount type=volume,source=/src,target=/dst: source /src of a volume mount must be a volume name",
+		"type=volume,target=/dst,readonly":             "invalid mount type=volume,target=/dst,readonly: an anonymous volume can't be readonly",
+		"type=bind,source=data,target=/dst":            "invalid mount type=bind,source=data,target=/dst: source data of a bind mount must be an absolute path",
+		"type=bind,source=./data,target=/dst":          "invalid mount type=bind,source=./data,target=/dst: source ./data of a bind mount must be an absolute path",
+		"type=bind,source=/src,target=dst":             "invalid mount type=bind,source=/src,target=dst: target dst must be an absolute path",
+		"type=volume,source=data,target=data":          "invalid mount type=volume,source=data,target=data: target data must be an absolute path",
+		"type=volume,target=data":                      "invalid mount type=volume,target=data: target data must be an absolute path",
+		"type=bind,source=/a:b,target=/dst":            "invalid mount type=bind,source=/a:b,target=/dst: source /a:b can't contain a colon",
+		"type=volume,source=a:b,target=/dst":           "invalid mount type=volume,source=a:b,target=/dst: source a:b can't contain a colon",
+		"type=bind,source=/src,target=/dst:ro":         "invalid mount type=bind,source=/src,target=/dst:ro: target /dst:ro can't contain a colon",
+	}
+	for val, expectedError := range invalid {
+		if _, err := ParseMount(val); err == nil {
+			t.Fatalf("ParseMount(%q) should have failed", val)
+		} else if err.Error() != expectedError {
+			t.Fatalf("ParseMount(%q) error should be %q, got %q", val, expectedError, err.Error())
+		}
+	}
+}
+
+func TestMountSpecBind(t *testing.T) {
+	binds := map[string]MountSpec{
+		"/src:/dst":     {Type: "bind", Source: "/src", Target: "/dst"},
+		"/src:/dst:ro":  {Type: "bind", Source: "/src", Target: "/dst", ReadOnly: true},
+		"data:/data:ro": {Type: "volume", Source: "data", Target: "/data", ReadOnly: true},
+		"":              {Type: "volume", Target: "/data"},
+	}
+	for expected, m := range binds {
+		if bind := m.Bind(); bind != expected {
+			t.Fatalf("Expected bind %q for %+v, got %q", expected, m, bind)
+		}
+	}
+}
@@ -17,6 +17,10 @@ import (
 var (
 	alphaRegexp  = regexp.MustCompile(`[a-zA-Z]`)
 	domainRegexp = regexp.MustCompile(`^(:?(:?[a-zA-Z0-9]|(:?[a-zA-Z0-9][a-zA-Z0-9\-]*[a-zA-Z0-9]))(:?\.(:?[a-zA-Z0-9]|(:?[a-zA-Z0-9][a-zA-Z0-9\-]*[a-zA-Z0-9])))*)\.?\s*$`)
+	// labelKeyRegexp matches the keys in reverse DNS notation, made of
+	// alphanumeric characters and underscores separated by single dots or
+	// dashes. Upper case characters are tolerated.
+	labelKeyRegexp = regexp.MustCompile(`^[a-zA-Z0-9_]+([.-][a-zA-Z0-9_]+)*$`)
 	// DefaultHTTPPort Default HTTP Port used if only the protocol is provided to -H flag e.g. docker daemon -H tcp://
 	// TODO Windows. DefaultHTTPPort is only used on Windows if a -H parameter
 	// is not supplied. A better longer term solution would be to use a named
@@ -265,52 +269,47 @@ func ValidDeviceMode(mode string) bool {
 	return true
 }
 
-// ValidateDevice validates a path for devices
-// It will make sure 'val' is in the form:
-//    [host-dir:]container-path[:mode]
-// It also validates the device mode.
+// ValidateDevice validates a device mapping and returns it in its complete
+// form. It will make sure 'val' is in the form:
+//    host-path[:container-path][:permissions]
+// where both paths are absolute and the permissions are a combination of r,
+// w and m. The container path defaults to the host path and the permissions
+// to rwm.
 func ValidateDevice(val string) (string, error) {
-	return validatePath(val, ValidDeviceMode)
-}
-
-func validatePath(val string, validator func(string) bool) (string, error) {
-	var containerPath string
-	var mode string
-
-	if strings.Count(val, ":") > 2 {
-		return val, fmt.Errorf("bad format for path: %s", val)
-	}
-
-	split := strings.SplitN(val, ":", 3)
-	if split[0] == "" {
-		return val, fmt.Errorf("bad format for path: %s", val)
+	var (
+		split       = strings.Split(val, ":")
+		src         = split[0]
+		dst         string
+		permissions = "rwm"
+	)
+	if len(split) > 3 || src == "" || (len(split) > 1 && split[1] == "") {
+		return val, fmt.Errorf("bad format for device: %s, the format is host-path[:container-path][:permissions]", val)
 	}
 	switch len(split) {
-	case 1:
-		containerPath = split[0]
-		val = path.Clean(containerPath)
 	case 2:
-		if isValid := validator(split[1]); isValid {
-			containerPath = split[0]
-			mode = split[1]
-			val = fmt.Sprintf("%s:%s", path.Clean(containerPath), mode)
+		if ValidDeviceMode(split[1]) {
+			permissions = split[1]
 		} else {
-			containerPath = split[1]
-			val = fmt.Sprintf("%s:%s", split[0], path.Clean(containerPath))
+			dst = split[1]
 		}
 	case 3:
-		containerPath = split[1]
-		mode = split[2]
-		if isValid := validator(split[2]); !isValid {
-			return val, fmt.Errorf("bad mode specified: %s", mode)
+		dst = split[1]
+		if !ValidDeviceMode(split[2]) {
+			return val, fmt.Errorf("invalid device %s: bad permissions %q, permissions are a combination of r, w and m", val, split[2])
 		}
-		val = fmt.Sprintf("%s:%s:%s", split[0], containerPath, mode)
+		permissions = split[2]
 	}
 
-	if !path.IsAbs(containerPath) {
-		return val, fmt.Errorf("%s is not an absolute path", containerPath)
+	if !path.IsAbs(src) {
+		return val, fmt.Errorf("invalid device %s: host path %s is not an absolute path", val, src)
 	}
-	return val, nil
+	if dst == "" {
+		dst = src
+	}
+	if !path.IsAbs(dst) {
+		return val, fmt.Errorf("invalid device %s: container path %s is not an absolute path", val, dst)
+	}
+	return fmt.Sprintf("%s:%s:%s", path.Clean(src), path.Clean(dst), permissions), nil
 }
 
 // ValidateEnv validates an environment variable and returns it.
@@ -368,16 +367,27 @@ func validateDomain(val string) (string, error) {
 	return "", fmt.Errorf("%s is not a valid domain", val)
 }
 
+// hostGatewayName is the host-gateway token which newer daemons resolve to
+// the IP address of the host. This daemon doesn't, it would write the token
+// to /etc/hosts as is.
+const hostGatewayName = "host-gateway"
+
 // ValidateExtraHost validates that the specified string is a valid extrahost and returns it.
 // ExtraHost are in the form of name:ip where the ip has to be a valid ip (ipv4 or ipv6).
 func ValidateExtraHost(val string) (string, error) {
 	// allow for IPv6 addresses in extra hosts by only splitting on first ":"
 	arr := strings.SplitN(val, ":", 2)
 	if len(arr) != 2 || len(arr[0]) == 0 {
-		return "", fmt.Errorf("bad format for add-host: %q", val)
+		return "", fmt.Errorf("%q: bad format, expected host:ip", val)
+	}
+	if strings.ContainsAny(arr[0], " \t") {
+		return "", fmt.Errorf("%q: invalid host name %q", val, arr[0])
+	}
+	if arr[1] == hostGatewayName {
+		return "", fmt.Errorf("%q: %s is not supported by this daemon, give the IP address of the host", val, hostGatewayName)
 	}
 	if _, err := ValidateIPAddress(arr[1]); err != nil {
-		return "", fmt.Errorf("invalid IP address in add-host: %q", arr[1])
+		return "", fmt.Errorf("%q: invalid IP address", val)
 	}
 	return val, nil
 }
@@ -391,6 +401,18 @@ func ValidateLabel(val string) (string, error) {
 	return val, nil
 }
 
+// ValidateLabelKey validates that the specified string is a label key in
+// reverse DNS notation, e.g. com.example.some-label.
+func ValidateLabelKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("label key can't be empty")
+	}
+	if !labelKeyRegexp.MatchString(key) {
+		return fmt.Errorf("invalid label key %q: keys consist of alphanumeric characters and underscores separated by single dots or dashes, e.g. com.example.some-label", key)
+	}
+	return nil
+}
+
 // ValidateHost validates that the specified string is a valid host and returns it.
 func ValidateHost(val string) (string, error) {
 	_, err := parsers.ParseDockerDaemonHost(DefaultTCPHost, DefaultTLSHost, DefaultUnixSocket, "", val)
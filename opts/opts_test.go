@@ -202,6 +202,10 @@ func TestValidateExtraHosts(t *testing.T) {
 		`thathost-nosemicolon10.0.0.1`: `bad format`,
 		`anipv6host:::::1`:             `invalid IP`,
 		`ipv6local:::0::`:              `invalid IP`,
+		`foo`:                          `"foo": bad format`,
+		`my host:10.0.0.1`:             `invalid host name`,
+		`myhost:`:                      `"myhost:": invalid IP address`,
+		`myhost:host-gateway`:          `host-gateway is not supported`,
 	}
 
 	for _, extrahost := range valid {
@@ -275,52 +279,49 @@ func TestValidateLink(t *testing.T) {
 }
 
 func TestValidateDevice(t *testing.T) {
-	valid := []string{
-		"/home",
-		"/home:/home",
-		"/home:/something/else",
-		"/with space",
-		"/home:/with space",
-		"relative:/absolute-path",
-		"hostPath:/containerPath:r",
-		"/hostPath:/containerPath:rw",
-		"/hostPath:/containerPath:mrw",
+	valid := map[string]string{
+		"/dev/sda":                     "/dev/sda:/dev/sda:rwm",
+		"/dev/sda:/dev/xvda":           "/dev/sda:/dev/xvda:rwm",
+		"/dev/sda:r":                   "/dev/sda:/dev/sda:r",
+		"/dev/sda:/dev/xvda:rw":        "/dev/sda:/dev/xvda:rw",
+		"/dev/sda:/dev/xvda:mrw":       "/dev/sda:/dev/xvda:mrw",
+		"/dev//sda/:/dev/./xvda":       "/dev/sda:/dev/xvda:rwm",
+		"/with space:/with space":      "/with space:/with space:rwm",
+		"/hostPath:/containerPath:wm":  "/hostPath:/containerPath:wm",
+		"/hostPath:/containerPath:mwr": "/hostPath:/containerPath:mwr",
 	}
 	invalid := map[string]string{
-		"":        "bad format for path: ",
-		"./":      "./ is not an absolute path",
-		"../":     "../ is not an absolute path",
-		"/:../":   "../ is not an absolute path",
-		"/:path":  "path is not an absolute path",
-		":":       "bad format for path: :",
-		"/tmp:":   " is not an absolute path",
-		":test":   "bad format for path: :test",
-		":/test":  "bad format for path: :/test",
-		"tmp:":    " is not an absolute path",
-		":test:":  "bad format for path: :test:",
-		"::":      "bad format for path: ::",
-		":::":     "bad format for path: :::",
-		"/tmp:::": "bad format for path: /tmp:::",
-		":/tmp::": "bad format for path: :/tmp::",
-		"path:ro": "ro is not an absolute path",
-		"path:rr": "rr is not an absolute path",
-		"a:/b:ro": "bad mode specified: ro",
-		"a:/b:rr": "bad mode specified: rr",
-	}
-
-	for _, path := range valid {
-		if _, err := ValidateDevice(path); err != nil {
-			t.Fatalf("ValidateDevice(`%q`) should succeed: error %q", path, err)
+		"":                   "bad format for device: , the format is host-path[:container-path][:permissions]",
+		":":                  "bad format for device: :, the format is host-path[:container-path][:permissions]",
+		":/test":             "bad format for device: :/test, the format is host-path[:container-path][:permissions]",
+		"/tmp:":              "bad format for device: /tmp:, the format is host-path[:container-path][:permissions]",
+		"/tmp::r":            "bad format for device: /tmp::r, the format is host-path[:container-path][:permissions]",
+		"::":                 "bad format for device: ::, the format is host-path[:container-path][:permissions]",
+		"/tmp:::":            "bad format for device: /tmp:::, the format is host-path[:container-path][:permissions]",
+		"/a:/b:/c:rw":        "bad format for device: /a:/b:/c:rw, the format is host-path[:container-path][:permissions]",
+		"./":                 "invalid device ./: host path ./ is not an absolute path",
+		"relative":           "invalid device relative: host path relative is not an absolute path",
+		"relative:/absolute": "invalid device relative:/absolute: host path relative is not an absolute path",
+		"/:../":              "invalid device /:../: container path ../ is not an absolute path",
+		"/dev/sda:ro":        "invalid device /dev/sda:ro: container path ro is not an absolute path",
+		"/a:/b:ro":           "invalid device /a:/b:ro: bad permissions \"ro\", permissions are a combination of r, w and m",
+		"/a:/b:rr":           "invalid device /a:/b:rr: bad permissions \"rr\", permissions are a combination of r, w and m",
+		"/a:/b:":             "invalid device /a:/b:: bad permissions \"\", permissions are a combination of r, w and m",
+	}
+
+	for device, expected := range valid {
+		if out, err := ValidateDevice(device); err != nil {
+			t.Fatalf("ValidateDevice(%q) should succeed: error %q", device, err)
+		} else if out != expected {
+			t.Fatalf("ValidateDevice(%q) should return %q, got %q", device, expected, out)
 		}
 	}
 
-	for path, expectedError := range invalid {
-		if _, err := ValidateDevice(path); err == nil {
-			t.Fatalf("ValidateDevice(`%q`) should have failed validation", path)
-		} else {
-			if err.Error() != expectedError {
-				t.Fatalf("ValidateDevice(`%q`) error should contain %q, got %q", path, expectedError, err.Error())
-			}
+	for device, expectedError := range invalid {
+		if _, err := ValidateDevice(device); err == nil {
+			t.Fatalf("ValidateDevice(%q) should have failed validation", device)
+		} else if err.Error() != expectedError {
+			t.Fatalf("ValidateDevice(%q) error should be %q, got %q", device, expectedError, err.Error())
 		}
 	}
 }
@@ -355,6 +356,40 @@ func TestValidateEnv(t *testing.T) {
 	}
 }
 
+func TestValidateLabelKey(t *testing.T) {
+	valid := []string{
+		"label",
+		"com.example.some-label",
+		"com.example.label1",
+		"LABEL1",
+		"1st-label",
+		"my_label",
+		"com.example.some_label",
+	}
+	for _, key := range valid {
+		if err := ValidateLabelKey(key); err != nil {
+			t.Fatalf("ValidateLabelKey(%q) should succeed: error %q", key, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"com.example.",
+		".com.example",
+		"-label",
+		"com..example",
+		"com.-example",
+		"some--label",
+		"some label",
+		"my_label.",
+	}
+	for _, key := range invalid {
+		if err := ValidateLabelKey(key); err == nil {
+			t.Fatalf("ValidateLabelKey(%q) should have failed validation", key)
+		}
+	}
+}
+
 func TestValidateLabel(t *testing.T) {
 	if _, err := ValidateLabel("label"); err == nil || err.Error() != "bad attribute format: label" {
 		t.Fatalf("Expected an error [bad attribute format: label], go %v", err)
@@ -0,0 +1,64 @@
+package term
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ASCII lists the supported control key names, indexed by their ASCII code.
+var ASCII = []string{
+	"ctrl-@",
+	"ctrl-a",
+	"ctrl-b",
+	"ctrl-c",
+	"ctrl-d",
+	"ctrl-e",
+	"ctrl-f",
+	"ctrl-g",
+	"ctrl-h",
+	"ctrl-i",
+	"ctrl-j",
+	"ctrl-k",
+	"ctrl-l",
+	"ctrl-m",
+	"ctrl-n",
+	"ctrl-o",
+	"ctrl-p",
+	"ctrl-q",
+	"ctrl-r",
+	"ctrl-s",
+	"ctrl-t",
+	"ctrl-u",
+	"ctrl-v",
+	"ctrl-w",
+	"ctrl-x",
+	"ctrl-y",
+	"ctrl-z",
+	"ctrl-[",
+	"ctrl-\\",
+	"ctrl-]",
+	"ctrl-^",
+	"ctrl-_",
+}
+
+// ToBytes converts a comma-separated key sequence, such as "ctrl-p,ctrl-q",
+// to the corresponding ASCII codes. A key is either a single character or
+// the name of a control key.
+func ToBytes(keys string) ([]byte, error) {
+	codes := []byte{}
+next:
+	for _, key := range strings.Split(keys, ",") {
+		if len(key) == 1 {
+			codes = append(codes, key[0])
+			continue
+		}
+		for code, ctrl := range ASCII {
+			if strings.ToLower(key) == ctrl {
+				codes = append(codes, byte(code))
+				continue next
+			}
+		}
+		return nil, fmt.Errorf("invalid key %q in key sequence %q: expected a single character or ctrl-[a-z@\\[\\]^_]", key, keys)
+	}
+	return codes, nil
+}
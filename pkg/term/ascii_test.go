@@ -0,0 +1,33 @@
+package term
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestToBytes(t *testing.T) {
+	cases := map[string][]byte{
+		"ctrl-p,ctrl-q":   {16, 17},
+		"ctrl-a,a":        {1, 'a'},
+		"CTRL-@":          {0},
+		"ctrl-\\,ctrl-_":  {28, 31},
+		"ctrl-[,x,ctrl-]": {27, 'x', 29},
+	}
+	for keys, expected := range cases {
+		codes, err := ToBytes(keys)
+		if err != nil {
+			t.Fatalf("%q: %v", keys, err)
+		}
+		if !bytes.Equal(codes, expected) {
+			t.Fatalf("%q: expected %v, got %v", keys, expected, codes)
+		}
+	}
+}
+
+func TestToBytesInvalid(t *testing.T) {
+	for _, keys := range []string{"", "ctrl-", "ctrl-1", "ctrl-p,,ctrl-q", "alt-x", "ab"} {
+		if codes, err := ToBytes(keys); err == nil {
+			t.Fatalf("%q: expected an error, got %v", keys, codes)
+		}
+	}
+}
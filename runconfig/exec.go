@@ -1,7 +1,11 @@
 package runconfig
 
 import (
+	"strings"
+
+	"github.com/docker/docker/opts"
 	flag "github.com/docker/docker/pkg/mflag"
+	"github.com/docker/docker/pkg/term"
 )
 
 // ExecConfig is a small subset of the Config struct that hold the configuration
@@ -15,6 +19,8 @@ type ExecConfig struct {
 	AttachStderr bool     // Attach the standard output
 	AttachStdout bool     // Attach the standard error
 	Detach       bool     // Execute in detach mode
+	DetachKeys   string   // Escape keys for detach
+	Env          []string // Environment variables, overriding the container's
 	Cmd          []string // Execution commands and args
 }
 
@@ -29,9 +35,14 @@ func ParseExec(cmd *flag.FlagSet, args []string) (*ExecConfig, error) {
 		flDetach     = cmd.Bool([]string{"d", "-detach"}, false, "Detached mode: run command in the background")
 		flUser       = cmd.String([]string{"u", "-user"}, "", "Username or UID (format: <name|uid>[:<group|gid>])")
 		flPrivileged = cmd.Bool([]string{"-privileged"}, false, "Give extended privileges to the command")
+		flDetachKeys = cmd.String([]string{"-detach-keys"}, "", "Override the key sequence for detaching from the command")
+		flEnv        = opts.NewListOpts(opts.ValidateEnv)
+		flEnvFile    = opts.NewListOpts(nil)
 		execCmd      []string
 		container    string
 	)
+	cmd.Var(&flEnv, []string{"e", "-env"}, "Set environment variables")
+	cmd.Var(&flEnvFile, []string{"-env-file"}, "Read in a file of environment variables")
 	cmd.Require(flag.Min, 2)
 	if err := cmd.ParseFlags(args, true); err != nil {
 		return nil, err
@@ -40,6 +51,17 @@ func ParseExec(cmd *flag.FlagSet, args []string) (*ExecConfig, error) {
 	parsedArgs := cmd.Args()
 	execCmd = parsedArgs[1:]
 
+	envVariables, err := readKVStrings(flEnvFile.GetAll(), flEnv.GetAll())
+	if err != nil {
+		return nil, err
+	}
+
+	if *flDetachKeys != "" {
+		if _, err := term.ToBytes(*flDetachKeys); err != nil {
+			return nil, err
+		}
+	}
+
 	execConfig := &ExecConfig{
 		User:       *flUser,
 		Privileged: *flPrivileged,
@@ -47,6 +69,8 @@ func ParseExec(cmd *flag.FlagSet, args []string) (*ExecConfig, error) {
 		Cmd:        execCmd,
 		Container:  container,
 		Detach:     *flDetach,
+		DetachKeys: *flDetachKeys,
+		Env:        dedupEnvVariables(envVariables),
 	}
 
 	// If -d is not set, attach to everything by default
@@ -60,3 +84,25 @@ func ParseExec(cmd *flag.FlagSet, args []string) (*ExecConfig, error) {
 
 	return execConfig, nil
 }
+
+// dedupEnvVariables removes the variables set more than once, keeping the
+// value set last at the position it was first set at.
+func dedupEnvVariables(env []string) []string {
+	if len(env) == 0 {
+		return nil
+	}
+	var (
+		deduped []string
+		index   = make(map[string]int, len(env))
+	)
+	for _, e := range env {
+		key := strings.SplitN(e, "=", 2)[0]
+		if i, exists := index[key]; exists {
+			deduped[i] = e
+			continue
+		}
+		index[key] = len(deduped)
+		deduped = append(deduped, e)
+	}
+	return deduped
+}
@@ -14,9 +14,10 @@ type arguments struct {
 
 func TestParseExec(t *testing.T) {
 	invalids := map[*arguments]error{
-		&arguments{[]string{"-unknown"}}: fmt.Errorf("flag provided but not defined: -unknown"),
-		&arguments{[]string{"-u"}}:       fmt.Errorf("flag needs an argument: -u"),
-		&arguments{[]string{"--user"}}:   fmt.Errorf("flag needs an argument: --user"),
+		&arguments{[]string{"-unknown"}}:                                        fmt.Errorf("flag provided but not defined: -unknown"),
+		&arguments{[]string{"-u"}}:                                              fmt.Errorf("flag needs an argument: -u"),
+		&arguments{[]string{"--user"}}:                                          fmt.Errorf("flag needs an argument: --user"),
+		&arguments{[]string{"--detach-keys", "ctrl-1", "container", "command"}}: fmt.Errorf("invalid key \"ctrl-1\" in key sequence \"ctrl-1\": expected a single character or ctrl-[a-z@\\[\\]^_]"),
 	}
 	valids := map[*arguments]*ExecConfig{
 		&arguments{
@@ -46,6 +47,17 @@ func TestParseExec(t *testing.T) {
 			Container:    "container",
 			Cmd:          []string{"command"},
 		},
+		&arguments{
+			[]string{"-it", "--detach-keys", "ctrl-a,a", "container", "command"},
+		}: {
+			AttachStdin:  true,
+			AttachStdout: true,
+			AttachStderr: true,
+			Tty:          true,
+			DetachKeys:   "ctrl-a,a",
+			Container:    "container",
+			Cmd:          []string{"command"},
+		},
 		&arguments{
 			[]string{"-d", "container", "command"},
 		}: {
@@ -117,6 +129,9 @@ func compareExecConfig(config1 *ExecConfig, config2 *ExecConfig) bool {
 	if config1.User != config2.User {
 		return false
 	}
+	if config1.DetachKeys != config2.DetachKeys {
+		return false
+	}
 	if len(config1.Cmd) != len(config2.Cmd) {
 		return false
 	}
@@ -125,5 +140,57 @@ func compareExecConfig(config1 *ExecConfig, config2 *ExecConfig) bool {
 			return false
 		}
 	}
+	if len(config1.Env) != len(config2.Env) {
+		return false
+	}
+	for index, value := range config1.Env {
+		if value != config2.Env[index] {
+			return false
+		}
+	}
 	return true
 }
+
+func TestParseExecEnvFile(t *testing.T) {
+	cmd := flag.NewFlagSet("exec", flag.ContinueOnError)
+	cmd.ShortUsage = func() {}
+	cmd.SetOutput(ioutil.Discard)
+	execConfig, err := ParseExec(cmd, []string{"--env-file=fixtures/valid.env", "-e", "ENV2=value2", "-e", "ENV1=override", "container", "command"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"ENV1=override", "ENV2=value2"}
+	if len(execConfig.Env) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, execConfig.Env)
+	}
+	for i, e := range expected {
+		if execConfig.Env[i] != e {
+			t.Fatalf("Expected %v, got %v", expected, execConfig.Env)
+		}
+	}
+}
+
+func TestParseExecEnvFileInvalid(t *testing.T) {
+	cmd := flag.NewFlagSet("exec", flag.ContinueOnError)
+	cmd.ShortUsage = func() {}
+	cmd.SetOutput(ioutil.Discard)
+	if _, err := ParseExec(cmd, []string{"--env-file=nonexistent", "container", "command"}); err == nil {
+		t.Fatal("Expected an error for a nonexistent env file")
+	}
+}
+
+func TestDedupEnvVariables(t *testing.T) {
+	env := dedupEnvVariables([]string{"A=1", "B=2", "A=3", "C", "B=4"})
+	expected := []string{"A=3", "B=4", "C"}
+	if len(env) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, env)
+	}
+	for i, e := range expected {
+		if env[i] != e {
+			t.Fatalf("Expected %v, got %v", expected, env)
+		}
+	}
+	if env := dedupEnvVariables(nil); env != nil {
+		t.Fatalf("Expected nil, got %v", env)
+	}
+}
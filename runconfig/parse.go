@@ -2,6 +2,7 @@ package runconfig
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 
@@ -41,6 +42,8 @@ var (
 	ErrConflictNetworkPublishPorts = fmt.Errorf("Conflicting options: port publishing and the container type network mode")
 	// ErrConflictNetworkExposePorts conflict between the expose option and the network mode
 	ErrConflictNetworkExposePorts = fmt.Errorf("Conflicting options: port exposing and the container type network mode")
+	// ErrConflictCPUsAndQuota conflict between --cpus and the CFS quota or period
+	ErrConflictCPUsAndQuota = fmt.Errorf("Conflicting options: --cpus and --cpu-quota or --cpu-period")
 )
 
 // Parse parses the specified args for the specified command and generates a Config,
@@ -52,6 +55,7 @@ func Parse(cmd *flag.FlagSet, args []string) (*Config, *HostConfig, *flag.FlagSe
 		flAttach            = opts.NewListOpts(opts.ValidateAttach)
 		flVolumes           = opts.NewListOpts(nil)
 		flTmpfs             = opts.NewListOpts(nil)
+		flMounts            = opts.NewMountOpt()
 		flBlkioWeightDevice = opts.NewWeightdeviceOpt(opts.ValidateWeightDevice)
 		flDeviceReadBps     = opts.NewThrottledeviceOpt(opts.ValidateThrottleBpsDevice)
 		flDeviceWriteBps    = opts.NewThrottledeviceOpt(opts.ValidateThrottleBpsDevice)
@@ -96,6 +100,7 @@ func Parse(cmd *flag.FlagSet, args []string) (*Config, *HostConfig, *flag.FlagSe
 		flCPUShares         = cmd.Int64([]string{"#c", "-cpu-shares"}, 0, "CPU shares (relative weight)")
 		flCPUPeriod         = cmd.Int64([]string{"-cpu-period"}, 0, "Limit CPU CFS (Completely Fair Scheduler) period")
 		flCPUQuota          = cmd.Int64([]string{"-cpu-quota"}, 0, "Limit CPU CFS (Completely Fair Scheduler) quota")
+		flCPUs              = cmd.String([]string{"-cpus"}, "", "Number of CPUs, as a CFS quota (e.g. 1.5)")
 		flCpusetCpus        = cmd.String([]string{"-cpuset-cpus"}, "", "CPUs in which to allow execution (0-3, 0,1)")
 		flCpusetMems        = cmd.String([]string{"-cpuset-mems"}, "", "MEMs in which to allow execution (0-3, 0,1)")
 		flBlkioWeight       = cmd.Uint16([]string{"-blkio-weight"}, 0, "Block IO (relative weight), between 10 and 1000")
@@ -119,6 +124,7 @@ func Parse(cmd *flag.FlagSet, args []string) (*Config, *HostConfig, *flag.FlagSe
 	cmd.Var(&flDeviceWriteBps, []string{"-device-write-bps"}, "Limit write rate (bytes per second) to a device")
 	cmd.Var(&flVolumes, []string{"v", "-volume"}, "Bind mount a volume")
 	cmd.Var(&flTmpfs, []string{"-tmpfs"}, "Mount a tmpfs directory")
+	cmd.Var(flMounts, []string{"-mount"}, "Attach a filesystem mount to the container")
 	cmd.Var(&flLinks, []string{"-link"}, "Add link to another container")
 	cmd.Var(&flDevices, []string{"-device"}, "Add a host device to the container")
 	cmd.Var(&flLabels, []string{"l", "-label"}, "Set meta data on a container")
@@ -204,6 +210,16 @@ func Parse(cmd *flag.FlagSet, args []string) (*Config, *HostConfig, *flag.FlagSe
 		}
 	}
 
+	cpuQuota, cpuPeriod := *flCPUQuota, *flCPUPeriod
+	if *flCPUs != "" {
+		if cpuQuota != 0 || cpuPeriod != 0 {
+			return nil, nil, cmd, ErrConflictCPUsAndQuota
+		}
+		if cpuQuota, cpuPeriod, err = ParseCPUs(*flCPUs); err != nil {
+			return nil, nil, cmd, err
+		}
+	}
+
 	swappiness := *flSwappiness
 	if swappiness != -1 && (swappiness < 0 || swappiness > 100) {
 		return nil, nil, cmd, fmt.Errorf("Invalid value: %d. Valid memory swappiness range is 0-100", swappiness)
@@ -228,6 +244,15 @@ func Parse(cmd *flag.FlagSet, args []string) (*Config, *HostConfig, *flag.FlagSe
 			flVolumes.Delete(bind)
 		}
 	}
+	// the --mount flags are added the same way, anonymous volumes don't have
+	// a bind and are only container volumes
+	for _, m := range flMounts.GetList() {
+		if bind := m.Bind(); bind != "" {
+			binds = append(binds, bind)
+			continue
+		}
+		flVolumes.Set(m.Target)
+	}
 
 	// Can't evaluate options passed into --tmpfs until we actually mount
 	tmpfs := make(map[string]string)
@@ -304,17 +329,24 @@ func Parse(cmd *flag.FlagSet, args []string) (*Config, *HostConfig, *flag.FlagSe
 		deviceMappings = append(deviceMappings, deviceMapping)
 	}
 
-	// collect all the environment variables for the container
+	// collect all the environment variables for the container, the env files
+	// in the order given, then the -e flags, the variable set last wins
 	envVariables, err := readKVStrings(flEnvFile.GetAll(), flEnv.GetAll())
 	if err != nil {
 		return nil, nil, cmd, err
 	}
+	envVariables = dedupEnvVariables(envVariables)
 
 	// collect all the labels for the container
 	labels, err := readKVStrings(flLabelsFile.GetAll(), flLabels.GetAll())
 	if err != nil {
 		return nil, nil, cmd, err
 	}
+	for _, label := range labels {
+		if err := opts.ValidateLabelKey(strings.SplitN(label, "=", 2)[0]); err != nil {
+			return nil, nil, cmd, err
+		}
+	}
 
 	ipcMode := IpcMode(*flIpcMode)
 	if !ipcMode.Valid() {
@@ -349,10 +381,10 @@ func Parse(cmd *flag.FlagSet, args []string) (*Config, *HostConfig, *flag.FlagSe
 		MemorySwappiness:    flSwappiness,
 		KernelMemory:        KernelMemory,
 		CPUShares:           *flCPUShares,
-		CPUPeriod:           *flCPUPeriod,
+		CPUPeriod:           cpuPeriod,
 		CpusetCpus:          *flCpusetCpus,
 		CpusetMems:          *flCpusetMems,
-		CPUQuota:            *flCPUQuota,
+		CPUQuota:            cpuQuota,
 		BlkioWeight:         *flBlkioWeight,
 		BlkioWeightDevice:   flBlkioWeightDevice.GetList(),
 		BlkioDeviceReadBps:  flDeviceReadBps.GetList(),
@@ -469,6 +501,28 @@ func parseLoggingOpts(loggingDriver string, loggingOpts []string) (map[string]st
 	return loggingOptsMap, nil
 }
 
+// cpusPeriod is the CFS period given with the quota of --cpus, the default
+// period of the kernel, in microseconds.
+const cpusPeriod = 100000
+
+// ParseCPUs returns the CFS quota and period, in microseconds, which limit a
+// container to the given number of CPUs, e.g. a quota of 150000 for 1.5 CPUs.
+// The smallest quota the kernel accepts is 1000, that is 0.01 CPUs.
+func ParseCPUs(cpus string) (quota, period int64, err error) {
+	value, err := strconv.ParseFloat(cpus, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) || value <= 0 {
+		return 0, 0, fmt.Errorf("%q is not a valid value for --cpus: must be a positive decimal", cpus)
+	}
+	if value > math.MaxInt64/cpusPeriod {
+		return 0, 0, fmt.Errorf("%q is not a valid value for --cpus: too many CPUs", cpus)
+	}
+	quota = int64(math.Floor(value*cpusPeriod + 0.5))
+	if quota < 1000 {
+		return 0, 0, fmt.Errorf("%q is not a valid value for --cpus: the minimum is 0.01", cpus)
+	}
+	return quota, cpusPeriod, nil
+}
+
 // ParseRestartPolicy returns the parsed policy or an error indicating what is incorrect
 func ParseRestartPolicy(policy string) (RestartPolicy, error) {
 	p := RestartPolicy{}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"reflect"
 	"runtime"
 	"strings"
 	"testing"
@@ -381,6 +382,25 @@ func TestParseWithMemorySwap(t *testing.T) {
 	}
 }
 
+func TestParseWithCPUs(t *testing.T) {
+	if _, hostconfig := mustParse(t, "--cpus=1.5"); hostconfig.CPUQuota != 150000 || hostconfig.CPUPeriod != 100000 {
+		t.Fatalf("Expected a quota of 150000 and a period of 100000, got %d and %d", hostconfig.CPUQuota, hostconfig.CPUPeriod)
+	}
+	if _, hostconfig := mustParse(t, "--cpus=0.01"); hostconfig.CPUQuota != 1000 {
+		t.Fatalf("Expected a quota of 1000, got %d", hostconfig.CPUQuota)
+	}
+	for _, cpus := range []string{"0", "-1", "abc", "NaN", "Inf", "0.001"} {
+		if _, _, err := parse(t, "--cpus="+cpus); err == nil || !strings.Contains(err.Error(), "is not a valid value for --cpus") {
+			t.Fatalf("Expected an error with --cpus=%s, got %v", cpus, err)
+		}
+	}
+	for _, flags := range []string{"--cpus=1 --cpu-quota=50000", "--cpus=1 --cpu-period=50000"} {
+		if _, _, err := parse(t, flags); err != ErrConflictCPUsAndQuota {
+			t.Fatalf("Expected %v with %s, got %v", ErrConflictCPUsAndQuota, flags, err)
+		}
+	}
+}
+
 func TestParseHostname(t *testing.T) {
 	hostname := "--hostname=hostname"
 	hostnameWithDomain := "--hostname=hostname.domainname"
@@ -449,6 +469,29 @@ func TestParseWithExpose(t *testing.T) {
 	}
 }
 
+func TestParseMounts(t *testing.T) {
+	config, hostConfig, _, err := parseRun([]string{
+		"--mount=type=bind,source=/src,target=/dst,readonly",
+		"--mount=type=volume,source=data,target=/data",
+		"--mount=target=/anonymous",
+		"img", "cmd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBinds := []string{"/src:/dst:ro", "data:/data"}
+	if !reflect.DeepEqual(hostConfig.Binds, expectedBinds) {
+		t.Fatalf("Expected binds %v, got %v", expectedBinds, hostConfig.Binds)
+	}
+	if _, exists := config.Volumes["/anonymous"]; len(config.Volumes) != 1 || !exists {
+		t.Fatalf("Expected the volume /anonymous, got %v", config.Volumes)
+	}
+
+	expectedError := `invalid value "type=bind,src=/src,target=/dst,mode=ro" for flag --mount: invalid mount type=bind,src=/src,target=/dst,mode=ro: unknown key mode`
+	if _, _, _, err := parseRun([]string{"--mount=type=bind,src=/src,target=/dst,mode=ro", "img", "cmd"}); err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error %q, got %v", expectedError, err)
+	}
+}
+
 func TestParseDevice(t *testing.T) {
 	valids := map[string]DeviceMapping{
 		"/dev/snd": {
@@ -612,6 +655,14 @@ func TestParseEnvfileVariables(t *testing.T) {
 	if len(config.Env) != 2 || config.Env[0] != "ENV1=value1" || config.Env[1] != "ENV2=value2" {
 		t.Fatalf("Expected a a config with [ENV1=value1 ENV2=value2], got %v", config.Env)
 	}
+	// the -e flags override the env files, the variable set last wins
+	config, _, _, err = parseRun([]string{"--env=ENV1=override", "--env-file=fixtures/valid.env", "--env=ENV2=value2", "--env=ENV2=value3", "img", "cmd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Env) != 2 || config.Env[0] != "ENV1=override" || config.Env[1] != "ENV2=value3" {
+		t.Fatalf("Expected a a config with [ENV1=override ENV2=value3], got %v", config.Env)
+	}
 }
 
 func TestParseLabelfileVariables(t *testing.T) {
@@ -638,6 +689,23 @@ func TestParseLabelfileVariables(t *testing.T) {
 	if len(config.Labels) != 2 || config.Labels["LABEL1"] != "value1" || config.Labels["LABEL2"] != "value2" {
 		t.Fatalf("Expected a a config with [LABEL1:value1 LABEL2:value2], got %v", config.Labels)
 	}
+	// the --label flags override the label files
+	config, _, _, err = parseRun([]string{"--label=LABEL1=override", "--label-file=fixtures/valid.label", "img", "cmd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Labels) != 1 || config.Labels["LABEL1"] != "override" {
+		t.Fatalf("Expected a a config with [LABEL1:override], got %v", config.Labels)
+	}
+	// the keys are validated
+	e = `invalid label key "com..example": keys consist of alphanumeric characters and underscores separated by single dots or dashes, e.g. com.example.some-label`
+	if _, _, _, err := parseRun([]string{"--label-file=fixtures/valid.label", "--label=com..example=value", "img", "cmd"}); err == nil || err.Error() != e {
+		t.Fatalf("Expected an error with message '%s', got %v", e, err)
+	}
+	e = "label key can't be empty"
+	if _, _, _, err := parseRun([]string{"--label==value", "img", "cmd"}); err == nil || err.Error() != e {
+		t.Fatalf("Expected an error with message '%s', got %v", e, err)
+	}
 }
 
 func TestParseEntryPoint(t *testing.T) {